@@ -11,6 +11,7 @@ import (
 	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
 	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
 	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/EngineerBetter/control-tower/iaas"
 	"github.com/EngineerBetter/control-tower/terraform"
 )
@@ -26,10 +27,14 @@ type AWSClient struct {
 	provider    iaas.Provider
 	boshCLI     boshcli.ICLI
 	versionFile []byte
+	recorder    events.Recorder
 }
 
 //NewAWSClient returns a AWS specific implementation of IClient
-func NewAWSClient(config config.ConfigView, outputs terraform.Outputs, workingdir workingdir.IClient, stdout, stderr io.Writer, provider iaas.Provider, boshCLI boshcli.ICLI, versionFile []byte) (IClient, error) {
+func NewAWSClient(config config.ConfigView, outputs terraform.Outputs, workingdir workingdir.IClient, stdout, stderr io.Writer, provider iaas.Provider, boshCLI boshcli.ICLI, versionFile []byte, recorder events.Recorder) (IClient, error) {
+	if recorder == nil {
+		recorder = events.NopRecorder{}
+	}
 	directorPublicIP, err := outputs.Get("DirectorPublicIP")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DirectorPublicIP from terraform outputs: [%v]", err)
@@ -78,6 +83,7 @@ func NewAWSClient(config config.ConfigView, outputs terraform.Outputs, workingdi
 		provider:    provider,
 		boshCLI:     boshCLI,
 		versionFile: versionFile,
+		recorder:    recorder,
 	}, nil
 }
 
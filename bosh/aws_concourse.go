@@ -7,11 +7,24 @@ import (
 	"os"
 	"strings"
 
+	"github.com/EngineerBetter/control-tower/config"
 	"github.com/EngineerBetter/control-tower/db"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/apparentlymart/go-cidr/cidr"
 )
 
-func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, error) {
+// DeployConcourse deploys only the Concourse manifest, leaving the director and its
+// cloud config as they are. It is used to apply changes such as the number of workers.
+func (client *AWSClient) DeployConcourse(creds []byte) ([]byte, error) {
+	err := client.recorder.Record(events.PhaseConcourseDeploy, func() error {
+		var err error
+		creds, err = client.deployConcourse(creds, false, false)
+		return err
+	})
+	return creds, err
+}
+
+func (client *AWSClient) deployConcourse(creds []byte, detach, dryRun bool) ([]byte, error) {
 
 	err := saveFilesToWorkingDir(client.workingdir, client.provider, creds)
 	if err != nil {
@@ -66,6 +79,10 @@ func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 		"influx_db_retention_period": client.config.GetInfluxDbRetention(),
 	}
 
+	if client.config.GetMetricsBackend() == config.MetricsBackendPrometheus {
+		vmap["prometheus_retention_period"] = client.config.GetPrometheusRetention()
+	}
+
 	flagFiles := []string{
 		client.workingdir.PathInWorkingDir(concourseManifestFilename),
 		"--vars-store",
@@ -117,8 +134,24 @@ func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseEphemeralWorkersFilename))
 	}
 
-	if client.config.MetricsIsDisabled() {
+	// The prometheus release is listed, and pinned, with every other release by the versions and shas files
+	if client.config.GetMetricsBackend() != config.MetricsBackendPrometheus {
+		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseNoPrometheusFilename))
+	}
+
+	switch client.config.GetMetricsBackend() {
+	case config.MetricsBackendNone:
 		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseNoMetricsFilename))
+	case config.MetricsBackendPrometheus:
+		flagFiles = append(flagFiles,
+			"--ops-file", client.workingdir.PathInWorkingDir(concoursePrometheusMetricsFilename),
+			"--vars-file", client.workingdir.PathInWorkingDir(concourseGrafanaPrometheusFilename))
+	}
+
+	// While the encryption key is being rotated, Concourse re-encrypts data encrypted with the old key on startup
+	if client.config.GetOldEncryptionKey() != "" {
+		vmap["atc_old_encryption_key"] = client.config.GetOldEncryptionKey()
+		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseOldEncryptionKeyFilename))
 	}
 
 	t, err1 := client.buildTagsYaml(vmap["project"], "concourse")
@@ -128,6 +161,10 @@ func (client *AWSClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 	vmap["tags"] = t
 	flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(extraTagsFilename))
 
+	if dryRun {
+		flagFiles = append(flagFiles, "--dry-run")
+	}
+
 	vs := vars(vmap)
 
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
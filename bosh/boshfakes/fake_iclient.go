@@ -8,6 +8,17 @@ import (
 )
 
 type FakeIClient struct {
+	CleanUpStub        func(bool) error
+	cleanUpMutex       sync.RWMutex
+	cleanUpArgsForCall []struct {
+		arg1 bool
+	}
+	cleanUpReturns struct {
+		result1 error
+	}
+	cleanUpReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CleanupStub        func() error
 	cleanupMutex       sync.RWMutex
 	cleanupArgsForCall []struct {
@@ -35,6 +46,17 @@ type FakeIClient struct {
 		result2 []byte
 		result3 error
 	}
+	DeleteOrphanedDiskStub        func(string) error
+	deleteOrphanedDiskMutex       sync.RWMutex
+	deleteOrphanedDiskArgsForCall []struct {
+		arg1 string
+	}
+	deleteOrphanedDiskReturns struct {
+		result1 error
+	}
+	deleteOrphanedDiskReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeployStub        func([]byte, []byte, bool) ([]byte, []byte, error)
 	deployMutex       sync.RWMutex
 	deployArgsForCall []struct {
@@ -52,6 +74,54 @@ type FakeIClient struct {
 		result2 []byte
 		result3 error
 	}
+	DeployConcourseStub        func([]byte) ([]byte, error)
+	deployConcourseMutex       sync.RWMutex
+	deployConcourseArgsForCall []struct {
+		arg1 []byte
+	}
+	deployConcourseReturns struct {
+		result1 []byte
+		result2 error
+	}
+	deployConcourseReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	DiagnosticsStub        func() (map[string][]byte, error)
+	diagnosticsMutex       sync.RWMutex
+	diagnosticsArgsForCall []struct {
+	}
+	diagnosticsReturns struct {
+		result1 map[string][]byte
+		result2 error
+	}
+	diagnosticsReturnsOnCall map[int]struct {
+		result1 map[string][]byte
+		result2 error
+	}
+	DiffStub        func([]byte) error
+	diffMutex       sync.RWMutex
+	diffArgsForCall []struct {
+		arg1 []byte
+	}
+	diffReturns struct {
+		result1 error
+	}
+	diffReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FetchLogsStub        func([]string, string) error
+	fetchLogsMutex       sync.RWMutex
+	fetchLogsArgsForCall []struct {
+		arg1 []string
+		arg2 string
+	}
+	fetchLogsReturns struct {
+		result1 error
+	}
+	fetchLogsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InstancesStub        func() ([]bosh.Instance, error)
 	instancesMutex       sync.RWMutex
 	instancesArgsForCall []struct {
@@ -76,6 +146,31 @@ type FakeIClient struct {
 		result1 []byte
 		result2 error
 	}
+	OrphanedDisksStub        func() ([]bosh.OrphanedDisk, error)
+	orphanedDisksMutex       sync.RWMutex
+	orphanedDisksArgsForCall []struct {
+	}
+	orphanedDisksReturns struct {
+		result1 []bosh.OrphanedDisk
+		result2 error
+	}
+	orphanedDisksReturnsOnCall map[int]struct {
+		result1 []bosh.OrphanedDisk
+		result2 error
+	}
+	PlanCleanUpStub        func(bool) (bosh.CleanUpPlan, error)
+	planCleanUpMutex       sync.RWMutex
+	planCleanUpArgsForCall []struct {
+		arg1 bool
+	}
+	planCleanUpReturns struct {
+		result1 bosh.CleanUpPlan
+		result2 error
+	}
+	planCleanUpReturnsOnCall map[int]struct {
+		result1 bosh.CleanUpPlan
+		result2 error
+	}
 	RecreateStub        func() error
 	recreateMutex       sync.RWMutex
 	recreateArgsForCall []struct {
@@ -86,10 +181,93 @@ type FakeIClient struct {
 	recreateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RecreateInstanceStub        func(string) error
+	recreateInstanceMutex       sync.RWMutex
+	recreateInstanceArgsForCall []struct {
+		arg1 string
+	}
+	recreateInstanceReturns struct {
+		result1 error
+	}
+	recreateInstanceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SSHStub        func(string) error
+	sSHMutex       sync.RWMutex
+	sSHArgsForCall []struct {
+		arg1 string
+	}
+	sSHReturns struct {
+		result1 error
+	}
+	sSHReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeIClient) CleanUp(arg1 bool) error {
+	fake.cleanUpMutex.Lock()
+	ret, specificReturn := fake.cleanUpReturnsOnCall[len(fake.cleanUpArgsForCall)]
+	fake.cleanUpArgsForCall = append(fake.cleanUpArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.CleanUpStub
+	fakeReturns := fake.cleanUpReturns
+	fake.recordInvocation("CleanUp", []interface{}{arg1})
+	fake.cleanUpMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) CleanUpCallCount() int {
+	fake.cleanUpMutex.RLock()
+	defer fake.cleanUpMutex.RUnlock()
+	return len(fake.cleanUpArgsForCall)
+}
+
+func (fake *FakeIClient) CleanUpCalls(stub func(bool) error) {
+	fake.cleanUpMutex.Lock()
+	defer fake.cleanUpMutex.Unlock()
+	fake.CleanUpStub = stub
+}
+
+func (fake *FakeIClient) CleanUpArgsForCall(i int) bool {
+	fake.cleanUpMutex.RLock()
+	defer fake.cleanUpMutex.RUnlock()
+	argsForCall := fake.cleanUpArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) CleanUpReturns(result1 error) {
+	fake.cleanUpMutex.Lock()
+	defer fake.cleanUpMutex.Unlock()
+	fake.CleanUpStub = nil
+	fake.cleanUpReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) CleanUpReturnsOnCall(i int, result1 error) {
+	fake.cleanUpMutex.Lock()
+	defer fake.cleanUpMutex.Unlock()
+	fake.CleanUpStub = nil
+	if fake.cleanUpReturnsOnCall == nil {
+		fake.cleanUpReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cleanUpReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIClient) Cleanup() error {
 	fake.cleanupMutex.Lock()
 	ret, specificReturn := fake.cleanupReturnsOnCall[len(fake.cleanupArgsForCall)]
@@ -222,6 +400,67 @@ func (fake *FakeIClient) CreateEnvReturnsOnCall(i int, result1 []byte, result2 [
 	}{result1, result2, result3}
 }
 
+func (fake *FakeIClient) DeleteOrphanedDisk(arg1 string) error {
+	fake.deleteOrphanedDiskMutex.Lock()
+	ret, specificReturn := fake.deleteOrphanedDiskReturnsOnCall[len(fake.deleteOrphanedDiskArgsForCall)]
+	fake.deleteOrphanedDiskArgsForCall = append(fake.deleteOrphanedDiskArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteOrphanedDiskStub
+	fakeReturns := fake.deleteOrphanedDiskReturns
+	fake.recordInvocation("DeleteOrphanedDisk", []interface{}{arg1})
+	fake.deleteOrphanedDiskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) DeleteOrphanedDiskCallCount() int {
+	fake.deleteOrphanedDiskMutex.RLock()
+	defer fake.deleteOrphanedDiskMutex.RUnlock()
+	return len(fake.deleteOrphanedDiskArgsForCall)
+}
+
+func (fake *FakeIClient) DeleteOrphanedDiskCalls(stub func(string) error) {
+	fake.deleteOrphanedDiskMutex.Lock()
+	defer fake.deleteOrphanedDiskMutex.Unlock()
+	fake.DeleteOrphanedDiskStub = stub
+}
+
+func (fake *FakeIClient) DeleteOrphanedDiskArgsForCall(i int) string {
+	fake.deleteOrphanedDiskMutex.RLock()
+	defer fake.deleteOrphanedDiskMutex.RUnlock()
+	argsForCall := fake.deleteOrphanedDiskArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) DeleteOrphanedDiskReturns(result1 error) {
+	fake.deleteOrphanedDiskMutex.Lock()
+	defer fake.deleteOrphanedDiskMutex.Unlock()
+	fake.DeleteOrphanedDiskStub = nil
+	fake.deleteOrphanedDiskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) DeleteOrphanedDiskReturnsOnCall(i int, result1 error) {
+	fake.deleteOrphanedDiskMutex.Lock()
+	defer fake.deleteOrphanedDiskMutex.Unlock()
+	fake.DeleteOrphanedDiskStub = nil
+	if fake.deleteOrphanedDiskReturnsOnCall == nil {
+		fake.deleteOrphanedDiskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteOrphanedDiskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIClient) Deploy(arg1 []byte, arg2 []byte, arg3 bool) ([]byte, []byte, error) {
 	var arg1Copy []byte
 	if arg1 != nil {
@@ -301,6 +540,264 @@ func (fake *FakeIClient) DeployReturnsOnCall(i int, result1 []byte, result2 []by
 	}{result1, result2, result3}
 }
 
+func (fake *FakeIClient) DeployConcourse(arg1 []byte) ([]byte, error) {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.deployConcourseMutex.Lock()
+	ret, specificReturn := fake.deployConcourseReturnsOnCall[len(fake.deployConcourseArgsForCall)]
+	fake.deployConcourseArgsForCall = append(fake.deployConcourseArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	stub := fake.DeployConcourseStub
+	fakeReturns := fake.deployConcourseReturns
+	fake.recordInvocation("DeployConcourse", []interface{}{arg1Copy})
+	fake.deployConcourseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIClient) DeployConcourseCallCount() int {
+	fake.deployConcourseMutex.RLock()
+	defer fake.deployConcourseMutex.RUnlock()
+	return len(fake.deployConcourseArgsForCall)
+}
+
+func (fake *FakeIClient) DeployConcourseCalls(stub func([]byte) ([]byte, error)) {
+	fake.deployConcourseMutex.Lock()
+	defer fake.deployConcourseMutex.Unlock()
+	fake.DeployConcourseStub = stub
+}
+
+func (fake *FakeIClient) DeployConcourseArgsForCall(i int) []byte {
+	fake.deployConcourseMutex.RLock()
+	defer fake.deployConcourseMutex.RUnlock()
+	argsForCall := fake.deployConcourseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) DeployConcourseReturns(result1 []byte, result2 error) {
+	fake.deployConcourseMutex.Lock()
+	defer fake.deployConcourseMutex.Unlock()
+	fake.DeployConcourseStub = nil
+	fake.deployConcourseReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) DeployConcourseReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.deployConcourseMutex.Lock()
+	defer fake.deployConcourseMutex.Unlock()
+	fake.DeployConcourseStub = nil
+	if fake.deployConcourseReturnsOnCall == nil {
+		fake.deployConcourseReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.deployConcourseReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) Diagnostics() (map[string][]byte, error) {
+	fake.diagnosticsMutex.Lock()
+	ret, specificReturn := fake.diagnosticsReturnsOnCall[len(fake.diagnosticsArgsForCall)]
+	fake.diagnosticsArgsForCall = append(fake.diagnosticsArgsForCall, struct {
+	}{})
+	stub := fake.DiagnosticsStub
+	fakeReturns := fake.diagnosticsReturns
+	fake.recordInvocation("Diagnostics", []interface{}{})
+	fake.diagnosticsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIClient) DiagnosticsCallCount() int {
+	fake.diagnosticsMutex.RLock()
+	defer fake.diagnosticsMutex.RUnlock()
+	return len(fake.diagnosticsArgsForCall)
+}
+
+func (fake *FakeIClient) DiagnosticsCalls(stub func() (map[string][]byte, error)) {
+	fake.diagnosticsMutex.Lock()
+	defer fake.diagnosticsMutex.Unlock()
+	fake.DiagnosticsStub = stub
+}
+
+func (fake *FakeIClient) DiagnosticsReturns(result1 map[string][]byte, result2 error) {
+	fake.diagnosticsMutex.Lock()
+	defer fake.diagnosticsMutex.Unlock()
+	fake.DiagnosticsStub = nil
+	fake.diagnosticsReturns = struct {
+		result1 map[string][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) DiagnosticsReturnsOnCall(i int, result1 map[string][]byte, result2 error) {
+	fake.diagnosticsMutex.Lock()
+	defer fake.diagnosticsMutex.Unlock()
+	fake.DiagnosticsStub = nil
+	if fake.diagnosticsReturnsOnCall == nil {
+		fake.diagnosticsReturnsOnCall = make(map[int]struct {
+			result1 map[string][]byte
+			result2 error
+		})
+	}
+	fake.diagnosticsReturnsOnCall[i] = struct {
+		result1 map[string][]byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) Diff(arg1 []byte) error {
+	var arg1Copy []byte
+	if arg1 != nil {
+		arg1Copy = make([]byte, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.diffMutex.Lock()
+	ret, specificReturn := fake.diffReturnsOnCall[len(fake.diffArgsForCall)]
+	fake.diffArgsForCall = append(fake.diffArgsForCall, struct {
+		arg1 []byte
+	}{arg1Copy})
+	stub := fake.DiffStub
+	fakeReturns := fake.diffReturns
+	fake.recordInvocation("Diff", []interface{}{arg1Copy})
+	fake.diffMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) DiffCallCount() int {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	return len(fake.diffArgsForCall)
+}
+
+func (fake *FakeIClient) DiffCalls(stub func([]byte) error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = stub
+}
+
+func (fake *FakeIClient) DiffArgsForCall(i int) []byte {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	argsForCall := fake.diffArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) DiffReturns(result1 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	fake.diffReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) DiffReturnsOnCall(i int, result1 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	if fake.diffReturnsOnCall == nil {
+		fake.diffReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.diffReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) FetchLogs(arg1 []string, arg2 string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.fetchLogsMutex.Lock()
+	ret, specificReturn := fake.fetchLogsReturnsOnCall[len(fake.fetchLogsArgsForCall)]
+	fake.fetchLogsArgsForCall = append(fake.fetchLogsArgsForCall, struct {
+		arg1 []string
+		arg2 string
+	}{arg1Copy, arg2})
+	stub := fake.FetchLogsStub
+	fakeReturns := fake.fetchLogsReturns
+	fake.recordInvocation("FetchLogs", []interface{}{arg1Copy, arg2})
+	fake.fetchLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) FetchLogsCallCount() int {
+	fake.fetchLogsMutex.RLock()
+	defer fake.fetchLogsMutex.RUnlock()
+	return len(fake.fetchLogsArgsForCall)
+}
+
+func (fake *FakeIClient) FetchLogsCalls(stub func([]string, string) error) {
+	fake.fetchLogsMutex.Lock()
+	defer fake.fetchLogsMutex.Unlock()
+	fake.FetchLogsStub = stub
+}
+
+func (fake *FakeIClient) FetchLogsArgsForCall(i int) ([]string, string) {
+	fake.fetchLogsMutex.RLock()
+	defer fake.fetchLogsMutex.RUnlock()
+	argsForCall := fake.fetchLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeIClient) FetchLogsReturns(result1 error) {
+	fake.fetchLogsMutex.Lock()
+	defer fake.fetchLogsMutex.Unlock()
+	fake.FetchLogsStub = nil
+	fake.fetchLogsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) FetchLogsReturnsOnCall(i int, result1 error) {
+	fake.fetchLogsMutex.Lock()
+	defer fake.fetchLogsMutex.Unlock()
+	fake.FetchLogsStub = nil
+	if fake.fetchLogsReturnsOnCall == nil {
+		fake.fetchLogsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.fetchLogsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIClient) Instances() ([]bosh.Instance, error) {
 	fake.instancesMutex.Lock()
 	ret, specificReturn := fake.instancesReturnsOnCall[len(fake.instancesArgsForCall)]
@@ -413,6 +910,126 @@ func (fake *FakeIClient) LocksReturnsOnCall(i int, result1 []byte, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeIClient) OrphanedDisks() ([]bosh.OrphanedDisk, error) {
+	fake.orphanedDisksMutex.Lock()
+	ret, specificReturn := fake.orphanedDisksReturnsOnCall[len(fake.orphanedDisksArgsForCall)]
+	fake.orphanedDisksArgsForCall = append(fake.orphanedDisksArgsForCall, struct {
+	}{})
+	stub := fake.OrphanedDisksStub
+	fakeReturns := fake.orphanedDisksReturns
+	fake.recordInvocation("OrphanedDisks", []interface{}{})
+	fake.orphanedDisksMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIClient) OrphanedDisksCallCount() int {
+	fake.orphanedDisksMutex.RLock()
+	defer fake.orphanedDisksMutex.RUnlock()
+	return len(fake.orphanedDisksArgsForCall)
+}
+
+func (fake *FakeIClient) OrphanedDisksCalls(stub func() ([]bosh.OrphanedDisk, error)) {
+	fake.orphanedDisksMutex.Lock()
+	defer fake.orphanedDisksMutex.Unlock()
+	fake.OrphanedDisksStub = stub
+}
+
+func (fake *FakeIClient) OrphanedDisksReturns(result1 []bosh.OrphanedDisk, result2 error) {
+	fake.orphanedDisksMutex.Lock()
+	defer fake.orphanedDisksMutex.Unlock()
+	fake.OrphanedDisksStub = nil
+	fake.orphanedDisksReturns = struct {
+		result1 []bosh.OrphanedDisk
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) OrphanedDisksReturnsOnCall(i int, result1 []bosh.OrphanedDisk, result2 error) {
+	fake.orphanedDisksMutex.Lock()
+	defer fake.orphanedDisksMutex.Unlock()
+	fake.OrphanedDisksStub = nil
+	if fake.orphanedDisksReturnsOnCall == nil {
+		fake.orphanedDisksReturnsOnCall = make(map[int]struct {
+			result1 []bosh.OrphanedDisk
+			result2 error
+		})
+	}
+	fake.orphanedDisksReturnsOnCall[i] = struct {
+		result1 []bosh.OrphanedDisk
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) PlanCleanUp(arg1 bool) (bosh.CleanUpPlan, error) {
+	fake.planCleanUpMutex.Lock()
+	ret, specificReturn := fake.planCleanUpReturnsOnCall[len(fake.planCleanUpArgsForCall)]
+	fake.planCleanUpArgsForCall = append(fake.planCleanUpArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	stub := fake.PlanCleanUpStub
+	fakeReturns := fake.planCleanUpReturns
+	fake.recordInvocation("PlanCleanUp", []interface{}{arg1})
+	fake.planCleanUpMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIClient) PlanCleanUpCallCount() int {
+	fake.planCleanUpMutex.RLock()
+	defer fake.planCleanUpMutex.RUnlock()
+	return len(fake.planCleanUpArgsForCall)
+}
+
+func (fake *FakeIClient) PlanCleanUpCalls(stub func(bool) (bosh.CleanUpPlan, error)) {
+	fake.planCleanUpMutex.Lock()
+	defer fake.planCleanUpMutex.Unlock()
+	fake.PlanCleanUpStub = stub
+}
+
+func (fake *FakeIClient) PlanCleanUpArgsForCall(i int) bool {
+	fake.planCleanUpMutex.RLock()
+	defer fake.planCleanUpMutex.RUnlock()
+	argsForCall := fake.planCleanUpArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) PlanCleanUpReturns(result1 bosh.CleanUpPlan, result2 error) {
+	fake.planCleanUpMutex.Lock()
+	defer fake.planCleanUpMutex.Unlock()
+	fake.PlanCleanUpStub = nil
+	fake.planCleanUpReturns = struct {
+		result1 bosh.CleanUpPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) PlanCleanUpReturnsOnCall(i int, result1 bosh.CleanUpPlan, result2 error) {
+	fake.planCleanUpMutex.Lock()
+	defer fake.planCleanUpMutex.Unlock()
+	fake.PlanCleanUpStub = nil
+	if fake.planCleanUpReturnsOnCall == nil {
+		fake.planCleanUpReturnsOnCall = make(map[int]struct {
+			result1 bosh.CleanUpPlan
+			result2 error
+		})
+	}
+	fake.planCleanUpReturnsOnCall[i] = struct {
+		result1 bosh.CleanUpPlan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIClient) Recreate() error {
 	fake.recreateMutex.Lock()
 	ret, specificReturn := fake.recreateReturnsOnCall[len(fake.recreateArgsForCall)]
@@ -466,21 +1083,163 @@ func (fake *FakeIClient) RecreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIClient) RecreateInstance(arg1 string) error {
+	fake.recreateInstanceMutex.Lock()
+	ret, specificReturn := fake.recreateInstanceReturnsOnCall[len(fake.recreateInstanceArgsForCall)]
+	fake.recreateInstanceArgsForCall = append(fake.recreateInstanceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RecreateInstanceStub
+	fakeReturns := fake.recreateInstanceReturns
+	fake.recordInvocation("RecreateInstance", []interface{}{arg1})
+	fake.recreateInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) RecreateInstanceCallCount() int {
+	fake.recreateInstanceMutex.RLock()
+	defer fake.recreateInstanceMutex.RUnlock()
+	return len(fake.recreateInstanceArgsForCall)
+}
+
+func (fake *FakeIClient) RecreateInstanceCalls(stub func(string) error) {
+	fake.recreateInstanceMutex.Lock()
+	defer fake.recreateInstanceMutex.Unlock()
+	fake.RecreateInstanceStub = stub
+}
+
+func (fake *FakeIClient) RecreateInstanceArgsForCall(i int) string {
+	fake.recreateInstanceMutex.RLock()
+	defer fake.recreateInstanceMutex.RUnlock()
+	argsForCall := fake.recreateInstanceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) RecreateInstanceReturns(result1 error) {
+	fake.recreateInstanceMutex.Lock()
+	defer fake.recreateInstanceMutex.Unlock()
+	fake.RecreateInstanceStub = nil
+	fake.recreateInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) RecreateInstanceReturnsOnCall(i int, result1 error) {
+	fake.recreateInstanceMutex.Lock()
+	defer fake.recreateInstanceMutex.Unlock()
+	fake.RecreateInstanceStub = nil
+	if fake.recreateInstanceReturnsOnCall == nil {
+		fake.recreateInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recreateInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) SSH(arg1 string) error {
+	fake.sSHMutex.Lock()
+	ret, specificReturn := fake.sSHReturnsOnCall[len(fake.sSHArgsForCall)]
+	fake.sSHArgsForCall = append(fake.sSHArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SSHStub
+	fakeReturns := fake.sSHReturns
+	fake.recordInvocation("SSH", []interface{}{arg1})
+	fake.sSHMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) SSHCallCount() int {
+	fake.sSHMutex.RLock()
+	defer fake.sSHMutex.RUnlock()
+	return len(fake.sSHArgsForCall)
+}
+
+func (fake *FakeIClient) SSHCalls(stub func(string) error) {
+	fake.sSHMutex.Lock()
+	defer fake.sSHMutex.Unlock()
+	fake.SSHStub = stub
+}
+
+func (fake *FakeIClient) SSHArgsForCall(i int) string {
+	fake.sSHMutex.RLock()
+	defer fake.sSHMutex.RUnlock()
+	argsForCall := fake.sSHArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) SSHReturns(result1 error) {
+	fake.sSHMutex.Lock()
+	defer fake.sSHMutex.Unlock()
+	fake.SSHStub = nil
+	fake.sSHReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) SSHReturnsOnCall(i int, result1 error) {
+	fake.sSHMutex.Lock()
+	defer fake.sSHMutex.Unlock()
+	fake.SSHStub = nil
+	if fake.sSHReturnsOnCall == nil {
+		fake.sSHReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sSHReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.cleanUpMutex.RLock()
+	defer fake.cleanUpMutex.RUnlock()
 	fake.cleanupMutex.RLock()
 	defer fake.cleanupMutex.RUnlock()
 	fake.createEnvMutex.RLock()
 	defer fake.createEnvMutex.RUnlock()
+	fake.deleteOrphanedDiskMutex.RLock()
+	defer fake.deleteOrphanedDiskMutex.RUnlock()
 	fake.deployMutex.RLock()
 	defer fake.deployMutex.RUnlock()
+	fake.deployConcourseMutex.RLock()
+	defer fake.deployConcourseMutex.RUnlock()
+	fake.diagnosticsMutex.RLock()
+	defer fake.diagnosticsMutex.RUnlock()
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	fake.fetchLogsMutex.RLock()
+	defer fake.fetchLogsMutex.RUnlock()
 	fake.instancesMutex.RLock()
 	defer fake.instancesMutex.RUnlock()
 	fake.locksMutex.RLock()
 	defer fake.locksMutex.RUnlock()
+	fake.orphanedDisksMutex.RLock()
+	defer fake.orphanedDisksMutex.RUnlock()
+	fake.planCleanUpMutex.RLock()
+	defer fake.planCleanUpMutex.RUnlock()
 	fake.recreateMutex.RLock()
 	defer fake.recreateMutex.RUnlock()
+	fake.recreateInstanceMutex.RLock()
+	defer fake.recreateInstanceMutex.RUnlock()
+	fake.sSHMutex.RLock()
+	defer fake.sSHMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,156 @@
+package bosh
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+)
+
+// OrphanedDisk is a persistent disk the director kept after the instance it belonged to was deleted
+type OrphanedDisk struct {
+	CID        string
+	Deployment string
+	Instance   string
+	SizeMB     uint64
+	OrphanedAt time.Time
+}
+
+// CleanUpPlan is what `bosh clean-up` would delete
+type CleanUpPlan struct {
+	Releases  []string
+	Stemcells []string
+}
+
+// Tables in the order `bosh clean-up --dry-run` prints them
+const (
+	cleanUpReleasesTable  = 0
+	cleanUpStemcellsTable = 1
+)
+
+type cleanUpRow struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// cleanUpFlags always keeps orphaned disks, which are only deleted by age with DeleteOrphanedDisk
+func cleanUpFlags(all bool) []string {
+	flags := []string{"--keep-orphaned-disks"}
+	if all {
+		flags = append([]string{"--all"}, flags...)
+	}
+	return flags
+}
+
+func planCleanUp(boshCLI boshcli.ICLI, ip, password, ca string, all bool) (CleanUpPlan, error) {
+	output := new(bytes.Buffer)
+	flags := append(cleanUpFlags(all), "--dry-run", "--json")
+	if err := boshCLI.RunAuthenticatedCommand("clean-up", ip, password, ca, false, output, flags...); err != nil {
+		return CleanUpPlan{}, fmt.Errorf("Error [%s] running `bosh clean-up --dry-run`. stdout: [%s]", err, output.String())
+	}
+
+	jsonOutput := struct {
+		Tables []struct {
+			Rows []cleanUpRow `json:"Rows"`
+		} `json:"Tables"`
+	}{}
+	if err := json.NewDecoder(output).Decode(&jsonOutput); err != nil {
+		return CleanUpPlan{}, err
+	}
+
+	var plan CleanUpPlan
+	for i, table := range jsonOutput.Tables {
+		for _, row := range table.Rows {
+			switch i {
+			case cleanUpReleasesTable:
+				plan.Releases = append(plan.Releases, row.Name+"/"+row.Version)
+			case cleanUpStemcellsTable:
+				plan.Stemcells = append(plan.Stemcells, row.Name+"/"+row.Version)
+			}
+		}
+	}
+	return plan, nil
+}
+
+func cleanUp(boshCLI boshcli.ICLI, ip, password, ca string, stdout io.Writer, all bool) error {
+	if err := boshCLI.RunAuthenticatedCommand("clean-up", ip, password, ca, false, stdout, cleanUpFlags(all)...); err != nil {
+		return fmt.Errorf("Error [%s] running `bosh clean-up`", err)
+	}
+	return nil
+}
+
+func orphanedDisks(boshCLI boshcli.ICLI, ip, password, ca string) ([]OrphanedDisk, error) {
+	output := new(bytes.Buffer)
+	if err := boshCLI.RunAuthenticatedCommand("disks", ip, password, ca, false, output, "--orphaned", "--json"); err != nil {
+		return nil, fmt.Errorf("Error [%s] running `bosh disks --orphaned`. stdout: [%s]", err, output.String())
+	}
+
+	jsonOutput := struct {
+		Tables []struct {
+			Rows []struct {
+				DiskCID    string `json:"disk_cid"`
+				Size       string `json:"size"`
+				Deployment string `json:"deployment"`
+				Instance   string `json:"instance"`
+				OrphanedAt string `json:"orphaned_at"`
+			} `json:"Rows"`
+		} `json:"Tables"`
+	}{}
+	if err := json.NewDecoder(output).Decode(&jsonOutput); err != nil {
+		return nil, err
+	}
+
+	disks := []OrphanedDisk{}
+	for _, table := range jsonOutput.Tables {
+		for _, row := range table.Rows {
+			size, err := parseSizeMB(row.Size)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the size of orphaned disk %s: [%v]", row.DiskCID, err)
+			}
+			orphanedAt, err := time.Parse(time.UnixDate, row.OrphanedAt)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse when disk %s was orphaned: [%v]", row.DiskCID, err)
+			}
+			disks = append(disks, OrphanedDisk{
+				CID:        row.DiskCID,
+				Deployment: row.Deployment,
+				Instance:   row.Instance,
+				SizeMB:     size,
+				OrphanedAt: orphanedAt,
+			})
+		}
+	}
+	return disks, nil
+}
+
+func deleteOrphanedDisk(boshCLI boshcli.ICLI, ip, password, ca string, stdout io.Writer, cid string) error {
+	if err := boshCLI.RunAuthenticatedCommand("delete-disk", ip, password, ca, false, stdout, cid); err != nil {
+		return fmt.Errorf("Error [%s] running `bosh delete-disk %s`", err, cid)
+	}
+	return nil
+}
+
+// parseSizeMB parses a size as printed by the BOSH CLI, e.g. 10 GiB, into megabytes
+func parseSizeMB(size string) (uint64, error) {
+	var value float64
+	var unit string
+	if _, err := fmt.Sscanf(size, "%g %s", &value, &unit); err != nil {
+		return 0, fmt.Errorf("invalid size [%s]", size)
+	}
+
+	multipliers := map[string]float64{
+		"B":   1.0 / (1024 * 1024),
+		"KiB": 1.0 / 1024,
+		"MiB": 1,
+		"GiB": 1024,
+		"TiB": 1024 * 1024,
+	}
+	multiplier, ok := multipliers[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid size [%s]", size)
+	}
+	return uint64(value*multiplier + 0.5), nil
+}
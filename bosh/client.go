@@ -10,6 +10,7 @@ import (
 	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
 	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
 	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/EngineerBetter/control-tower/iaas"
 	"github.com/EngineerBetter/control-tower/terraform"
 	"github.com/EngineerBetter/control-tower/util"
@@ -27,11 +28,21 @@ const CredsFilename = "director-creds.yml"
 // IClient is a client for performing bosh-init commands
 type IClient interface {
 	Deploy([]byte, []byte, bool) ([]byte, []byte, error)
+	Diff([]byte) error
+	DeployConcourse([]byte) ([]byte, error)
 	Cleanup() error
 	Instances() ([]Instance, error)
 	CreateEnv([]byte, []byte, string) ([]byte, []byte, error)
 	Recreate() error
+	RecreateInstance(string) error
+	SSH(string) error
+	FetchLogs(instanceGroups []string, dir string) error
+	Diagnostics() (map[string][]byte, error)
 	Locks() ([]byte, error)
+	PlanCleanUp(all bool) (CleanUpPlan, error)
+	CleanUp(all bool) error
+	OrphanedDisks() ([]OrphanedDisk, error)
+	DeleteOrphanedDisk(cid string) error
 }
 
 // Instance represents a vm deployed by BOSH
@@ -42,10 +53,10 @@ type Instance struct {
 }
 
 // ClientFactory creates a new IClient
-type ClientFactory func(config config.ConfigView, outputs terraform.Outputs, stdout, stderr io.Writer, provider iaas.Provider, versionFile []byte) (IClient, error)
+type ClientFactory func(config config.ConfigView, outputs terraform.Outputs, stdout, stderr io.Writer, provider iaas.Provider, versionFile []byte, recorder events.Recorder) (IClient, error)
 
 //New returns an IAAS specific implementation of BOSH client
-func New(config config.ConfigView, outputs terraform.Outputs, stdout, stderr io.Writer, provider iaas.Provider, versionFile []byte) (IClient, error) {
+func New(config config.ConfigView, outputs terraform.Outputs, stdout, stderr io.Writer, provider iaas.Provider, versionFile []byte, recorder events.Recorder) (IClient, error) {
 	workingdir, err := workingdir.New()
 	if err != nil {
 		return nil, err
@@ -67,9 +78,9 @@ func New(config config.ConfigView, outputs terraform.Outputs, stdout, stderr io.
 
 	switch provider.IAAS() {
 	case iaas.AWS:
-		return NewAWSClient(config, outputs, workingdir, stdout, stderr, provider, boshCLI, versionFile)
+		return NewAWSClient(config, outputs, workingdir, stdout, stderr, provider, boshCLI, versionFile, recorder)
 	case iaas.GCP:
-		return NewGCPClient(config, outputs, workingdir, stdout, stderr, provider, boshCLI, versionFile)
+		return NewGCPClient(config, outputs, workingdir, stdout, stderr, provider, boshCLI, versionFile, recorder)
 	}
 	return nil, fmt.Errorf("IAAS not supported: %s", provider.IAAS())
 }
@@ -118,6 +129,21 @@ func instances(boshCLI boshcli.ICLI, ip, password, ca string) ([]Instance, error
 	return instances, nil
 }
 
+func recreateInstance(boshCLI boshcli.ICLI, ip, password, ca string, stdout io.Writer, instance string) error {
+	if err := boshCLI.RunAuthenticatedCommand(
+		"recreate",
+		ip,
+		password,
+		ca,
+		false,
+		stdout,
+		instance,
+	); err != nil {
+		return fmt.Errorf("Error [%s] running `bosh recreate %s`", err, instance)
+	}
+	return nil
+}
+
 func saveFilesToWorkingDir(workingdir workingdir.IClient, provider iaas.Provider, creds []byte) error {
 	concourseVersionsContents, _ := provider.Choose(iaas.Choice{
 		AWS: awsConcourseVersions,
@@ -133,6 +159,7 @@ func saveFilesToWorkingDir(workingdir workingdir.IClient, provider iaas.Provider
 		concourseSHAsFilename:                 concourseSHAsContents,
 		concourseManifestFilename:             concourseManifestContents,
 		concourseGrafanaFilename:              concourseGrafana,
+		concourseGrafanaPrometheusFilename:    concourseGrafanaPrometheus,
 		concourseBitBucketAuthFilename:        concourseBitBucketAuth,
 		concourseGitHubAuthFilename:           concourseGitHubAuth,
 		concourseGitHubEnterpriseAuthFilename: concourseGithubEnterpriseAuth,
@@ -140,6 +167,9 @@ func saveFilesToWorkingDir(workingdir workingdir.IClient, provider iaas.Provider
 		concourseMicrosoftAuthFilename:        concourseMicrosoftAuth,
 		concourseEphemeralWorkersFilename:     concourseEphemeralWorkers,
 		concourseNoMetricsFilename:            concourseNoMetrics,
+		concoursePrometheusMetricsFilename:    concoursePrometheusMetrics,
+		concourseNoPrometheusFilename:         concourseNoPrometheus,
+		concourseOldEncryptionKeyFilename:     concourseOldEncryptionKey,
 		credsFilename:                         creds,
 		extraTagsFilename:                     extraTags,
 	}
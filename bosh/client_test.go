@@ -5,6 +5,8 @@ import (
 	_ "embed"
 	"errors"
 	"io"
+	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -61,7 +63,7 @@ var _ = Describe("Client", func() {
 				})
 
 				It("returns an AWSClient", func() {
-					client, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile)
+					client, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile, nil)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(client).To(BeAssignableToTypeOf(&bosh.AWSClient{}))
 				})
@@ -73,7 +75,7 @@ var _ = Describe("Client", func() {
 				})
 
 				It("returns an appropriate error", func() {
-					_, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile)
+					_, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile, nil)
 					Expect(err.Error()).To(HavePrefix("failed to determine BOSH CLI path:"))
 				})
 			})
@@ -95,7 +97,7 @@ var _ = Describe("Client", func() {
 				})
 
 				It("returns an AWSClient", func() {
-					client, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile)
+					client, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile, nil)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(client).To(BeAssignableToTypeOf(&bosh.GCPClient{}))
 				})
@@ -107,7 +109,7 @@ var _ = Describe("Client", func() {
 				})
 
 				It("returns an appropriate error", func() {
-					_, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile)
+					_, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile, nil)
 					Expect(err.Error()).To(HavePrefix("failed to determine BOSH CLI path:"))
 				})
 			})
@@ -124,7 +126,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns an appropriate error", func() {
-				_, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile)
+				_, err := bosh.New(configInput, terraformOutputs, io.Discard, io.Discard, provider, versionFile, nil)
 				Expect(err.Error()).To(HavePrefix("IAAS not supported: Unknown"))
 			})
 		})
@@ -140,7 +142,7 @@ var _ = Describe("Client", func() {
 				stdout = new(bytes.Buffer)
 
 				buildClient = func() bosh.IClient {
-					client, err := bosh.NewAWSClient(configInput, terraformOutputs, directorClient, stdout, io.Discard, provider, boshCLI, versionFile)
+					client, err := bosh.NewAWSClient(configInput, terraformOutputs, directorClient, stdout, io.Discard, provider, boshCLI, versionFile, nil)
 					Expect(err).NotTo(HaveOccurred())
 					return client
 				}
@@ -168,6 +170,284 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Describe("DeployConcourse", func() {
+		When("on GCP", func() {
+			var credsPath string
+
+			BeforeEach(func() {
+				provider = buildFakeGCPProvider()
+				versionFile = []byte("{}")
+				configInput.PublicCIDR = "10.0.0.0/24"
+				configInput.ConcourseWorkerCount = 5
+				configInput.ConcourseWorkerSize = "xlarge"
+
+				credsFile, err := os.CreateTemp("", "director-creds")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = credsFile.WriteString("atc_password: s3cret\n")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(credsFile.Close()).To(Succeed())
+				credsPath = credsFile.Name()
+				directorClient.PathInWorkingDirStub = func(filename string) string {
+					if filename == "concourse-creds.yml" {
+						return credsPath
+					}
+					return filename
+				}
+
+				buildClient = func() bosh.IClient {
+					client, err := bosh.NewGCPClient(configInput, terraformOutputs, directorClient, io.Discard, io.Discard, provider, boshCLI, versionFile, nil)
+					Expect(err).NotTo(HaveOccurred())
+					return client
+				}
+			})
+
+			AfterEach(func() {
+				os.Remove(credsPath)
+			})
+
+			It("deploys only the concourse manifest with the worker count and size from the config", func() {
+				creds, err := buildClient().DeployConcourse([]byte("atc_password: s3cret\n"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(creds)).To(Equal("atc_password: s3cret\n"))
+
+				Expect(boshCLI.RunAuthenticatedCommandCallCount()).To(Equal(1))
+				Expect(boshCLI.CreateEnvCallCount()).To(BeZero())
+				Expect(boshCLI.UpdateCloudConfigCallCount()).To(BeZero())
+				action, _, _, _, _, _, flags := boshCLI.RunAuthenticatedCommandArgsForCall(0)
+				Expect(action).To(Equal("deploy"))
+				Expect(flags).To(ContainElement("worker_count=5"))
+				Expect(flags).To(ContainElement(`worker_vm_type="concourse-xlarge"`))
+			})
+		})
+	})
+
+	Describe("RecreateInstance", func() {
+		When("on GCP", func() {
+			BeforeEach(func() {
+				provider = buildFakeGCPProvider()
+				versionFile = []byte("{}")
+
+				buildClient = func() bosh.IClient {
+					client, err := bosh.NewGCPClient(configInput, terraformOutputs, directorClient, io.Discard, io.Discard, provider, boshCLI, versionFile, nil)
+					Expect(err).NotTo(HaveOccurred())
+					return client
+				}
+			})
+
+			It("recreates only that instance", func() {
+				err := buildClient().RecreateInstance("worker/abc-123")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshCLI.RunAuthenticatedCommandCallCount()).To(Equal(1))
+				action, _, _, _, detach, _, flags := boshCLI.RunAuthenticatedCommandArgsForCall(0)
+				Expect(action).To(Equal("recreate"))
+				Expect(detach).To(BeFalse())
+				Expect(flags).To(Equal([]string{"worker/abc-123"}))
+			})
+
+			When("bosh recreate fails", func() {
+				BeforeEach(func() {
+					boshCLI.RunAuthenticatedCommandReturns(errors.New("task failed"))
+				})
+
+				It("returns an error naming the instance", func() {
+					err := buildClient().RecreateInstance("worker/abc-123")
+					Expect(err).To(MatchError("Error [task failed] running `bosh recreate worker/abc-123`"))
+				})
+			})
+		})
+	})
+
+	Describe("SSH", func() {
+		When("on GCP", func() {
+			BeforeEach(func() {
+				provider = buildFakeGCPProvider()
+				versionFile = []byte("{}")
+				terraformOutputs.GetReturns("1.2.3.4", nil)
+
+				buildClient = func() bosh.IClient {
+					client, err := bosh.NewGCPClient(configInput, terraformOutputs, directorClient, io.Discard, io.Discard, provider, boshCLI, versionFile, nil)
+					Expect(err).NotTo(HaveOccurred())
+					return client
+				}
+			})
+
+			It("tunnels through the director with the private key, removing the key file afterwards", func() {
+				var keyPath string
+				boshCLI.RunInteractiveCommandStub = func(action, ip, password, ca string, flags ...string) error {
+					keyPath = flags[len(flags)-1]
+					contents, err := os.ReadFile(keyPath)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(contents)).To(Equal(privateKey))
+					return nil
+				}
+
+				err := buildClient().SSH("worker/abc-123")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshCLI.RunInteractiveCommandCallCount()).To(Equal(1))
+				action, ip, _, _, flags := boshCLI.RunInteractiveCommandArgsForCall(0)
+				Expect(action).To(Equal("ssh"))
+				Expect(ip).To(Equal("1.2.3.4"))
+				Expect(flags).To(Equal([]string{"worker/abc-123", "--gw-host", "1.2.3.4", "--gw-user", "jumpbox", "--gw-private-key", keyPath}))
+				Expect(keyPath).NotTo(BeAnExistingFile())
+			})
+
+			When("bosh ssh fails", func() {
+				BeforeEach(func() {
+					boshCLI.RunInteractiveCommandReturns(errors.New("exit status 255"))
+				})
+
+				It("returns an error naming the instance", func() {
+					err := buildClient().SSH("worker/abc-123")
+					Expect(err).To(MatchError("Error [exit status 255] running `bosh ssh worker/abc-123`"))
+				})
+			})
+		})
+	})
+
+	Describe("FetchLogs", func() {
+		When("on GCP", func() {
+			BeforeEach(func() {
+				provider = buildFakeGCPProvider()
+				versionFile = []byte("{}")
+
+				buildClient = func() bosh.IClient {
+					client, err := bosh.NewGCPClient(configInput, terraformOutputs, directorClient, io.Discard, io.Discard, provider, boshCLI, versionFile, nil)
+					Expect(err).NotTo(HaveOccurred())
+					return client
+				}
+			})
+
+			It("downloads the logs of each instance group into the directory", func() {
+				err := buildClient().FetchLogs([]string{"web", "worker"}, "/tmp/logs")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshCLI.RunAuthenticatedCommandCallCount()).To(Equal(2))
+				for i, group := range []string{"web", "worker"} {
+					action, _, _, _, detach, _, flags := boshCLI.RunAuthenticatedCommandArgsForCall(i)
+					Expect(action).To(Equal("logs"))
+					Expect(detach).To(BeFalse())
+					Expect(flags).To(Equal([]string{group, "--dir", "/tmp/logs"}))
+				}
+			})
+
+			When("bosh logs fails", func() {
+				BeforeEach(func() {
+					boshCLI.RunAuthenticatedCommandReturns(errors.New("no instances"))
+				})
+
+				It("returns an error naming the instance group", func() {
+					err := buildClient().FetchLogs([]string{"db"}, "/tmp/logs")
+					Expect(err).To(MatchError("Error [no instances] running `bosh logs db`"))
+				})
+			})
+		})
+	})
+
+	Describe("Diagnostics", func() {
+		When("on GCP", func() {
+			BeforeEach(func() {
+				provider = buildFakeGCPProvider()
+				versionFile = []byte("{}")
+				boshCLI.RunAuthenticatedCommandStub = func(action, ip, password, ca string, detach bool, stdout io.Writer, flags ...string) error {
+					_, err := io.WriteString(stdout, action+" output")
+					return err
+				}
+
+				buildClient = func() bosh.IClient {
+					client, err := bosh.NewGCPClient(configInput, terraformOutputs, directorClient, io.Discard, io.Discard, provider, boshCLI, versionFile, nil)
+					Expect(err).NotTo(HaveOccurred())
+					return client
+				}
+			})
+
+			It("returns the output of bosh instances --ps, tasks --recent and locks", func() {
+				outputs, err := buildClient().Diagnostics()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs).To(Equal(map[string][]byte{
+					"bosh-instances.txt": []byte("instances output"),
+					"bosh-tasks.txt":     []byte("tasks output"),
+					"bosh-locks.txt":     []byte("locks output"),
+				}))
+
+				_, _, _, _, _, _, flags := boshCLI.RunAuthenticatedCommandArgsForCall(0)
+				Expect(flags).To(Equal([]string{"--ps"}))
+				_, _, _, _, _, _, flags = boshCLI.RunAuthenticatedCommandArgsForCall(1)
+				Expect(flags).To(Equal([]string{"--recent"}))
+			})
+		})
+	})
+
+	Describe("PlanCleanUp", func() {
+		When("on GCP", func() {
+			BeforeEach(func() {
+				provider = buildFakeGCPProvider()
+				versionFile = []byte("{}")
+
+				buildClient = func() bosh.IClient {
+					client, err := bosh.NewGCPClient(configInput, terraformOutputs, directorClient, io.Discard, io.Discard, provider, boshCLI, versionFile, nil)
+					Expect(err).NotTo(HaveOccurred())
+					return client
+				}
+				boshCLI.RunAuthenticatedCommandStub = func(action, ip, password, ca string, detach bool, stdout io.Writer, flags ...string) error {
+					io.WriteString(stdout, `{"Tables":[
+						{"Rows":[{"name":"concourse","version":"6.7.0"}]},
+						{"Rows":[{"name":"bosh-google-kvm-ubuntu-xenial-go_agent","version":"621.90"}]},
+						{"Rows":[]},{"Rows":[]},{"Rows":[]},{"Rows":[]}]}`)
+					return nil
+				}
+			})
+
+			It("runs a dry run of bosh clean-up which keeps orphaned disks", func() {
+				_, err := buildClient().PlanCleanUp(true)
+				Expect(err).NotTo(HaveOccurred())
+
+				action, _, _, _, _, _, flags := boshCLI.RunAuthenticatedCommandArgsForCall(0)
+				Expect(action).To(Equal("clean-up"))
+				Expect(flags).To(Equal([]string{"--all", "--keep-orphaned-disks", "--dry-run", "--json"}))
+			})
+
+			It("returns what would be deleted", func() {
+				plan, err := buildClient().PlanCleanUp(false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(plan).To(Equal(bosh.CleanUpPlan{
+					Releases:  []string{"concourse/6.7.0"},
+					Stemcells: []string{"bosh-google-kvm-ubuntu-xenial-go_agent/621.90"},
+				}))
+			})
+		})
+	})
+
+	Describe("OrphanedDisks", func() {
+		When("on GCP", func() {
+			BeforeEach(func() {
+				provider = buildFakeGCPProvider()
+				versionFile = []byte("{}")
+
+				buildClient = func() bosh.IClient {
+					client, err := bosh.NewGCPClient(configInput, terraformOutputs, directorClient, io.Discard, io.Discard, provider, boshCLI, versionFile, nil)
+					Expect(err).NotTo(HaveOccurred())
+					return client
+				}
+				boshCLI.RunAuthenticatedCommandStub = func(action, ip, password, ca string, detach bool, stdout io.Writer, flags ...string) error {
+					io.WriteString(stdout, `{"Tables":[{"Rows":[{"disk_cid":"disk-1","size":"1.5 GiB","deployment":"concourse","instance":"worker/abc-123","orphaned_at":"Mon Jan  2 15:04:05 UTC 2006"}]}]}`)
+					return nil
+				}
+			})
+
+			It("returns the orphaned disks with their size and when they were orphaned", func() {
+				disks, err := buildClient().OrphanedDisks()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(disks).To(HaveLen(1))
+				Expect(disks[0].CID).To(Equal("disk-1"))
+				Expect(disks[0].Instance).To(Equal("worker/abc-123"))
+				Expect(disks[0].SizeMB).To(Equal(uint64(1536)))
+				Expect(disks[0].OrphanedAt.Equal(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC))).To(BeTrue())
+			})
+		})
+	})
 })
 
 func buildFakeGCPProvider() *iaasfakes.FakeProvider {
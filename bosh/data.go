@@ -14,6 +14,7 @@ const (
 	concourseVersionsFilename             = "versions.json"
 	concourseSHAsFilename                 = "shas.json"
 	concourseGrafanaFilename              = "grafana_dashboard.yml"
+	concourseGrafanaPrometheusFilename    = "grafana_prometheus_dashboard.yml"
 	concourseBitBucketAuthFilename        = "bitbucket-auth.yml"
 	concourseGitHubAuthFilename           = "github-auth.yml"
 	concourseGitHubEnterpriseAuthFilename = "github-enterprise-auth.yml"
@@ -21,6 +22,9 @@ const (
 	concourseMicrosoftAuthFilename        = "microsoft-auth.yml"
 	concourseEphemeralWorkersFilename     = "ephemeral_workers.yml"
 	concourseNoMetricsFilename            = "no_metrics.yml"
+	concoursePrometheusMetricsFilename    = "prometheus_metrics.yml"
+	concourseNoPrometheusFilename         = "no_prometheus.yml"
+	concourseOldEncryptionKeyFilename     = "old_encryption_key.yml"
 	extraTagsFilename                     = "extra_tags.yml"
 	uaaCertFilename                       = "uaa-cert.yml"
 )
@@ -29,6 +33,9 @@ var (
 	//go:embed assets/grafana_dashboard.yml
 	concourseGrafana []byte
 
+	//go:embed assets/grafana_prometheus_dashboard.yml
+	concourseGrafanaPrometheus []byte
+
 	//go:embed assets/ops/bitbucket-auth.yml
 	concourseBitBucketAuth []byte
 
@@ -50,6 +57,15 @@ var (
 	//go:embed assets/ops/no_metrics.yml
 	concourseNoMetrics []byte
 
+	//go:embed assets/ops/prometheus_metrics.yml
+	concoursePrometheusMetrics []byte
+
+	//go:embed assets/ops/no_prometheus.yml
+	concourseNoPrometheus []byte
+
+	//go:embed assets/ops/old_encryption_key.yml
+	concourseOldEncryptionKey []byte
+
 	//go:embed assets/ops/extra_tags.yml
 	extraTags []byte
 
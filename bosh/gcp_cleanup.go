@@ -0,0 +1,68 @@
+package bosh
+
+import "fmt"
+
+// PlanCleanUp returns the unused releases and stemcells which CleanUp would delete
+func (client *GCPClient) PlanCleanUp(all bool) (CleanUpPlan, error) {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return CleanUpPlan{}, fmt.Errorf("failed to retrieve director IP: [%v]", err)
+	}
+
+	return planCleanUp(
+		client.boshCLI,
+		directorPublicIP,
+		client.config.GetDirectorPassword(),
+		client.config.GetDirectorCACert(),
+		all,
+	)
+}
+
+// CleanUp runs bosh clean-up, which deletes unused releases and stemcells. Orphaned disks are kept.
+func (client *GCPClient) CleanUp(all bool) error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return fmt.Errorf("failed to retrieve director IP: [%v]", err)
+	}
+
+	return cleanUp(
+		client.boshCLI,
+		directorPublicIP,
+		client.config.GetDirectorPassword(),
+		client.config.GetDirectorCACert(),
+		client.stdout,
+		all,
+	)
+}
+
+// OrphanedDisks returns the disks the director kept after deleting the instances they belonged to
+func (client *GCPClient) OrphanedDisks() ([]OrphanedDisk, error) {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve director IP: [%v]", err)
+	}
+
+	return orphanedDisks(
+		client.boshCLI,
+		directorPublicIP,
+		client.config.GetDirectorPassword(),
+		client.config.GetDirectorCACert(),
+	)
+}
+
+// DeleteOrphanedDisk deletes an orphaned disk, given by its CID
+func (client *GCPClient) DeleteOrphanedDisk(cid string) error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return fmt.Errorf("failed to retrieve director IP: [%v]", err)
+	}
+
+	return deleteOrphanedDisk(
+		client.boshCLI,
+		directorPublicIP,
+		client.config.GetDirectorPassword(),
+		client.config.GetDirectorCACert(),
+		client.stdout,
+		cid,
+	)
+}
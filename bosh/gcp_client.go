@@ -6,6 +6,7 @@ import (
 	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
 	"github.com/EngineerBetter/control-tower/bosh/internal/workingdir"
 	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/EngineerBetter/control-tower/iaas"
 	"github.com/EngineerBetter/control-tower/terraform"
 )
@@ -20,10 +21,14 @@ type GCPClient struct {
 	provider    iaas.Provider
 	boshCLI     boshcli.ICLI
 	versionFile []byte
+	recorder    events.Recorder
 }
 
 //NewGCPClient returns a GCP specific implementation of IClient
-func NewGCPClient(config config.ConfigView, outputs terraform.Outputs, workingdir workingdir.IClient, stdout, stderr io.Writer, provider iaas.Provider, boshCLI boshcli.ICLI, versionFile []byte) (IClient, error) {
+func NewGCPClient(config config.ConfigView, outputs terraform.Outputs, workingdir workingdir.IClient, stdout, stderr io.Writer, provider iaas.Provider, boshCLI boshcli.ICLI, versionFile []byte, recorder events.Recorder) (IClient, error) {
+	if recorder == nil {
+		recorder = events.NopRecorder{}
+	}
 	return &GCPClient{
 		config:     config,
 		outputs:    outputs,
@@ -33,6 +38,7 @@ func NewGCPClient(config config.ConfigView, outputs terraform.Outputs, workingdi
 		provider:   provider,
 		boshCLI:    boshCLI,
 		versionFile: versionFile,
+		recorder:    recorder,
 	}, nil
 }
 
@@ -7,10 +7,23 @@ import (
 	"os"
 	"strings"
 
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/apparentlymart/go-cidr/cidr"
 )
 
-func (client *GCPClient) deployConcourse(creds []byte, detach bool) ([]byte, error) {
+// DeployConcourse deploys only the Concourse manifest, leaving the director and its
+// cloud config as they are. It is used to apply changes such as the number of workers.
+func (client *GCPClient) DeployConcourse(creds []byte) ([]byte, error) {
+	err := client.recorder.Record(events.PhaseConcourseDeploy, func() error {
+		var err error
+		creds, err = client.deployConcourse(creds, false, false)
+		return err
+	})
+	return creds, err
+}
+
+func (client *GCPClient) deployConcourse(creds []byte, detach, dryRun bool) ([]byte, error) {
 
 	err := saveFilesToWorkingDir(client.workingdir, client.provider, creds)
 	if err != nil {
@@ -77,6 +90,10 @@ func (client *GCPClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 		"influx_db_retention_period": client.config.GetInfluxDbRetention(),
 	}
 
+	if client.config.GetMetricsBackend() == config.MetricsBackendPrometheus {
+		vmap["prometheus_retention_period"] = client.config.GetPrometheusRetention()
+	}
+
 	flagFiles := []string{
 		client.workingdir.PathInWorkingDir(concourseManifestFilename),
 		"--vars-store",
@@ -130,8 +147,24 @@ func (client *GCPClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseEphemeralWorkersFilename))
 	}
 
-	if client.config.MetricsIsDisabled() {
+	// The prometheus release is listed, and pinned, with every other release by the versions and shas files
+	if client.config.GetMetricsBackend() != config.MetricsBackendPrometheus {
+		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseNoPrometheusFilename))
+	}
+
+	switch client.config.GetMetricsBackend() {
+	case config.MetricsBackendNone:
 		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseNoMetricsFilename))
+	case config.MetricsBackendPrometheus:
+		flagFiles = append(flagFiles,
+			"--ops-file", client.workingdir.PathInWorkingDir(concoursePrometheusMetricsFilename),
+			"--vars-file", client.workingdir.PathInWorkingDir(concourseGrafanaPrometheusFilename))
+	}
+
+	// While the encryption key is being rotated, Concourse re-encrypts data encrypted with the old key on startup
+	if client.config.GetOldEncryptionKey() != "" {
+		vmap["atc_old_encryption_key"] = client.config.GetOldEncryptionKey()
+		flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(concourseOldEncryptionKeyFilename))
 	}
 
 	t, err1 := client.buildTagsYaml(vmap["project"], "concourse")
@@ -141,6 +174,10 @@ func (client *GCPClient) deployConcourse(creds []byte, detach bool) ([]byte, err
 	vmap["tags"] = t
 	flagFiles = append(flagFiles, "--ops-file", client.workingdir.PathInWorkingDir(extraTagsFilename))
 
+	if dryRun {
+		flagFiles = append(flagFiles, "--dry-run")
+	}
+
 	vs := vars(vmap)
 
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
@@ -6,35 +6,40 @@ import (
 	"github.com/apparentlymart/go-cidr/cidr"
 
 	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+	"github.com/EngineerBetter/control-tower/events"
 )
 
 // Deploy deploys a new Bosh director or converges an existing deployment
 // Returns new contents of bosh state file
 func (client *GCPClient) Deploy(state, creds []byte, detach bool) (newState, newCreds []byte, err error) {
+	err = client.recorder.Record(events.PhaseBOSHCreateEnv, func() error {
+		var err error
+		state, creds, err = client.CreateEnv(state, creds, "")
+		return err
+	})
 	if err != nil {
 		return state, creds, err
 	}
 
-	state, creds, err = client.CreateEnv(state, creds, "")
-	if err != nil {
-		return state, creds, err
-	}
-
-	if err = client.updateCloudConfig(client.boshCLI); err != nil {
-		return state, creds, err
-	}
-	if err = client.uploadConcourseStemcell(client.boshCLI); err != nil {
+	if err = client.recorder.Record(events.PhaseBOSHCloudConfig, func() error {
+		return client.updateCloudConfig(client.boshCLI)
+	}); err != nil {
 		return state, creds, err
 	}
-	if err = client.createDefaultDatabases(); err != nil {
+	if err = client.recorder.Record(events.PhaseBOSHStemcell, func() error {
+		return client.uploadConcourseStemcell(client.boshCLI)
+	}); err != nil {
 		return state, creds, err
 	}
-
-	creds, err = client.deployConcourse(creds, detach)
-	if err != nil {
+	if err = client.recorder.Record(events.PhaseDatabaseCreation, client.createDefaultDatabases); err != nil {
 		return state, creds, err
 	}
 
+	err = client.recorder.Record(events.PhaseConcourseDeploy, func() error {
+		var err error
+		creds, err = client.deployConcourse(creds, detach, false)
+		return err
+	})
 	return state, creds, err
 }
 
@@ -121,6 +126,13 @@ func (client *GCPClient) Recreate() error {
 	}, directorPublicIP, client.config.GetDirectorPassword(), client.config.GetDirectorCACert())
 }
 
+// Diff runs a dry-run deploy of the Concourse manifest for GCP client, printing
+// the changes that a deploy would make without applying them
+func (client *GCPClient) Diff(creds []byte) error {
+	_, err := client.deployConcourse(creds, false, true)
+	return err
+}
+
 // Locks implements locks for GCP client
 func (client *GCPClient) Locks() ([]byte, error) {
 	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
@@ -16,3 +16,38 @@ func (client *GCPClient) Instances() ([]Instance, error) {
 		client.config.GetDirectorCACert(),
 	)
 }
+
+// RecreateInstance recreates a single Concourse VM, given as <group>/<id>
+func (client *GCPClient) RecreateInstance(instance string) error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return fmt.Errorf("failed to retrieve director IP: [%v]", err)
+	}
+
+	return recreateInstance(
+		client.boshCLI,
+		directorPublicIP,
+		client.config.GetDirectorPassword(),
+		client.config.GetDirectorCACert(),
+		client.stdout,
+		instance,
+	)
+}
+
+// SSH opens an interactive shell on a single Concourse VM, given as <group>/<id>, through the director
+func (client *GCPClient) SSH(instance string) error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return fmt.Errorf("failed to retrieve director IP: [%v]", err)
+	}
+
+	return sshInstance(
+		client.boshCLI,
+		directorPublicIP,
+		client.config.GetDirectorPassword(),
+		client.config.GetDirectorCACert(),
+		gcpGatewayUser,
+		client.config.GetPrivateKey(),
+		instance,
+	)
+}
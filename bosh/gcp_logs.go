@@ -0,0 +1,37 @@
+package bosh
+
+import "fmt"
+
+// FetchLogs downloads the job logs of each instance group into dir, as tarballs named by bosh logs
+func (client *GCPClient) FetchLogs(instanceGroups []string, dir string) error {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return fmt.Errorf("failed to retrieve director IP: [%v]", err)
+	}
+
+	return fetchLogs(
+		client.boshCLI,
+		directorPublicIP,
+		client.config.GetDirectorPassword(),
+		client.config.GetDirectorCACert(),
+		client.stdout,
+		instanceGroups,
+		dir,
+	)
+}
+
+// Diagnostics returns the output of `bosh instances --ps`, `bosh tasks --recent` and `bosh locks`,
+// by the name of the file it belongs in
+func (client *GCPClient) Diagnostics() (map[string][]byte, error) {
+	directorPublicIP, err := client.outputs.Get("DirectorPublicIP")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve director IP: [%v]", err)
+	}
+
+	return diagnostics(
+		client.boshCLI,
+		directorPublicIP,
+		client.config.GetDirectorPassword(),
+		client.config.GetDirectorCACert(),
+	)
+}
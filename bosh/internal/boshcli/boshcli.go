@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
 
@@ -21,6 +22,7 @@ import (
 type ICLI interface {
 	CreateEnv(createEnvFiles *CreateEnvFiles, config IAASEnvironment, password, cert, key, ca string, tags map[string]string) (*CreateEnvFiles, error)
 	RunAuthenticatedCommand(action, ip, password, ca string, detach bool, stdout io.Writer, flags ...string) error
+	RunInteractiveCommand(action, ip, password, ca string, flags ...string) error
 	Locks(config IAASEnvironment, ip, password, ca string) ([]byte, error)
 	Recreate(config IAASEnvironment, ip, password, ca string) error
 	UpdateCloudConfig(config IAASEnvironment, ip, password, ca string) error
@@ -245,6 +247,31 @@ func (c *CLI) RunAuthenticatedCommand(action, ip, password, ca string, detach bo
 	return c.boshCommand(stdout, flags...)
 }
 
+// RunInteractiveCommand runs the bosh command `action` with flags `flags` attached to the terminal,
+// for commands such as `ssh` which read from it. Interrupts are left to the bosh CLI to handle
+// rather than stopping control-tower, so that callers can still clean up once it exits.
+func (c *CLI) RunInteractiveCommand(action, ip, password, ca string, flags ...string) error {
+	caPath, err := writeTempFile([]byte(ca))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(caPath)
+	ip = fmt.Sprintf("https://%s", ip)
+
+	authFlags := []string{"--environment", ip, "--ca-cert", caPath, "--client", "admin", "--client-secret", password, "--deployment", "concourse", action}
+	flags = append(authFlags, flags...)
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	cmd := c.execCmd(c.boshPath, flags...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 func (c *CLI) boshCommand(stdout io.Writer, flags ...string) error {
 	cmd := c.execCmd(c.boshPath, flags...)
 	cmd.Stderr = os.Stderr
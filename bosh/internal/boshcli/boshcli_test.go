@@ -90,3 +90,21 @@ func TestCLI_UploadConcourseStemcell(t *testing.T) {
 	require.NoError(t, err)
 
 }
+
+func TestCLI_RunInteractiveCommand(t *testing.T) {
+	e := fakeexec.New(t)
+	defer e.Finish()
+	c := boshcli.New("bosh", e.Cmd())
+	e.ExpectFunc(func(t testing.TB, command string, args ...string) {
+		require.Equal(t, "bosh", command)
+
+		require.Equal(t, "--environment", args[0])
+		require.Equal(t, "https://ip", args[1])
+		require.Equal(t, "--client-secret", args[6])
+		require.Equal(t, "password", args[7])
+		require.Equal(t, "ssh", args[10])
+		require.Equal(t, []string{"web/abc-123"}, args[11:])
+	})
+	err := c.RunInteractiveCommand("ssh", "ip", "password", "ca", "web/abc-123")
+	require.NoError(t, err)
+}
@@ -75,6 +75,21 @@ type FakeICLI struct {
 	runAuthenticatedCommandReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RunInteractiveCommandStub        func(string, string, string, string, ...string) error
+	runInteractiveCommandMutex       sync.RWMutex
+	runInteractiveCommandArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []string
+	}
+	runInteractiveCommandReturns struct {
+		result1 error
+	}
+	runInteractiveCommandReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateCloudConfigStub        func(boshcli.IAASEnvironment, string, string, string) error
 	updateCloudConfigMutex       sync.RWMutex
 	updateCloudConfigArgsForCall []struct {
@@ -119,15 +134,16 @@ func (fake *FakeICLI) CreateEnv(arg1 *boshcli.CreateEnvFiles, arg2 boshcli.IAASE
 		arg6 string
 		arg7 map[string]string
 	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	stub := fake.CreateEnvStub
+	fakeReturns := fake.createEnvReturns
 	fake.recordInvocation("CreateEnv", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.createEnvMutex.Unlock()
-	if fake.CreateEnvStub != nil {
-		return fake.CreateEnvStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.createEnvReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -185,15 +201,16 @@ func (fake *FakeICLI) Locks(arg1 boshcli.IAASEnvironment, arg2 string, arg3 stri
 		arg3 string
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.LocksStub
+	fakeReturns := fake.locksReturns
 	fake.recordInvocation("Locks", []interface{}{arg1, arg2, arg3, arg4})
 	fake.locksMutex.Unlock()
-	if fake.LocksStub != nil {
-		return fake.LocksStub(arg1, arg2, arg3, arg4)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.locksReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -251,15 +268,16 @@ func (fake *FakeICLI) Recreate(arg1 boshcli.IAASEnvironment, arg2 string, arg3 s
 		arg3 string
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.RecreateStub
+	fakeReturns := fake.recreateReturns
 	fake.recordInvocation("Recreate", []interface{}{arg1, arg2, arg3, arg4})
 	fake.recreateMutex.Unlock()
-	if fake.RecreateStub != nil {
-		return fake.RecreateStub(arg1, arg2, arg3, arg4)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.recreateReturns
 	return fakeReturns.result1
 }
 
@@ -317,15 +335,16 @@ func (fake *FakeICLI) RunAuthenticatedCommand(arg1 string, arg2 string, arg3 str
 		arg6 io.Writer
 		arg7 []string
 	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	stub := fake.RunAuthenticatedCommandStub
+	fakeReturns := fake.runAuthenticatedCommandReturns
 	fake.recordInvocation("RunAuthenticatedCommand", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
 	fake.runAuthenticatedCommandMutex.Unlock()
-	if fake.RunAuthenticatedCommandStub != nil {
-		return fake.RunAuthenticatedCommandStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7...)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7...)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.runAuthenticatedCommandReturns
 	return fakeReturns.result1
 }
 
@@ -371,6 +390,71 @@ func (fake *FakeICLI) RunAuthenticatedCommandReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeICLI) RunInteractiveCommand(arg1 string, arg2 string, arg3 string, arg4 string, arg5 ...string) error {
+	fake.runInteractiveCommandMutex.Lock()
+	ret, specificReturn := fake.runInteractiveCommandReturnsOnCall[len(fake.runInteractiveCommandArgsForCall)]
+	fake.runInteractiveCommandArgsForCall = append(fake.runInteractiveCommandArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.RunInteractiveCommandStub
+	fakeReturns := fake.runInteractiveCommandReturns
+	fake.recordInvocation("RunInteractiveCommand", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.runInteractiveCommandMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeICLI) RunInteractiveCommandCallCount() int {
+	fake.runInteractiveCommandMutex.RLock()
+	defer fake.runInteractiveCommandMutex.RUnlock()
+	return len(fake.runInteractiveCommandArgsForCall)
+}
+
+func (fake *FakeICLI) RunInteractiveCommandCalls(stub func(string, string, string, string, ...string) error) {
+	fake.runInteractiveCommandMutex.Lock()
+	defer fake.runInteractiveCommandMutex.Unlock()
+	fake.RunInteractiveCommandStub = stub
+}
+
+func (fake *FakeICLI) RunInteractiveCommandArgsForCall(i int) (string, string, string, string, []string) {
+	fake.runInteractiveCommandMutex.RLock()
+	defer fake.runInteractiveCommandMutex.RUnlock()
+	argsForCall := fake.runInteractiveCommandArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeICLI) RunInteractiveCommandReturns(result1 error) {
+	fake.runInteractiveCommandMutex.Lock()
+	defer fake.runInteractiveCommandMutex.Unlock()
+	fake.RunInteractiveCommandStub = nil
+	fake.runInteractiveCommandReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeICLI) RunInteractiveCommandReturnsOnCall(i int, result1 error) {
+	fake.runInteractiveCommandMutex.Lock()
+	defer fake.runInteractiveCommandMutex.Unlock()
+	fake.RunInteractiveCommandStub = nil
+	if fake.runInteractiveCommandReturnsOnCall == nil {
+		fake.runInteractiveCommandReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.runInteractiveCommandReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeICLI) UpdateCloudConfig(arg1 boshcli.IAASEnvironment, arg2 string, arg3 string, arg4 string) error {
 	fake.updateCloudConfigMutex.Lock()
 	ret, specificReturn := fake.updateCloudConfigReturnsOnCall[len(fake.updateCloudConfigArgsForCall)]
@@ -380,15 +464,16 @@ func (fake *FakeICLI) UpdateCloudConfig(arg1 boshcli.IAASEnvironment, arg2 strin
 		arg3 string
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateCloudConfigStub
+	fakeReturns := fake.updateCloudConfigReturns
 	fake.recordInvocation("UpdateCloudConfig", []interface{}{arg1, arg2, arg3, arg4})
 	fake.updateCloudConfigMutex.Unlock()
-	if fake.UpdateCloudConfigStub != nil {
-		return fake.UpdateCloudConfigStub(arg1, arg2, arg3, arg4)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.updateCloudConfigReturns
 	return fakeReturns.result1
 }
 
@@ -443,15 +528,16 @@ func (fake *FakeICLI) UploadConcourseStemcell(arg1 boshcli.IAASEnvironment, arg2
 		arg3 string
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.UploadConcourseStemcellStub
+	fakeReturns := fake.uploadConcourseStemcellReturns
 	fake.recordInvocation("UploadConcourseStemcell", []interface{}{arg1, arg2, arg3, arg4})
 	fake.uploadConcourseStemcellMutex.Unlock()
-	if fake.UploadConcourseStemcellStub != nil {
-		return fake.UploadConcourseStemcellStub(arg1, arg2, arg3, arg4)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.uploadConcourseStemcellReturns
 	return fakeReturns.result1
 }
 
@@ -508,6 +594,8 @@ func (fake *FakeICLI) Invocations() map[string][][]interface{} {
 	defer fake.recreateMutex.RUnlock()
 	fake.runAuthenticatedCommandMutex.RLock()
 	defer fake.runAuthenticatedCommandMutex.RUnlock()
+	fake.runInteractiveCommandMutex.RLock()
+	defer fake.runInteractiveCommandMutex.RUnlock()
 	fake.updateCloudConfigMutex.RLock()
 	defer fake.updateCloudConfigMutex.RUnlock()
 	fake.uploadConcourseStemcellMutex.RLock()
@@ -0,0 +1,41 @@
+package bosh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+)
+
+// diagnosticCommands are the bosh commands whose output goes in a diagnostic bundle, by the file it is saved as
+var diagnosticCommands = []struct {
+	filename string
+	action   string
+	flags    []string
+}{
+	{filename: "bosh-instances.txt", action: "instances", flags: []string{"--ps"}},
+	{filename: "bosh-tasks.txt", action: "tasks", flags: []string{"--recent"}},
+	{filename: "bosh-locks.txt", action: "locks"},
+}
+
+func fetchLogs(boshCLI boshcli.ICLI, ip, password, ca string, stdout io.Writer, instanceGroups []string, dir string) error {
+	for _, group := range instanceGroups {
+		if err := boshCLI.RunAuthenticatedCommand("logs", ip, password, ca, false, stdout, group, "--dir", dir); err != nil {
+			return fmt.Errorf("Error [%s] running `bosh logs %s`", err, group)
+		}
+	}
+	return nil
+}
+
+func diagnostics(boshCLI boshcli.ICLI, ip, password, ca string) (map[string][]byte, error) {
+	outputs := map[string][]byte{}
+	for _, command := range diagnosticCommands {
+		output := new(bytes.Buffer)
+		if err := boshCLI.RunAuthenticatedCommand(command.action, ip, password, ca, false, output, command.flags...); err != nil {
+			return nil, fmt.Errorf("Error [%s] running `bosh %s`. stdout: [%s]", err, command.action, output.String())
+		}
+		outputs[command.filename] = output.Bytes()
+	}
+	return outputs, nil
+}
@@ -0,0 +1,47 @@
+package bosh
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/EngineerBetter/control-tower/bosh/internal/boshcli"
+)
+
+// Users which the director's SSH gateway accepts the deployment's private key for
+const (
+	awsGatewayUser = "vcap"
+	gcpGatewayUser = "jumpbox"
+)
+
+// sshInstance opens an interactive shell on instance, tunnelling through the director as gatewayUser.
+// The private key is written to a temporary file for the bosh CLI, which is removed once the session ends.
+func sshInstance(boshCLI boshcli.ICLI, ip, password, ca, gatewayUser, privateKey, instance string) error {
+	keyFile, err := os.CreateTemp("", "control-tower-ssh")
+	if err != nil {
+		return err
+	}
+	keyPath := keyFile.Name()
+	defer os.Remove(keyPath)
+
+	_, err = keyFile.WriteString(privateKey)
+	if err1 := keyFile.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the private key for the SSH gateway: [%v]", err)
+	}
+
+	if err = boshCLI.RunInteractiveCommand(
+		"ssh",
+		ip,
+		password,
+		ca,
+		instance,
+		"--gw-host", ip,
+		"--gw-user", gatewayUser,
+		"--gw-private-key", keyPath,
+	); err != nil {
+		return fmt.Errorf("Error [%s] running `bosh ssh %s`", err, instance)
+	}
+	return nil
+}
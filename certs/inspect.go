@@ -0,0 +1,81 @@
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Certificate describes a certificate found in a deployment's config or director-creds.yml
+type Certificate struct {
+	Name      string    `json:"name"`
+	Source    string    `json:"source"`
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	SANs      []string  `json:"sans"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+	IsCA      bool      `json:"is_ca"`
+}
+
+// Inspect parses each PEM encoded certificate in pemData. The second and later certificates
+// of a chain are named after the first, with their position appended, e.g. concourse_cert#2
+func Inspect(name, source, pemData string) ([]Certificate, error) {
+	var certificates []Certificate
+	rest := []byte(pemData)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %s from %s: [%v]", name, source, err)
+		}
+
+		certName := name
+		if len(certificates) > 0 {
+			certName = fmt.Sprintf("%s#%d", name, len(certificates)+1)
+		}
+		certificates = append(certificates, Certificate{
+			Name:      certName,
+			Source:    source,
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			SANs:      subjectAltNames(cert),
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+			IsCA:      cert.IsCA,
+		})
+	}
+
+	if len(certificates) == 0 && strings.TrimSpace(pemData) != "" {
+		return nil, fmt.Errorf("no PEM encoded certificate found in %s from %s", name, source)
+	}
+	return certificates, nil
+}
+
+// DaysLeft returns the number of whole days until the certificate expires, which is negative once it has
+func (c Certificate) DaysLeft(now time.Time) int {
+	return int(math.Floor(c.NotAfter.Sub(now).Hours() / 24))
+}
+
+func subjectAltNames(cert *x509.Certificate) []string {
+	sans := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
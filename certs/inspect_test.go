@@ -0,0 +1,84 @@
+package certs_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/EngineerBetter/control-tower/certs"
+)
+
+var _ = Describe("Inspect", func() {
+	var notAfter time.Time
+
+	BeforeEach(func() {
+		notAfter = time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+	})
+
+	It("reports the subject, issuer, SANs and expiry of the certificate", func() {
+		certificates, err := Inspect("concourse_cert", "config.json", selfSignedPEM("ci.example.com", notAfter))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificates).To(HaveLen(1))
+
+		cert := certificates[0]
+		Expect(cert.Name).To(Equal("concourse_cert"))
+		Expect(cert.Source).To(Equal("config.json"))
+		Expect(cert.Subject).To(Equal("CN=ci.example.com,O=EngineerBetter"))
+		Expect(cert.Issuer).To(Equal("CN=ci.example.com,O=EngineerBetter"))
+		Expect(cert.SANs).To(Equal([]string{"ci.example.com", "10.0.0.6"}))
+		Expect(cert.NotAfter.Equal(notAfter)).To(BeTrue())
+	})
+
+	It("reports each certificate of a chain", func() {
+		chain := selfSignedPEM("ci.example.com", notAfter) + selfSignedPEM("intermediate", notAfter)
+		certificates, err := Inspect("concourse_cert", "config.json", chain)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificates).To(HaveLen(2))
+		Expect(certificates[1].Name).To(Equal("concourse_cert#2"))
+		Expect(certificates[1].Subject).To(ContainSubstring("CN=intermediate"))
+	})
+
+	It("returns nothing for an empty certificate", func() {
+		certificates, err := Inspect("director_cert", "config.json", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificates).To(BeEmpty())
+	})
+
+	It("returns an error when there is no certificate", func() {
+		_, err := Inspect("director_cert", "config.json", "not a certificate")
+		Expect(err).To(MatchError("no PEM encoded certificate found in director_cert from config.json"))
+	})
+
+	Describe("DaysLeft", func() {
+		It("counts whole days until the certificate expires", func() {
+			cert := Certificate{NotAfter: notAfter}
+			Expect(cert.DaysLeft(notAfter.Add(-36 * time.Hour))).To(Equal(1))
+			Expect(cert.DaysLeft(notAfter.Add(12 * time.Hour))).To(Equal(-1))
+		})
+	})
+})
+
+func selfSignedPEM(commonName string, notAfter time.Time) string {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"EngineerBetter"}},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+		DNSNames:     []string{commonName},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.6")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -19,6 +19,8 @@ jq --null-input \
   --arg grafana_release_version "${grafana_release_version}" \
   --arg influxdb_release_url "${influxdb_release_url}" \
   --arg influxdb_release_version "${influxdb_release_version}" \
+  --arg prometheus_release_url "${prometheus_release_url}" \
+  --arg prometheus_release_version "${prometheus_release_version}" \
   --arg stemcell_version "${stemcell_version}" \
   --arg uaa_release_url "${uaa_release_url}" \
   --arg uaa_release_version "${uaa_release_version}" \
@@ -39,6 +41,8 @@ jq --null-input \
   --arg grafana_release_version_gcp "${grafana_release_version_gcp}" \
   --arg influxdb_release_url_gcp "${influxdb_release_url_gcp}" \
   --arg influxdb_release_version_gcp "${influxdb_release_version_gcp}" \
+  --arg prometheus_release_url_gcp "${prometheus_release_url_gcp}" \
+  --arg prometheus_release_version_gcp "${prometheus_release_version_gcp}" \
   --arg stemcell_version_gcp "${stemcell_version_gcp}" \
   --arg uaa_release_url_gcp "${uaa_release_url_gcp}" \
   --arg uaa_release_version_gcp "${uaa_release_version_gcp}" \
@@ -79,6 +83,11 @@ jq --null-input \
         url: $influxdb_release_url,
         version: $influxdb_release_version
       },
+      {
+        name: "prometheus_release",
+        url: $prometheus_release_url,
+        version: $prometheus_release_version
+      },
       {
         name: "stemcell_aws",
         version: $stemcell_version
@@ -6,7 +6,7 @@ source control-tower/ci/tasks/lib/pipeline.sh
 function assertPipelinesCanReadFromCredhub() {
   echo "About to test that pipelines can get values from Credhub"
 
-  config=$(./cup info --region "$region" --json "$deployment" -iaas "$IAAS")
+  config=$(./cup info --show-secrets --region "$region" --json "$deployment" -iaas "$IAAS")
   # shellcheck disable=SC2034
   domain=$(echo "$config" | jq -r '.config.domain')
   # shellcheck disable=SC2034
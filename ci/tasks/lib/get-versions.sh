@@ -12,6 +12,8 @@ function getVersions() {
   grafana_release_version=$(  jq -r '.[] | select(.value.name? == "grafana") | .value.version' ops/versions-aws.json)
   influxdb_release_url=$(     jq -r '.[] | select(.value.name? == "influxdb") | .value.url' ops/versions-aws.json)
   influxdb_release_version=$( jq -r '.[] | select(.value.name? == "influxdb") | .value.version' ops/versions-aws.json)
+  prometheus_release_url=$(   jq -r '.[] | select(.value.name? == "prometheus") | .value.url' ops/versions-aws.json)
+  prometheus_release_version=$(jq -r '.[] | select(.value.name? == "prometheus") | .value.version' ops/versions-aws.json)
   stemcell_version=$(         jq -r '.[] | select(.path == "/stemcells/alias=jammy/version") | .value' ops/versions-aws.json)
   uaa_release_url=$(          jq -r '.[] | select(.value.name? == "uaa") | .value.url' ops/versions-aws.json)
   uaa_release_version=$(      jq -r '.[] | select(.value.name? == "uaa") | .value.version' ops/versions-aws.json)
@@ -33,6 +35,8 @@ function getVersions() {
   grafana_release_version_gcp=$(  jq -r '.[] | select(.value.name? == "grafana") | .value.version' ops/versions-gcp.json)
   influxdb_release_url_gcp=$(     jq -r '.[] | select(.value.name? == "influxdb") | .value.url' ops/versions-gcp.json)
   influxdb_release_version_gcp=$( jq -r '.[] | select(.value.name? == "influxdb") | .value.version' ops/versions-gcp.json)
+  prometheus_release_url_gcp=$(   jq -r '.[] | select(.value.name? == "prometheus") | .value.url' ops/versions-gcp.json)
+  prometheus_release_version_gcp=$(jq -r '.[] | select(.value.name? == "prometheus") | .value.version' ops/versions-gcp.json)
   stemcell_version_gcp=$(         jq -r '.[] | select(.path == "/stemcells/alias=jammy/version") | .value' ops/versions-gcp.json)
   uaa_release_url_gcp=$(          jq -r '.[] | select(.value.name? == "uaa") | .value.url' ops/versions-gcp.json)
   uaa_release_version_gcp=$(      jq -r '.[] | select(.value.name? == "uaa") | .value.version' ops/versions-gcp.json)
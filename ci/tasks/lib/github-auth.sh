@@ -13,7 +13,7 @@ function addMainTeamGitHubAuthFlags() {
 }
 
 function assertGitHubAuthConfigured() {
-  config=$(./cup info --region "$region" --iaas "$IAAS" --json "$deployment")
+  config=$(./cup info --show-secrets --region "$region" --iaas "$IAAS" --json "$deployment")
   domain=$(echo "$config" | jq -r '.config.domain')
   username=$(echo "$config" | jq -r '.config.concourse_username')
   password=$(echo "$config" | jq -r '.config.concourse_password')
@@ -46,7 +46,7 @@ function assertGitHubAuthConfigured() {
 }
 
 function assertMainTeamGitHubConfigured() {
-  config=$(./cup info --region "$region" --iaas "$IAAS" --json "$deployment")
+  config=$(./cup info --show-secrets --region "$region" --iaas "$IAAS" --json "$deployment")
   domain=$(echo "$config" | jq -r '.config.domain')
   username=$(echo "$config" | jq -r '.config.concourse_username')
   password=$(echo "$config" | jq -r '.config.concourse_password')
@@ -8,7 +8,7 @@ function addMicrosoftFlagsToArgs() {
 }
 
 function assertMicrosoftAuthConfigured() {
-  config=$(./cup info --region "$region" --iaas "$IAAS" --json "$deployment")
+  config=$(./cup info --show-secrets --region "$region" --iaas "$IAAS" --json "$deployment")
   domain=$(echo "$config" | jq -r '.config.domain')
   username=$(echo "$config" | jq -r '.config.concourse_username')
   password=$(echo "$config" | jq -r '.config.concourse_password')
@@ -29,7 +29,7 @@ fi
   --domain "$custom_domain"
 sleep 60
 
-config=$(./cup info --json "$deployment")
+config=$(./cup info --show-secrets --json "$deployment")
 # shellcheck disable=SC2034
 username=$(echo "$config" | jq -r '.config.concourse_username')
 # shellcheck disable=SC2034
@@ -18,7 +18,7 @@ echo "DEPLOY WITH AUTOGENERATED CERT, NO DOMAIN, CUSTOM REGION, m5 WORKERS"
 
 sleep 60
 
-config=$(./cup info --region eu-west-3 --json "$deployment")
+config=$(./cup info --show-secrets --region eu-west-3 --json "$deployment")
 # shellcheck disable=SC2034
 domain=$(echo "$config" | jq -r '.config.domain')
 # shellcheck disable=SC2034
@@ -22,7 +22,7 @@ assertNetworkCidrsCorrect
 # Assigning a subshell to a variable fails fast; eval "$(... doesn't
 info_output="$(./cup info --env "$deployment")"
 eval "$info_output"
-config=$(./cup info --json "$deployment")
+config=$(./cup info --show-secrets --json "$deployment")
 [[ -n $config ]]
 domain=$(echo "$config" | jq -r '.config.domain')
 
@@ -46,7 +46,7 @@ echo "AWS have changed the CA cert - proceeding"
 echo "Sleeping for one minute whilst database connections are re-established"
 sleep 60
 
-config=$(./cup info --json "$deployment")
+config=$(./cup info --show-secrets --json "$deployment")
 # shellcheck disable=SC2034
 domain=$(echo "$config" | jq -r '.config.domain')
 # shellcheck disable=SC2034
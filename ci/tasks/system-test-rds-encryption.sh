@@ -19,7 +19,7 @@ echo "DEPLOY WITH ENCRYPTED DISK"
 
 sleep 60
 
-config=$(./cup info --region "$region" --json "$deployment")
+config=$(./cup info --show-secrets --region "$region" --json "$deployment")
 # shellcheck disable=SC2034
 domain=$(echo "$config" | jq -r '.config.domain')
 # shellcheck disable=SC2034
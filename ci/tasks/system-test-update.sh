@@ -25,7 +25,7 @@ assertNetworkCidrsCorrect
 # Assigning a subshell to a variable fails fast; eval "$(... doesn't
 info_output="$(./cup info --env "$deployment")"
 eval "$info_output"
-config=$(./cup info --json "$deployment")
+config=$(./cup info --show-secrets --json "$deployment")
 [[ -n $config ]]
 domain=$(echo "$config" | jq -r '.config.domain')
 
@@ -66,7 +66,7 @@ echo "Update complete - Proceeding"
 
 sleep 60
 
-config=$(./cup info --json "$deployment")
+config=$(./cup info --show-secrets --json "$deployment")
 domain=$(echo "$config" | jq -r '.config.domain')
 # shellcheck disable=SC2034
 username=$(echo "$config" | jq -r '.config.concourse_username')
@@ -67,7 +67,7 @@ eval "$info_output"
 bosh vms
 bosh ssh worker true
 
-config=$(./cup info --json "$deployment")
+config=$(./cup info --show-secrets --json "$deployment")
 # shellcheck disable=SC2034
 username=$(echo "$config" | jq -r '.config.concourse_username')
 # shellcheck disable=SC2034
@@ -129,7 +129,7 @@ assertDbCorrect
 checkManifestProperty "${global_resources_path}" false
 checkManifestProperty "${pipeline_instances_path}" false
 
-config=$(./cup info --json "$deployment")
+config=$(./cup info --show-secrets --json "$deployment")
 # shellcheck disable=SC2034
 username=$(echo "$config" | jq -r '.config.concourse_username')
 # shellcheck disable=SC2034
@@ -142,12 +142,12 @@ assertPipelineIsRunnable
 assertPipelinesCanReadFromCredhub
 
 # Check nats certificate renewal
-before="$(./cup info "$deployment" --cert-expiry)"
+before="$(./cup info --json "$deployment" | jq -r '.cert_expiry')"
 before_timestamp="$(date -d "$before" +"%s")"
 
 ./cup maintain --renew-nats-cert "$deployment"
 
-after="$(./cup info "$deployment" --cert-expiry)"
+after="$(./cup info --json "$deployment" | jq -r '.cert_expiry')"
 after_timestamp="$(date -d "$after" +"%s")"
 
 [[ $before_timestamp -lt $after_timestamp ]]
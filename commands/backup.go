@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/backup"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+var initialBackupArgs backup.Args
+
+var backupFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialBackupArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialBackupArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialBackupArgs.Namespace,
+	},
+	cli.StringFlag{
+		Name:        "to",
+		Usage:       "(optional) Path of the file to write the backup to (default: <name>.backup)",
+		EnvVar:      "BACKUP_FILE",
+		Destination: &initialBackupArgs.To,
+	},
+	cli.StringFlag{
+		Name:        "passphrase",
+		Usage:       "(required) Passphrase used to encrypt the backup",
+		EnvVar:      "BACKUP_PASSPHRASE",
+		Destination: &initialBackupArgs.Passphrase,
+	},
+}
+
+func backupAction(c *cli.Context, backupArgs backup.Args, provider iaas.Provider) error {
+	name := c.Args().Get(0)
+	if name == "" {
+		return errors.New("Usage is `control-tower backup <name>`")
+	}
+
+	stateStore, err := buildStateStore(provider)
+	if err != nil {
+		return err
+	}
+	configClient := config.NewWithStateStore(provider, stateStore, name, backupArgs.Namespace)
+	data, manifest, err := config.Backup(configClient, name, configClient.Namespace, backupArgs.Passphrase)
+	if err != nil {
+		return fmt.Errorf("Error backing up deployment: [%v]", err)
+	}
+
+	path := backupArgs.To
+	if path == "" {
+		path = fmt.Sprintf("%s.backup", name)
+	}
+	if err = ioutil.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("Error writing backup to %s: [%v]", path, err)
+	}
+
+	_, err = fmt.Fprintf(os.Stdout, "Backed up %d files from deployment %s to %s\n", len(manifest.Checksums), name, path)
+	return err
+}
+
+func validateBackupArgs(c *cli.Context, backupArgs backup.Args) (backup.Args, error) {
+	err := backupArgs.MarkSetFlags(c)
+	if err != nil {
+		return backupArgs, fmt.Errorf("failed to mark set Backup flags: [%v]", err)
+	}
+
+	if err = backupArgs.Validate(); err != nil {
+		return backupArgs, fmt.Errorf("failed to validate Backup flags: [%v]", err)
+	}
+
+	return backupArgs, nil
+}
+
+var backupCmd = cli.Command{
+	Name:      "backup",
+	Aliases:   []string{"b"},
+	Usage:     "Writes all state of a deployment to an encrypted backup file",
+	ArgsUsage: "<name>",
+	Flags:     backupFlags,
+	Action: func(c *cli.Context) error {
+		backupArgs, err := validateBackupArgs(c, initialBackupArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on backup: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(backupArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on backup: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, backupArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on backup: [%v]", err)
+		}
+		return backupAction(c, backupArgs, provider)
+	},
+}
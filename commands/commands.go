@@ -1,18 +1,46 @@
 package commands
 
 import (
+	"fmt"
+	"io"
+	"os"
+
 	cli "gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/certs"
+	"github.com/EngineerBetter/control-tower/commands/deploy"
+	"github.com/EngineerBetter/control-tower/concourse"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/credhub"
+	"github.com/EngineerBetter/control-tower/events"
+	"github.com/EngineerBetter/control-tower/fly"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/terraform"
+	"github.com/EngineerBetter/control-tower/util"
 )
 
 // Commands is a list of all supported CLI commands
 var Commands = []cli.Command{
+	backupCmd,
+	configCmd,
 	deployCmd,
 	destroyCmd,
+	doctorCmd,
 	infoCmd,
+	listCmd,
+	logsCmd,
 	maintainCmd,
+	restoreCmd,
+	scaleCmd,
+	sshCmd,
+	upgradeAllCmd,
 }
 
 var nonInteractive bool
+var stateStoreType string
+var stateStoreLocation string
 
 // GlobalFlags are the global CLIflags
 var GlobalFlags = []cli.Flag{
@@ -22,9 +50,89 @@ var GlobalFlags = []cli.Flag{
 		Usage:       "Non interactive",
 		Destination: &nonInteractive,
 	},
+	cli.StringFlag{
+		Name:        "state-store",
+		EnvVar:      "STATE_STORE",
+		Usage:       "(optional) Where to keep the state of deployments. Can be s3, gcs or local (default: the buckets of --iaas)",
+		Destination: &stateStoreType,
+	},
+	cli.StringFlag{
+		Name:        "state-store-location",
+		EnvVar:      "STATE_STORE_LOCATION",
+		Usage:       "(optional) Region of an s3 or gcs state store, or directory of a local state store (default: ~/.control-tower/state)",
+		Destination: &stateStoreLocation,
+	},
 }
 
 // NonInteractiveModeEnabled returns true if --non-interactive true has been passed in
 func NonInteractiveModeEnabled() bool {
 	return nonInteractive
 }
+
+func buildStateStore(provider iaas.Provider) (config.StateStore, error) {
+	store, err := config.NewStateStore(stateStoreType, stateStoreLocation, provider)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating state store [%v]", err)
+	}
+	return store, nil
+}
+
+// buildConcourseClient builds the client which acts on the named deployment. deployArgs are only given by deploy.
+func buildConcourseClient(name, namespace, version string, provider iaas.Provider, deployArgs *deploy.Args, stdout, stderr io.Writer, recorder events.Recorder) (*concourse.Client, error) {
+	versionFile, _ := provider.Choose(iaas.Choice{
+		AWS: resource.AWSVersionFile,
+		GCP: resource.GCPVersionFile,
+	}).([]byte)
+
+	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
+	if err != nil {
+		return nil, err
+	}
+
+	stateStore, err := buildStateStore(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	tfInputVarsFactory, err := concourse.NewTFInputVarsFactory(provider, stateStore)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating TFInputVarsFactory [%v]", err)
+	}
+
+	client := concourse.NewClient(
+		provider,
+		terraformClient,
+		tfInputVarsFactory,
+		bosh.New,
+		fly.New,
+		certs.Generate,
+		config.NewWithStateStore(provider, stateStore, name, namespace),
+		deployArgs,
+		stdout,
+		stderr,
+		util.FindUserIP,
+		certs.NewAcmeClient,
+		util.GeneratePasswordWithLength,
+		util.EightRandomLetters,
+		util.GenerateSSHKeyPair,
+		version,
+		versionFile,
+		credhub.NewClient,
+		recorder,
+	)
+
+	return client, nil
+}
+
+// outputWriters returns the recorder of progress events for the value of an --output flag, and the writers the rest of
+// a command's output goes to. With json, stdout only carries the events, so everything else goes to stderr. That
+// includes the output of terraform, bosh and the IAAS which is written straight to the process's stdout, so os.Stdout
+// is pointed at stderr too. It must be called before the command writes anything.
+func outputWriters(output string) (recorder events.Recorder, stdout, stderr io.Writer) {
+	if output != "json" {
+		return events.NopRecorder{}, os.Stdout, os.Stderr
+	}
+	recorder = events.NewJSONRecorder(os.Stdout)
+	os.Stdout = os.Stderr
+	return recorder, os.Stderr, os.Stderr
+}
@@ -6,6 +6,43 @@ import (
 )
 
 var _ = Describe("commands", func() {
+	Describe("backup", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("backup", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("control-tower backup - Writes all state of a deployment to an encrypted backup file"))
+				Expect(string(output)).To(ContainSubstring("--passphrase"))
+			})
+		})
+
+		When("no passphrase is provided", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("backup", "--iaas", "AWS", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on backup: \[failed to validate Backup flags: \[--passphrase flag not set\]\]`))
+			})
+		})
+	})
+
+	Describe("config export", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("config", "export", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("config export - Prints the settings of a deployment as YAML, with all secrets redacted"))
+			})
+		})
+
+		When("the IAAS is not specified", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("config", "export", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on config export: \[failed to validate Export flags: \[--iaas flag not set\]\]`))
+			})
+		})
+	})
+
 	Describe("deploy", func() {
 		When("using --help", func() {
 			It("displays usage details", func() {
@@ -17,6 +54,7 @@ var _ = Describe("commands", func() {
 				Expect(string(output)).To(ContainSubstring("--tls-cert value"))
 				Expect(string(output)).To(ContainSubstring("--tls-key value"))
 				Expect(string(output)).To(ContainSubstring("--db-size value"))
+				Expect(string(output)).To(ContainSubstring("--output value"))
 				Expect(string(output)).To(MatchRegexp(`--vpc-network-range value\s+\(optional\) VPC network CIDR to deploy into, only required if IAAS is AWS`))
 				Expect(string(output)).To(MatchRegexp(`--public-subnet-range value\s+\(optional\) public network CIDR \(if IAAS is AWS must be within --vpc-network-range\)`))
 				Expect(string(output)).To(MatchRegexp(`--private-subnet-range value\s+\(optional\) private network CIDR \(if IAAS is AWS must be within --vpc-network-range\)`))
@@ -33,6 +71,14 @@ var _ = Describe("commands", func() {
 			})
 		})
 
+		When("the output format is not supported", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("deploy", "--iaas", "AWS", "--output", "xml", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("--output must be text or json, not [xml]"))
+			})
+		})
+
 		When("no name is passed in", func() {
 			It("displays correct usage", func() {
 				output, err := controlTowerCommand("deploy", "--iaas", "AWS").CombinedOutput()
@@ -132,6 +178,24 @@ var _ = Describe("commands", func() {
 		})
 	})
 
+	Describe("doctor", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("doctor", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("control-tower doctor - Checks the health of a deployment, reporting each check as pass, warn or fail"))
+			})
+		})
+
+		When("the IAAS is not specified", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("doctor", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on doctor: \[failed to validate Doctor flags: \[--iaas flag not set\]\]`))
+			})
+		})
+	})
+
 	Describe("info", func() {
 		When("using --help", func() {
 			It("displays usage details", func() {
@@ -158,12 +222,54 @@ var _ = Describe("commands", func() {
 		})
 	})
 
+	Describe("list", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("list", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("control-tower list - Lists all deployments found in the account"))
+				Expect(string(output)).To(ContainSubstring("--json"))
+			})
+		})
+
+		When("the IAAS is not specified", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("list").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on list: \[failed to validate List flags: \[--iaas flag not set\]\]`))
+			})
+		})
+	})
+
+	Describe("logs", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("logs", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("control-tower logs - Collects job logs, BOSH state, terraform outputs and redacted config into a diagnostic bundle"))
+			})
+		})
+
+		When("the IAAS is not specified", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("logs", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on logs: \[failed to validate Logs flags: \[--iaas flag not set\]\]`))
+			})
+		})
+	})
+
 	Describe("maintain", func() {
 		When("using --help", func() {
 			It("displays usage details", func() {
 				output, err := controlTowerCommand("maintain", "--help", "--iaas", "AWS").CombinedOutput()
 				Expect(err).NotTo(HaveOccurred(), string(output))
 				Expect(string(output)).To(ContainSubstring("control-tower maintain - Handles maintenance operations in control-tower"))
+				Expect(string(output)).To(ContainSubstring("--rotate-credentials"))
+				Expect(string(output)).To(ContainSubstring("--renew-director-certs"))
+				Expect(string(output)).To(ContainSubstring("--recreate-workers"))
+				Expect(string(output)).To(ContainSubstring("--snapshot-db"))
+				Expect(string(output)).To(ContainSubstring("--restore-db"))
 			})
 		})
 
@@ -175,6 +281,39 @@ var _ = Describe("commands", func() {
 			})
 		})
 
+		When("an unknown credential is to be rotated", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("maintain", "--iaas", "AWS", "--rotate-credentials", "--credentials", "vault", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("unknown credential [vault], must be one of director, rds, concourse, grafana, credhub"))
+			})
+		})
+
+		When("a drain timeout is given without recreating workers", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("maintain", "--iaas", "AWS", "--drain-timeout", "5m", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("--drain-timeout can only be used with --recreate-workers"))
+			})
+		})
+
+		When("listing the procedures", func() {
+			It("shows the flag of each one without needing an IAAS", func() {
+				output, err := controlTowerCommand("maintain", "--list").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("--renew-nats-cert"))
+				Expect(string(output)).To(ContainSubstring("--restore-db"))
+			})
+		})
+
+		When("the database is snapshotted and restored at once", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("maintain", "--iaas", "AWS", "--snapshot-db", "--restore-db", "some-snapshot", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("--snapshot-db, --restore-db, --rotate-ssh-key, --rotate-encryption-key, --cleanup, --list and --status can be used at a time"))
+			})
+		})
+
 		When("no name is passed in", func() {
 			It("displays correct usage", func() {
 				output, err := controlTowerCommand("maintain", "--iaas", "AWS").CombinedOutput()
@@ -183,4 +322,79 @@ var _ = Describe("commands", func() {
 			})
 		})
 	})
+
+	Describe("restore", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("restore", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("control-tower restore - Restores all state of a deployment from an encrypted backup file"))
+				Expect(string(output)).To(ContainSubstring("--from"))
+			})
+		})
+
+		When("no backup file is provided", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("restore", "--iaas", "AWS", "--passphrase", "secret", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on restore: \[failed to validate Restore flags: \[--from flag not set\]\]`))
+			})
+		})
+	})
+
+	Describe("scale", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("scale", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("control-tower scale - Changes the number or size of Concourse workers without a full redeploy"))
+				Expect(string(output)).To(ContainSubstring("--workers"))
+			})
+		})
+
+		When("neither workers nor worker size are given", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("scale", "--iaas", "AWS", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on scale: \[failed to validate Scale flags: \[at least one of --workers and --worker-size must be set\]\]`))
+			})
+		})
+	})
+
+	Describe("ssh", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("ssh", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("control-tower ssh - Opens a shell on a VM of a deployment, tunnelling through the director"))
+			})
+		})
+
+		When("the IAAS is not specified", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("ssh", "abc").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on ssh: \[failed to validate SSH flags: \[--iaas flag not set\]\]`))
+			})
+		})
+	})
+
+	Describe("upgrade-all", func() {
+		When("using --help", func() {
+			It("displays usage details", func() {
+				output, err := controlTowerCommand("upgrade-all", "--help").CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(output))
+				Expect(string(output)).To(ContainSubstring("control-tower upgrade-all - Upgrades every deployment in the account to this version of control-tower"))
+				Expect(string(output)).To(ContainSubstring("--stop-on-failure"))
+			})
+		})
+
+		When("the IAAS is not specified", func() {
+			It("shows a meaningful error", func() {
+				output, err := controlTowerCommand("upgrade-all").CombinedOutput()
+				Expect(err).To(HaveOccurred(), string(output))
+				Expect(string(output)).To(MatchRegexp(`Error validating args on upgrade-all: \[failed to validate UpgradeAll flags: \[--iaas flag not set\]\]`))
+			})
+		})
+	})
 })
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/export"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+var initialExportArgs export.Args
+
+var exportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialExportArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialExportArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialExportArgs.Namespace,
+	},
+}
+
+func exportAction(c *cli.Context, exportArgs export.Args, provider iaas.Provider) error {
+	name := c.Args().Get(0)
+	if name == "" {
+		return errors.New("Usage is `control-tower config export <name>`")
+	}
+
+	stateStore, err := buildStateStore(provider)
+	if err != nil {
+		return err
+	}
+
+	conf, err := config.NewWithStateStore(provider, stateStore, name, exportArgs.Namespace).Load()
+	if err != nil {
+		return fmt.Errorf("Error loading config: [%v]", err)
+	}
+
+	out, err := yaml.Marshal(config.Redact(conf))
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+func validateExportArgs(c *cli.Context, exportArgs export.Args) (export.Args, error) {
+	err := exportArgs.MarkSetFlags(c)
+	if err != nil {
+		return exportArgs, fmt.Errorf("failed to mark set Export flags: [%v]", err)
+	}
+
+	if err = exportArgs.Validate(); err != nil {
+		return exportArgs, fmt.Errorf("failed to validate Export flags: [%v]", err)
+	}
+
+	return exportArgs, nil
+}
+
+var configExportCmd = cli.Command{
+	Name:      "export",
+	Usage:     "Prints the settings of a deployment as YAML, with all secrets redacted",
+	ArgsUsage: "<name>",
+	Flags:     exportFlags,
+	Action: func(c *cli.Context) error {
+		exportArgs, err := validateExportArgs(c, initialExportArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on config export: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(exportArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on config export: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, exportArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on config export: [%v]", err)
+		}
+		return exportAction(c, exportArgs, provider)
+	},
+}
+
+var configCmd = cli.Command{
+	Name:  "config",
+	Usage: "Works with the configuration of a deployment",
+	Subcommands: []cli.Command{
+		configExportCmd,
+	},
+}
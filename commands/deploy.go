@@ -3,25 +3,18 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"net"
-	"os"
 	"regexp"
 	"strings"
 
 	"gopkg.in/urfave/cli.v1"
 
-	"github.com/EngineerBetter/control-tower/bosh"
-	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/commands/deploy"
 	"github.com/EngineerBetter/control-tower/concourse"
-	"github.com/EngineerBetter/control-tower/config"
-	"github.com/EngineerBetter/control-tower/credhub"
-	"github.com/EngineerBetter/control-tower/fly"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/EngineerBetter/control-tower/iaas"
-	"github.com/EngineerBetter/control-tower/resource"
-	"github.com/EngineerBetter/control-tower/terraform"
-	"github.com/EngineerBetter/control-tower/util"
 )
 
 const maxAllowedNameLength = 11
@@ -101,6 +94,24 @@ var deployFlags = []cli.Flag{
 		Hidden:      true,
 		Destination: &initialDeployArgs.SelfUpdate,
 	},
+	cli.BoolFlag{
+		Name:        "dry-run",
+		Usage:       "(optional) Print the infrastructure and manifest changes a deploy would make, without applying them. May only be used against an existing deployment",
+		EnvVar:      "DRY_RUN",
+		Destination: &initialDeployArgs.DryRun,
+	},
+	cli.BoolFlag{
+		Name:        "resume",
+		Usage:       "(optional) Skip the phases of the deploy which completed last time with the same inputs, such as after a failed deploy",
+		EnvVar:      "RESUME",
+		Destination: &initialDeployArgs.Resume,
+	},
+	cli.BoolFlag{
+		Name:        "snapshot-db",
+		Usage:       "(optional) Snapshot the database before upgrading an existing deployment, recording the snapshot ID in config.json",
+		EnvVar:      "SNAPSHOT_DB",
+		Destination: &initialDeployArgs.SnapshotDB,
+	},
 	cli.BoolFlag{
 		Name:        "enable-global-resources",
 		Usage:       "(optional) Enables Concourse global resources. Can be true/false (default: false)",
@@ -120,6 +131,19 @@ var deployFlags = []cli.Flag{
 		Value:       "28d",
 		Destination: &initialDeployArgs.InfluxDbRetention,
 	},
+	cli.StringFlag{
+		Name:        "metrics-backend",
+		Usage:       "(optional) Metrics stack to deploy colocated on the web VM. Can be prometheus, influxdb or none (default: influxdb)",
+		EnvVar:      "METRICS_BACKEND",
+		Destination: &initialDeployArgs.MetricsBackend,
+	},
+	cli.StringFlag{
+		Name:        "prometheus-retention-period",
+		Usage:       "(optional) Sets prometheus retention period. (default: 28d)",
+		EnvVar:      "PROMETHEUS_RETENTION_PERIOD",
+		Value:       "28d",
+		Destination: &initialDeployArgs.PrometheusRetention,
+	},
 	cli.StringFlag{
 		Name:        "db-size",
 		Usage:       "(optional) Size of Concourse RDS instance. Can be small, medium, large, xlarge, 2xlarge, or 4xlarge",
@@ -274,10 +298,17 @@ var deployFlags = []cli.Flag{
 	},
 	cli.BoolFlag{
 		Name:        "no-metrics",
-		Usage:       "(optional) Don't deploy the metrics stack colocated on the web VM (default: true)",
+		Usage:       "(optional) Don't deploy the metrics stack colocated on the web VM, the same as --metrics-backend none (default: false)",
 		EnvVar:      "NO_METRICS",
 		Destination: &initialDeployArgs.NoMetrics,
 	},
+	cli.StringFlag{
+		Name:        "output",
+		Usage:       "(optional) Output format. With json, a line of json is written to stdout as each phase finishes and all other output goes to stderr. Can be text or json",
+		EnvVar:      "OUTPUT",
+		Value:       "text",
+		Destination: &initialDeployArgs.Output,
+	},
 }
 
 func deployAction(c *cli.Context, deployArgs deploy.Args, provider iaas.Provider) error {
@@ -286,9 +317,11 @@ func deployAction(c *cli.Context, deployArgs deploy.Args, provider iaas.Provider
 		return errors.New("Usage is `control-tower deploy <name>`")
 	}
 
+	recorder, stdout, stderr := outputWriters(deployArgs.Output)
+
 	version := c.App.Version
 
-	deployArgs, err := setZoneAndRegion(provider.Region(), deployArgs)
+	deployArgs, err := setZoneAndRegion(provider.Region(), deployArgs, stdout)
 	if err != nil {
 		return err
 	}
@@ -303,7 +336,7 @@ func deployAction(c *cli.Context, deployArgs deploy.Args, provider iaas.Provider
 		return err
 	}
 
-	client, err := buildClient(name, version, deployArgs, provider)
+	client, err := buildClient(name, version, deployArgs, provider, stdout, stderr, recorder)
 	if err != nil {
 		return err
 	}
@@ -324,7 +357,7 @@ func validateDeployArgs(c *cli.Context, deployArgs deploy.Args) (deploy.Args, er
 	return deployArgs, nil
 }
 
-func setZoneAndRegion(providerRegion string, deployArgs deploy.Args) (deploy.Args, error) {
+func setZoneAndRegion(providerRegion string, deployArgs deploy.Args, stdout io.Writer) (deploy.Args, error) {
 	if !deployArgs.RegionIsSet {
 		deployArgs.Region = providerRegion
 	}
@@ -339,7 +372,9 @@ func setZoneAndRegion(providerRegion string, deployArgs deploy.Args) (deploy.Arg
 		region, message := regionFromZone(deployArgs.Zone)
 		if region != "" {
 			deployArgs.Region = region
-			fmt.Print(message)
+			if _, err := fmt.Fprint(stdout, message); err != nil {
+				return deployArgs, err
+			}
 		}
 	}
 
@@ -475,44 +510,11 @@ func validateRDSSubnetSize(cidr *net.IPNet) bool {
 	return size > 4
 }
 
-func buildClient(name, version string, deployArgs deploy.Args, provider iaas.Provider) (*concourse.Client, error) {
-	versionFile, _ := provider.Choose(iaas.Choice{
-		AWS: resource.AWSVersionFile,
-		GCP: resource.GCPVersionFile,
-	}).([]byte)
-
-	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
-	if err != nil {
-		return nil, err
-	}
-
-	tfInputVarsFactory, err := concourse.NewTFInputVarsFactory(provider)
-	if err != nil {
-		return nil, fmt.Errorf("Error creating TFInputVarsFactory [%v]", err)
-	}
-
-	client := concourse.NewClient(
-		provider,
-		terraformClient,
-		tfInputVarsFactory,
-		bosh.New,
-		fly.New,
-		certs.Generate,
-		config.New(provider, name, deployArgs.Namespace),
-		&deployArgs,
-		os.Stdout,
-		os.Stderr,
-		util.FindUserIP,
-		certs.NewAcmeClient,
-		util.GeneratePasswordWithLength,
-		util.EightRandomLetters,
-		util.GenerateSSHKeyPair,
-		version,
-		versionFile,
-		credhub.NewClient,
-	)
-
-	return client, nil
+// buildClient builds the client for deploy, which records the state store in the config so the self-update pipeline uses it
+func buildClient(name, version string, deployArgs deploy.Args, provider iaas.Provider, stdout, stderr io.Writer, recorder events.Recorder) (*concourse.Client, error) {
+	deployArgs.StateStore = stateStoreType
+	deployArgs.StateStoreLocation = stateStoreLocation
+	return buildConcourseClient(name, deployArgs.Namespace, version, provider, &deployArgs, stdout, stderr, recorder)
 }
 
 var deployCmd = cli.Command{
@@ -33,21 +33,35 @@ type Args struct {
 	PersistentDiskIsSet bool
 	SelfUpdate          bool
 	SelfUpdateIsSet     bool
+	DryRun              bool
+	DryRunIsSet         bool
+	Resume              bool
+	ResumeIsSet         bool
+	SnapshotDB          bool
+	SnapshotDBIsSet     bool
 	DBSize              string
 	// DBSizeIsSet is true if the user has manually specified the db-size (ie, it's not the default)
-	DBSizeIsSet                    bool
-	RDSDiskEncryption              bool
-	RDSDiskEncryptionIsSet         bool
-	EnableGlobalResources          bool
-	EnableGlobalResourcesIsSet     bool
-	EnablePipelineInstances        bool
-	EnablePipelineInstancesIsSet   bool
-	InfluxDbRetention              string
-	InfluxDbRetentionIsSet         bool
-	Namespace                      string
-	NamespaceIsSet                 bool
-	AllowIPs                       string
-	AllowIPsIsSet                  bool
+	DBSizeIsSet                  bool
+	RDSDiskEncryption            bool
+	RDSDiskEncryptionIsSet       bool
+	EnableGlobalResources        bool
+	EnableGlobalResourcesIsSet   bool
+	EnablePipelineInstances      bool
+	EnablePipelineInstancesIsSet bool
+	InfluxDbRetention            string
+	InfluxDbRetentionIsSet       bool
+	MetricsBackend               string
+	MetricsBackendIsSet          bool
+	PrometheusRetention          string
+	PrometheusRetentionIsSet     bool
+	Namespace                    string
+	NamespaceIsSet               bool
+	AllowIPs                     string
+	AllowIPsIsSet                bool
+	// StateStore and StateStoreLocation are copied from the global --state-store flags so the
+	// self-update pipeline can find the state of the deployment
+	StateStore                     string
+	StateStoreLocation             string
 	BitbucketAuthClientID          string
 	BitbucketAuthClientIDIsSet     bool
 	BitbucketAuthClientSecret      string
@@ -103,6 +117,7 @@ type Args struct {
 	RDS1CIDRIsSet    bool
 	RDS2CIDR         string
 	RDS2CIDRIsSet    bool
+	Output           string
 }
 
 // MarkSetFlags is marking the IsSet DeployArgs
@@ -118,6 +133,10 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 				a.EnablePipelineInstancesIsSet = true
 			case "influxdb-retention-period":
 				a.InfluxDbRetentionIsSet = true
+			case "metrics-backend":
+				a.MetricsBackendIsSet = true
+			case "prometheus-retention-period":
+				a.PrometheusRetentionIsSet = true
 			case "domain":
 				a.DomainIsSet = true
 			case "tls-cert":
@@ -136,6 +155,12 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 				a.IAASIsSet = true
 			case "self-update":
 				a.SelfUpdateIsSet = true
+			case "dry-run":
+				a.DryRunIsSet = true
+			case "resume":
+				a.ResumeIsSet = true
+			case "snapshot-db":
+				a.SnapshotDBIsSet = true
 			case "db-size":
 				a.DBSizeIsSet = true
 			case "rds-disk-encryption":
@@ -188,6 +213,8 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 				a.RDS2CIDRIsSet = true
 			case "no-metrics":
 				a.NoMetricsIsSet = true
+			case "output":
+				//do nothing
 			default:
 				return fmt.Errorf("flag %q is not supported by deployment flags", f)
 			}
@@ -208,6 +235,9 @@ var WorkerSizes = []string{"medium", "large", "xlarge", "2xlarge", "4xlarge", "1
 // WebSizes are the permitted concourse web sizes
 var WebSizes = []string{"small", "medium", "large", "xlarge", "2xlarge"}
 
+// MetricsBackends are the permitted metrics backends
+var MetricsBackends = []string{"prometheus", "influxdb", "none"}
+
 // PersistentDiskSizes are the permitted concourse persistent disk sizes
 var PersistentDiskSizes = []string{"small", "default", "medium", "large"}
 
@@ -220,6 +250,22 @@ func (a Args) Validate() error {
 		return fmt.Errorf("--iaas flag not set")
 	}
 
+	if a.DryRun && a.SelfUpdate {
+		return errors.New("--dry-run cannot be used together with --self-update")
+	}
+
+	if a.DryRun && a.Resume {
+		return errors.New("--dry-run cannot be used together with --resume")
+	}
+
+	if a.DryRun && a.SnapshotDB {
+		return errors.New("--dry-run cannot be used together with --snapshot-db")
+	}
+
+	if a.Output != "text" && a.Output != "json" {
+		return fmt.Errorf("--output must be text or json, not [%s]", a.Output)
+	}
+
 	if err := a.validateCertFields(); err != nil {
 		return err
 	}
@@ -303,6 +349,10 @@ func (a Args) validateWebFields() error {
 		return fmt.Errorf("no-metrics is invalid when used with influxdb-retention-period")
 	}
 
+	if err := a.validateMetricsFields(); err != nil {
+		return err
+	}
+
 	for _, size := range WebSizes {
 		if size == a.WebSize {
 			return nil
@@ -311,6 +361,37 @@ func (a Args) validateWebFields() error {
 	return fmt.Errorf("unknown web node size: `%s`. Valid sizes are: %v", a.WebSize, WebSizes)
 }
 
+func (a Args) validateMetricsFields() error {
+	if a.NoMetricsIsSet && a.PrometheusRetentionIsSet {
+		return fmt.Errorf("no-metrics is invalid when used with prometheus-retention-period")
+	}
+
+	if !a.MetricsBackendIsSet {
+		return nil
+	}
+
+	valid := false
+	for _, backend := range MetricsBackends {
+		if backend == a.MetricsBackend {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unknown metrics backend: `%s`. Valid backends are: %v", a.MetricsBackend, MetricsBackends)
+	}
+
+	if a.NoMetricsIsSet && a.NoMetrics && a.MetricsBackend != "none" {
+		return fmt.Errorf("no-metrics is invalid when used with metrics-backend %s", a.MetricsBackend)
+	}
+	if a.InfluxDbRetentionIsSet && a.MetricsBackend != "influxdb" {
+		return fmt.Errorf("influxdb-retention-period is invalid when used with metrics-backend %s", a.MetricsBackend)
+	}
+	if a.PrometheusRetentionIsSet && a.MetricsBackend != "prometheus" {
+		return fmt.Errorf("prometheus-retention-period is invalid when used with metrics-backend %s", a.MetricsBackend)
+	}
+	return nil
+}
+
 func (a Args) validatePersistentDiskFields() error {
 	for _, size := range PersistentDiskSizes {
 		if size == a.PersistentDiskSize {
@@ -64,6 +64,7 @@ nLRbwHOoq7hHwg==
 		WorkerSize:                "xlarge",
 		WorkerType:                "",
 		WorkerTypeIsSet:           false,
+		Output:                    "text",
 	}
 	tests := []struct {
 		name         string
@@ -79,6 +80,25 @@ nLRbwHOoq7hHwg==
 			},
 			wantErr: false,
 		},
+		{
+			name: "Output format not supported",
+			modification: func() Args {
+				args := defaultFields
+				args.Output = "xml"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--output must be text or json, not [xml]",
+		},
+		{
+			name: "JSON output",
+			modification: func() Args {
+				args := defaultFields
+				args.Output = "json"
+				return args
+			},
+			wantErr: false,
+		},
 		{
 			name: "All cert fields should be set",
 			modification: func() Args {
@@ -101,6 +121,39 @@ nLRbwHOoq7hHwg==
 			wantErr:     true,
 			expectedErr: "--tls-cert requires --tls-key to also be provided",
 		},
+		{
+			name: "DryRun cannot be used with SelfUpdate",
+			modification: func() Args {
+				args := defaultFields
+				args.DryRun = true
+				args.SelfUpdate = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--dry-run cannot be used together with --self-update",
+		},
+		{
+			name: "Dry run cannot be combined with resume",
+			modification: func() Args {
+				args := defaultFields
+				args.DryRun = true
+				args.Resume = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--dry-run cannot be used together with --resume",
+		},
+		{
+			name: "Dry run cannot be combined with snapshot-db",
+			modification: func() Args {
+				args := defaultFields
+				args.DryRun = true
+				args.SnapshotDB = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--dry-run cannot be used together with --snapshot-db",
+		},
 		{
 			name: "IAAS not set",
 			modification: func() Args {
@@ -277,6 +330,80 @@ nLRbwHOoq7hHwg==
 			wantErr:     true,
 			expectedErr: "no-metrics is invalid when used with influxdb-retention-period",
 		},
+		{
+			name: "Prometheus is a valid metrics backend",
+			modification: func() Args {
+				args := defaultFields
+				args.MetricsBackendIsSet = true
+				args.MetricsBackend = "prometheus"
+				args.PrometheusRetentionIsSet = true
+				args.PrometheusRetention = "90d"
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "An unknown metrics backend is an error",
+			modification: func() Args {
+				args := defaultFields
+				args.MetricsBackendIsSet = true
+				args.MetricsBackend = "graphite"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: fmt.Sprintf("unknown metrics backend: `graphite`. Valid backends are: %v", MetricsBackends),
+		},
+		{
+			name: "Setting --no-metrics with a metrics backend is an error",
+			modification: func() Args {
+				args := defaultFields
+				args.NoMetricsIsSet = true
+				args.NoMetrics = true
+				args.MetricsBackendIsSet = true
+				args.MetricsBackend = "prometheus"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "no-metrics is invalid when used with metrics-backend prometheus",
+		},
+		{
+			name: "Setting --influxdb-retention-period with the prometheus backend is an error",
+			modification: func() Args {
+				args := defaultFields
+				args.MetricsBackendIsSet = true
+				args.MetricsBackend = "prometheus"
+				args.InfluxDbRetentionIsSet = true
+				args.InfluxDbRetention = "3d"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "influxdb-retention-period is invalid when used with metrics-backend prometheus",
+		},
+		{
+			name: "Setting --prometheus-retention-period with the influxdb backend is an error",
+			modification: func() Args {
+				args := defaultFields
+				args.MetricsBackendIsSet = true
+				args.MetricsBackend = "influxdb"
+				args.PrometheusRetentionIsSet = true
+				args.PrometheusRetention = "3d"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "prometheus-retention-period is invalid when used with metrics-backend influxdb",
+		},
+		{
+			name: "Setting --no-metrics and --prometheus-retention-period together is an error",
+			modification: func() Args {
+				args := defaultFields
+				args.NoMetricsIsSet = true
+				args.PrometheusRetentionIsSet = true
+				args.PrometheusRetention = "3d"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "no-metrics is invalid when used with prometheus-retention-period",
+		},
 		{
 			name: "-invalid is not a valid GitHub user for main auth",
 			modification: func() Args {
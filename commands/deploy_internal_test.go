@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -88,6 +89,7 @@ func Test_setZoneAndRegion(t *testing.T) {
 		providerRegion string
 		wantErr        bool
 		expectedRegion string
+		wantOutput     string
 	}{
 		{
 			name: "region should default to eu-west-1 when iaas is AWS",
@@ -115,10 +117,22 @@ func Test_setZoneAndRegion(t *testing.T) {
 			providerRegion: "eu-west-1",
 			expectedRegion: "us-east-1",
 		},
+		{
+			name: "region should be taken from the zone if only the zone is provided",
+			args: deploy.Args{
+				IAAS:      "AWS",
+				Zone:      "us-east-1a",
+				ZoneIsSet: true,
+			},
+			providerRegion: "eu-west-1",
+			expectedRegion: "us-east-1",
+			wantOutput:     "No region provided, please note that your zone will be paired with a matching region.\nThis region: us-east-1 is used for deployment.\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actual, err := setZoneAndRegion(tt.providerRegion, tt.args)
+			var output bytes.Buffer
+			actual, err := setZoneAndRegion(tt.providerRegion, tt.args, &output)
 
 			if err == nil && tt.wantErr {
 				t.Errorf("setZoneAndRegion() error = %v, wantErr %v", err, tt.wantErr)
@@ -127,6 +141,10 @@ func Test_setZoneAndRegion(t *testing.T) {
 			if actual.Region != tt.expectedRegion {
 				t.Errorf("setZoneAndRegion() region = %v, expected %v", actual.Region, tt.expectedRegion)
 			}
+
+			if output.String() != tt.wantOutput {
+				t.Errorf("setZoneAndRegion() output = %q, expected %q", output.String(), tt.wantOutput)
+			}
 		})
 	}
 }
@@ -5,16 +5,8 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/EngineerBetter/control-tower/bosh"
-	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/commands/destroy"
-	"github.com/EngineerBetter/control-tower/concourse"
-	"github.com/EngineerBetter/control-tower/config"
-	"github.com/EngineerBetter/control-tower/credhub"
-	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/iaas"
-	"github.com/EngineerBetter/control-tower/resource"
-	"github.com/EngineerBetter/control-tower/terraform"
 	"github.com/EngineerBetter/control-tower/util"
 
 	"gopkg.in/urfave/cli.v1"
@@ -42,6 +34,13 @@ var destroyFlags = []cli.Flag{
 		EnvVar:      "NAMESPACE",
 		Destination: &initialDestroyArgs.Namespace,
 	},
+	cli.StringFlag{
+		Name:        "output",
+		Usage:       "(optional) Output format. With json, a line of json is written to stdout as each phase finishes and all other output goes to stderr. Can be text or json",
+		EnvVar:      "OUTPUT",
+		Value:       "text",
+		Destination: &initialDestroyArgs.Output,
+	},
 }
 
 func destroyAction(c *cli.Context, destroyArgs destroy.Args, provider iaas.Provider) error {
@@ -50,21 +49,23 @@ func destroyAction(c *cli.Context, destroyArgs destroy.Args, provider iaas.Provi
 		return errors.New("Usage is `control-tower destroy <name>`")
 	}
 
+	recorder, stdout, stderr := outputWriters(destroyArgs.Output)
+
 	if !NonInteractiveModeEnabled() {
-		confirm, err := util.CheckConfirmation(os.Stdin, os.Stdout, name)
+		confirm, err := util.CheckConfirmation(os.Stdin, stdout, name)
 		if err != nil {
 			return err
 		}
 
 		if !confirm {
-			fmt.Println("Bailing out...")
-			return nil
+			_, err = fmt.Fprintln(stdout, "Bailing out...")
+			return err
 		}
 	}
 
 	version := c.App.Version
 
-	client, err := buildDestroyClient(name, version, destroyArgs, provider)
+	client, err := buildConcourseClient(name, destroyArgs.Namespace, version, provider, nil, stdout, stderr, recorder)
 	if err != nil {
 		return err
 	}
@@ -84,46 +85,6 @@ func validateDestroyArgs(c *cli.Context, destroyArgs destroy.Args) (destroy.Args
 	return destroyArgs, nil
 }
 
-func buildDestroyClient(name, version string, destroyArgs destroy.Args, provider iaas.Provider) (*concourse.Client, error) {
-	versionFile, _ := provider.Choose(iaas.Choice{
-		AWS: resource.AWSVersionFile,
-		GCP: resource.GCPVersionFile,
-	}).([]byte)
-
-	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
-	if err != nil {
-		return nil, err
-	}
-
-	tfInputVarsFactory, err := concourse.NewTFInputVarsFactory(provider)
-	if err != nil {
-		return nil, fmt.Errorf("Error creating TFInputVarsFactory [%v]", err)
-	}
-
-	client := concourse.NewClient(
-		provider,
-		terraformClient,
-		tfInputVarsFactory,
-		bosh.New,
-		fly.New,
-		certs.Generate,
-		config.New(provider, name, destroyArgs.Namespace),
-		nil,
-		os.Stdout,
-		os.Stderr,
-		util.FindUserIP,
-		certs.NewAcmeClient,
-		util.GeneratePasswordWithLength,
-		util.EightRandomLetters,
-		util.GenerateSSHKeyPair,
-		version,
-		versionFile,
-		credhub.NewClient,
-	)
-
-	return client, nil
-}
-
 var destroyCmd = cli.Command{
 	Name:      "destroy",
 	Aliases:   []string{"x"},
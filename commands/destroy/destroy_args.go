@@ -14,6 +14,7 @@ type Args struct {
 	Namespace      string
 	NamespaceIsSet bool
 	IAASIsSet      bool
+	Output         string
 }
 
 //MarkSetFlags is marking which destroy Args have been set
@@ -27,6 +28,8 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 				a.NamespaceIsSet = true
 			case "iaas":
 				a.IAASIsSet = true
+			case "output":
+				//do nothing
 			default:
 				return fmt.Errorf("flag %q is not supported by deployment flags", f)
 			}
@@ -39,6 +42,9 @@ func (a *Args) Validate() error {
 	if !a.IAASIsSet {
 		return fmt.Errorf("--iaas flag not set")
 	}
+	if a.Output != "text" && a.Output != "json" {
+		return fmt.Errorf("--output must be text or json, not [%s]", a.Output)
+	}
 	return nil
 }
 
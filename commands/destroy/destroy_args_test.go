@@ -12,6 +12,7 @@ func TestDestroyArgs_Validate(t *testing.T) {
 		Region:    "eu-west-1",
 		IAAS:      "AWS",
 		IAASIsSet: true,
+		Output:    "text",
 	}
 	tests := []struct {
 		name         string
@@ -37,6 +38,25 @@ func TestDestroyArgs_Validate(t *testing.T) {
 			wantErr:     true,
 			expectedErr: "--iaas flag not set",
 		},
+		{
+			name: "Output format not supported",
+			modification: func() Args {
+				args := defaultFields
+				args.Output = "xml"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--output must be text or json, not [xml]",
+		},
+		{
+			name: "JSON output",
+			modification: func() Args {
+				args := defaultFields
+				args.Output = "json"
+				return args
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/doctor"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+var initialDoctorArgs doctor.Args
+
+var doctorFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialDoctorArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialDoctorArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialDoctorArgs.Namespace,
+	},
+}
+
+func doctorAction(c *cli.Context, doctorArgs doctor.Args, provider iaas.Provider) error {
+	name := c.Args().Get(0)
+	if name == "" {
+		return errors.New("Usage is `control-tower doctor <name>`")
+	}
+
+	client, err := buildConcourseClient(name, doctorArgs.Namespace, c.App.Version, provider, nil, os.Stdout, os.Stderr, nil)
+	if err != nil {
+		return err
+	}
+
+	return client.Doctor()
+}
+
+func validateDoctorArgs(c *cli.Context, doctorArgs doctor.Args) (doctor.Args, error) {
+	err := doctorArgs.MarkSetFlags(c)
+	if err != nil {
+		return doctorArgs, fmt.Errorf("failed to mark set Doctor flags: [%v]", err)
+	}
+
+	if err = doctorArgs.Validate(); err != nil {
+		return doctorArgs, fmt.Errorf("failed to validate Doctor flags: [%v]", err)
+	}
+
+	return doctorArgs, nil
+}
+
+var doctorCmd = cli.Command{
+	Name:      "doctor",
+	Usage:     "Checks the health of a deployment, reporting each check as pass, warn or fail",
+	ArgsUsage: "<name>",
+	Flags:     doctorFlags,
+	Action: func(c *cli.Context) error {
+		doctorArgs, err := validateDoctorArgs(c, initialDoctorArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on doctor: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(doctorArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on doctor: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, doctorArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on doctor: [%v]", err)
+		}
+		return doctorAction(c, doctorArgs, provider)
+	},
+}
@@ -5,20 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/urfave/cli.v1"
 
-	"github.com/EngineerBetter/control-tower/bosh"
-	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/commands/info"
 	"github.com/EngineerBetter/control-tower/concourse"
-	"github.com/EngineerBetter/control-tower/config"
-	"github.com/EngineerBetter/control-tower/credhub"
-	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/iaas"
-	"github.com/EngineerBetter/control-tower/resource"
-	"github.com/EngineerBetter/control-tower/terraform"
-	"github.com/EngineerBetter/control-tower/util"
 )
 
 var initialInfoArgs info.Args
@@ -36,6 +29,21 @@ var infoFlags = []cli.Flag{
 		EnvVar:      "JSON",
 		Destination: &initialInfoArgs.JSON,
 	},
+	cli.StringFlag{
+		Name:        "format",
+		Usage:       "(optional) Output in the versioned info schema. Can be json, yaml or table",
+		Destination: &initialInfoArgs.Format,
+	},
+	cli.StringFlag{
+		Name:        "template",
+		Usage:       "(optional) Output the result of a Go template run against the versioned info schema, e.g. '{{.concourse.atc_public_ip}}'",
+		Destination: &initialInfoArgs.Template,
+	},
+	cli.BoolFlag{
+		Name:        "show-secrets",
+		Usage:       "(optional) Include passwords, keys and other secrets in the output, which --env always includes",
+		Destination: &initialInfoArgs.ShowSecrets,
+	},
 	cli.BoolFlag{
 		Name:        "env",
 		Usage:       "(optional) Output environment variables",
@@ -43,9 +51,24 @@ var infoFlags = []cli.Flag{
 	},
 	cli.BoolFlag{
 		Name:        "cert-expiry",
-		Usage:       "(optional) Output only the expiration date of the director nats certificate",
+		Usage:       "(optional) Output only the subject, issuer, SANs and expiry of each certificate",
 		Destination: &initialInfoArgs.CertExpiry,
 	},
+	cli.BoolFlag{
+		Name:        "cost",
+		Usage:       "(optional) Output an estimate of the monthly cost of the deployment, broken down by component",
+		Destination: &initialInfoArgs.Cost,
+	},
+	cli.IntFlag{
+		Name:        "warning-days",
+		Usage:       "(optional) With --cert-expiry, exit with code 1 if a certificate expires within this many days",
+		Destination: &initialInfoArgs.WarningDays,
+	},
+	cli.IntFlag{
+		Name:        "critical-days",
+		Usage:       "(optional) With --cert-expiry, exit with code 2 if a certificate expires within this many days",
+		Destination: &initialInfoArgs.CriticalDays,
+	},
 	cli.StringFlag{
 		Name:        "iaas",
 		Usage:       "(required) IAAS, can be AWS or GCP",
@@ -68,14 +91,26 @@ func infoAction(c *cli.Context, infoArgs info.Args, provider iaas.Provider) erro
 
 	version := c.App.Version
 
-	client, err := buildInfoClient(name, version, infoArgs, provider)
+	client, err := buildConcourseClient(name, infoArgs.Namespace, version, provider, nil, os.Stdout, os.Stderr, nil)
 	if err != nil {
 		return err
 	}
+	if infoArgs.Cost {
+		estimate, err := client.EstimateCost()
+		if err != nil {
+			return err
+		}
+		return estimate.Write(os.Stdout)
+	}
+
 	i, err := client.FetchInfo()
 	if err != nil {
 		return err
 	}
+	// --env is for loading the credentials into a shell, so only it includes secrets without --show-secrets
+	if !infoArgs.ShowSecrets && !infoArgs.Env {
+		i = i.Redacted()
+	}
 	switch {
 	case infoArgs.JSON:
 		return json.NewEncoder(os.Stdout).Encode(i)
@@ -86,15 +121,46 @@ func infoAction(c *cli.Context, infoArgs info.Args, provider iaas.Provider) erro
 		}
 		_, err = os.Stdout.WriteString(env)
 		return err
+	case infoArgs.FormatIsSet:
+		return i.WriteFormat(os.Stdout, infoArgs.Format)
+	case infoArgs.TemplateIsSet:
+		return i.ExecuteTemplate(os.Stdout, infoArgs.Template)
 	case infoArgs.CertExpiry:
-		os.Stdout.WriteString(i.CertExpiry)
-		return nil
+		return writeCertExpiry(i, infoArgs)
 	default:
 		_, err := fmt.Fprint(os.Stdout, i)
 		return err
 	}
 }
 
+// Exit codes of info --cert-expiry, following the convention of monitoring plugins
+const (
+	certExpiryWarningExitCode  = 1
+	certExpiryCriticalExitCode = 2
+)
+
+func writeCertExpiry(i *concourse.Info, infoArgs info.Args) error {
+	warningDays, criticalDays := -1, -1
+	if infoArgs.WarningDaysIsSet {
+		warningDays = infoArgs.WarningDays
+	}
+	if infoArgs.CriticalDaysIsSet {
+		criticalDays = infoArgs.CriticalDays
+	}
+
+	status, err := i.WriteCertificates(os.Stdout, time.Now(), warningDays, criticalDays)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case concourse.CertificateCritical:
+		return cli.NewExitError(fmt.Sprintf("a certificate expires within %d days", criticalDays), certExpiryCriticalExitCode)
+	case concourse.CertificateWarning:
+		return cli.NewExitError(fmt.Sprintf("a certificate expires within %d days", warningDays), certExpiryWarningExitCode)
+	}
+	return nil
+}
+
 func validateInfoArgs(c *cli.Context, infoArgs info.Args) (info.Args, error) {
 	err := infoArgs.MarkSetFlags(c)
 	if err != nil {
@@ -108,46 +174,6 @@ func validateInfoArgs(c *cli.Context, infoArgs info.Args) (info.Args, error) {
 	return infoArgs, nil
 }
 
-func buildInfoClient(name, version string, infoArgs info.Args, provider iaas.Provider) (*concourse.Client, error) {
-	versionFile, _ := provider.Choose(iaas.Choice{
-		AWS: resource.AWSVersionFile,
-		GCP: resource.GCPVersionFile,
-	}).([]byte)
-
-	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
-	if err != nil {
-		return nil, err
-	}
-
-	tfInputVarsFactory, err := concourse.NewTFInputVarsFactory(provider)
-	if err != nil {
-		return nil, fmt.Errorf("Error creating TFInputVarsFactory [%v]", err)
-	}
-
-	client := concourse.NewClient(
-		provider,
-		terraformClient,
-		tfInputVarsFactory,
-		bosh.New,
-		fly.New,
-		certs.Generate,
-		config.New(provider, name, infoArgs.Namespace),
-		nil,
-		os.Stdout,
-		os.Stderr,
-		util.FindUserIP,
-		certs.NewAcmeClient,
-		util.GeneratePasswordWithLength,
-		util.EightRandomLetters,
-		util.GenerateSSHKeyPair,
-		version,
-		versionFile,
-		credhub.NewClient,
-	)
-
-	return client, nil
-}
-
 var infoCmd = cli.Command{
 	Name:      "info",
 	Aliases:   []string{"i"},
@@ -2,23 +2,37 @@ package info
 
 import (
 	"fmt"
+	"strings"
 
 	cli "gopkg.in/urfave/cli.v1"
 )
 
 // Args are arguments passed to the info command
 type Args struct {
-	Region         string
-	RegionIsSet    bool
-	JSON           bool
-	Env            bool
-	Namespace      string
-	NamespaceIsSet bool
-	IAAS           string
-	IAASIsSet      bool
-	CertExpiry     bool
+	Region            string
+	RegionIsSet       bool
+	JSON              bool
+	Env               bool
+	Namespace         string
+	NamespaceIsSet    bool
+	IAAS              string
+	IAASIsSet         bool
+	CertExpiry        bool
+	Cost              bool
+	WarningDays       int
+	WarningDaysIsSet  bool
+	CriticalDays      int
+	CriticalDaysIsSet bool
+	ShowSecrets       bool
+	Format            string
+	FormatIsSet       bool
+	Template          string
+	TemplateIsSet     bool
 }
 
+// Formats are the values of --format
+var Formats = []string{"json", "yaml", "table"}
+
 //MarkSetFlags is marking which info Args have been set
 func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 	for _, f := range c.FlagNames() {
@@ -30,7 +44,15 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 				a.NamespaceIsSet = true
 			case "iaas":
 				a.IAASIsSet = true
-			case "json", "env", "cert-expiry":
+			case "warning-days":
+				a.WarningDaysIsSet = true
+			case "critical-days":
+				a.CriticalDaysIsSet = true
+			case "format":
+				a.FormatIsSet = true
+			case "template":
+				a.TemplateIsSet = true
+			case "json", "env", "cert-expiry", "cost", "show-secrets":
 				//do nothing
 			default:
 				return fmt.Errorf("flag %q is not supported by info flags", f)
@@ -44,9 +66,47 @@ func (a *Args) Validate() error {
 	if !a.IAASIsSet {
 		return fmt.Errorf("--iaas flag not set")
 	}
+
+	outputs := 0
+	for _, isSet := range []bool{a.JSON, a.Env, a.CertExpiry, a.Cost, a.FormatIsSet, a.TemplateIsSet} {
+		if isSet {
+			outputs++
+		}
+	}
+	if outputs > 1 {
+		return fmt.Errorf("only one of --json, --env, --cert-expiry, --cost, --format and --template can be used at a time")
+	}
+	if a.FormatIsSet && !isFormat(a.Format) {
+		return fmt.Errorf("--format must be one of %s, not [%s]", strings.Join(Formats, ", "), a.Format)
+	}
+	if a.TemplateIsSet && a.Template == "" {
+		return fmt.Errorf("--template must not be empty")
+	}
+
+	if (a.WarningDaysIsSet || a.CriticalDaysIsSet) && !a.CertExpiry {
+		return fmt.Errorf("--warning-days and --critical-days can only be used with --cert-expiry")
+	}
+	if a.WarningDaysIsSet && a.WarningDays < 0 {
+		return fmt.Errorf("--warning-days must not be negative, not [%d]", a.WarningDays)
+	}
+	if a.CriticalDaysIsSet && a.CriticalDays < 0 {
+		return fmt.Errorf("--critical-days must not be negative, not [%d]", a.CriticalDays)
+	}
+	if a.WarningDaysIsSet && a.CriticalDaysIsSet && a.CriticalDays > a.WarningDays {
+		return fmt.Errorf("--critical-days [%d] must not be more than --warning-days [%d]", a.CriticalDays, a.WarningDays)
+	}
 	return nil
 }
 
+func isFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
 // FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
 type FlagSetChecker interface {
 	IsSet(name string) bool
@@ -40,6 +40,111 @@ func TestInfoArgs_Validate(t *testing.T) {
 			wantErr:     true,
 			expectedErr: "--iaas flag not set",
 		},
+		{
+			name: "YAML format",
+			modification: func() Args {
+				args := defaultFields
+				args.Format = "yaml"
+				args.FormatIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Format not supported",
+			modification: func() Args {
+				args := defaultFields
+				args.Format = "xml"
+				args.FormatIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--format must be one of json, yaml, table, not [xml]",
+		},
+		{
+			name: "Template and format",
+			modification: func() Args {
+				args := defaultFields
+				args.Format = "json"
+				args.FormatIsSet = true
+				args.Template = "{{.concourse.url}}"
+				args.TemplateIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --json, --env, --cert-expiry, --cost, --format and --template can be used at a time",
+		},
+		{
+			name: "Cost and json",
+			modification: func() Args {
+				args := defaultFields
+				args.Cost = true
+				args.JSON = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --json, --env, --cert-expiry, --cost, --format and --template can be used at a time",
+		},
+		{
+			name: "Empty template",
+			modification: func() Args {
+				args := defaultFields
+				args.TemplateIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--template must not be empty",
+		},
+		{
+			name: "Certificate expiry thresholds",
+			modification: func() Args {
+				args := defaultFields
+				args.CertExpiry = true
+				args.WarningDays = 30
+				args.WarningDaysIsSet = true
+				args.CriticalDays = 0
+				args.CriticalDaysIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Threshold without cert expiry",
+			modification: func() Args {
+				args := defaultFields
+				args.WarningDays = 30
+				args.WarningDaysIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--warning-days and --critical-days can only be used with --cert-expiry",
+		},
+		{
+			name: "Negative threshold",
+			modification: func() Args {
+				args := defaultFields
+				args.CertExpiry = true
+				args.CriticalDays = -1
+				args.CriticalDaysIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--critical-days must not be negative, not [-1]",
+		},
+		{
+			name: "Critical threshold more than the warning threshold",
+			modification: func() Args {
+				args := defaultFields
+				args.CertExpiry = true
+				args.WarningDays = 7
+				args.WarningDaysIsSet = true
+				args.CriticalDays = 30
+				args.CriticalDaysIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--critical-days [30] must not be more than --warning-days [7]",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
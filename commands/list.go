@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/list"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+var initialListArgs list.Args
+
+var listFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialListArgs.Region,
+	},
+	cli.BoolFlag{
+		Name:        "json",
+		Usage:       "(optional) Output as json",
+		EnvVar:      "JSON",
+		Destination: &initialListArgs.JSON,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialListArgs.IAAS,
+	},
+}
+
+type deploymentSummary struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	Region      string `json:"region"`
+	IAAS        string `json:"iaas"`
+	Version     string `json:"version"`
+	Domain      string `json:"domain"`
+	WorkerCount int    `json:"worker_count"`
+}
+
+func listAction(listArgs list.Args, provider iaas.Provider) error {
+	store, err := buildStateStore(provider)
+	if err != nil {
+		return err
+	}
+
+	configs, err := config.List(store)
+	if err != nil {
+		return fmt.Errorf("Error listing deployments: [%v]", err)
+	}
+
+	summaries := []deploymentSummary{}
+	for _, conf := range configs {
+		summaries = append(summaries, deploymentSummary{
+			Name:        conf.Project,
+			Namespace:   conf.Namespace,
+			Region:      conf.Region,
+			IAAS:        conf.IAAS,
+			Version:     conf.Version,
+			Domain:      conf.Domain,
+			WorkerCount: conf.ConcourseWorkerCount,
+		})
+	}
+
+	if listArgs.JSON {
+		return json.NewEncoder(os.Stdout).Encode(summaries)
+	}
+	return writeDeploymentTable(os.Stdout, summaries)
+}
+
+func writeDeploymentTable(w io.Writer, summaries []deploymentSummary) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tNAMESPACE\tREGION\tIAAS\tVERSION\tDOMAIN\tWORKERS")
+	for _, s := range summaries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n", s.Name, s.Namespace, s.Region, s.IAAS, s.Version, s.Domain, s.WorkerCount)
+	}
+	return tw.Flush()
+}
+
+func validateListArgs(c *cli.Context, listArgs list.Args) (list.Args, error) {
+	err := listArgs.MarkSetFlags(c)
+	if err != nil {
+		return listArgs, fmt.Errorf("failed to mark set List flags: [%v]", err)
+	}
+
+	if err = listArgs.Validate(); err != nil {
+		return listArgs, fmt.Errorf("failed to validate List flags: [%v]", err)
+	}
+
+	return listArgs, nil
+}
+
+var listCmd = cli.Command{
+	Name:    "list",
+	Aliases: []string{"l"},
+	Usage:   "Lists all deployments found in the account",
+	Flags:   listFlags,
+	Action: func(c *cli.Context) error {
+		listArgs, err := validateListArgs(c, initialListArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on list: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(listArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on list: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, listArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on list: [%v]", err)
+		}
+		return listAction(listArgs, provider)
+	},
+}
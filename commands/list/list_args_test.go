@@ -0,0 +1,133 @@
+package list_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/commands/list"
+)
+
+func TestListArgs_Validate(t *testing.T) {
+	defaultFields := Args{
+		Region:    "eu-west-1",
+		JSON:      false,
+		IAAS:      "AWS",
+		IAASIsSet: true,
+	}
+	tests := []struct {
+		name         string
+		modification func() Args
+		outcomeCheck func(Args) bool
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "Default args",
+			modification: func() Args {
+				return defaultFields
+			},
+			wantErr: false,
+		},
+		{
+			name: "IAAS not set",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--iaas flag not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.modification()
+			err := args.Validate()
+			if (err != nil) != tt.wantErr || (err != nil && tt.wantErr && !strings.Contains(err.Error(), tt.expectedErr)) {
+				if err != nil {
+					t.Errorf("ListArgs.Validate() %v test failed.\nFailed with error = %v,\nExpected error = %v,\nShould fail %v\nWith args: %#v", tt.name, err.Error(), tt.expectedErr, tt.wantErr, args)
+				} else {
+					t.Errorf("ListArgs.Validate() %v test failed.\nShould fail %v\nWith args: %#v", tt.name, tt.wantErr, args)
+				}
+			}
+			if tt.outcomeCheck != nil {
+				if tt.outcomeCheck(args) {
+					t.Errorf("ListArgs.Validate() %v test failed.\nShould fail %v\nWith args: %#v", tt.name, tt.wantErr, args)
+				}
+			}
+		})
+	}
+}
+
+type FakeFlagSetChecker struct {
+	names          []string
+	specifiedFlags []string
+}
+
+func NewFakeFlagSetChecker(names, specifiedFlags []string) FakeFlagSetChecker {
+	return FakeFlagSetChecker{
+		names:          names,
+		specifiedFlags: specifiedFlags,
+	}
+}
+
+func (f *FakeFlagSetChecker) IsSet(desired string) bool {
+	for _, flag := range f.specifiedFlags {
+		if desired == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FakeFlagSetChecker) FlagNames() (names []string) {
+	return f.names
+}
+
+func TestListArgs_MarkSetFlags(t *testing.T) {
+	tests := []struct {
+		name           string
+		names          []string
+		specifiedFlags []string
+		want           Args
+		wantErr        string
+	}{
+		{
+			name:           "region and iaas",
+			names:          []string{"region", "iaas", "json"},
+			specifiedFlags: []string{"region", "iaas", "json"},
+			want:           Args{RegionIsSet: true, IAASIsSet: true},
+		},
+		{
+			name:           "flags which weren't given",
+			names:          []string{"region", "iaas", "json"},
+			specifiedFlags: []string{"iaas"},
+			want:           Args{IAASIsSet: true},
+		},
+		{
+			name:           "an unknown flag",
+			names:          []string{"iaas", "namespace"},
+			specifiedFlags: []string{"namespace"},
+			wantErr:        `flag "namespace" is not supported by list flags`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewFakeFlagSetChecker(tt.names, tt.specifiedFlags)
+			var args Args
+			err := args.MarkSetFlags(&checker)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Args.MarkSetFlags() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Args.MarkSetFlags() error = %v", err)
+			}
+			if args != tt.want {
+				t.Errorf("Args.MarkSetFlags() = %#v, want %#v", args, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/logs"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+var initialLogsArgs logs.Args
+
+var logsFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialLogsArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialLogsArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialLogsArgs.Namespace,
+	},
+	cli.StringFlag{
+		Name:        "instance-groups",
+		Usage:       "(optional) Comma separated instance groups to fetch the job logs of",
+		Value:       "web,worker",
+		Destination: &initialLogsArgs.InstanceGroups,
+	},
+	cli.StringFlag{
+		Name:        "to",
+		Usage:       "(optional) Path of the file to write the bundle to (default: <deployment>-logs-<timestamp>.tgz)",
+		Destination: &initialLogsArgs.To,
+	},
+}
+
+func logsAction(c *cli.Context, logsArgs logs.Args, provider iaas.Provider) error {
+	name := c.Args().Get(0)
+	if name == "" {
+		return errors.New("Usage is `control-tower logs <name>`")
+	}
+
+	client, err := buildConcourseClient(name, logsArgs.Namespace, c.App.Version, provider, nil, os.Stdout, os.Stderr, nil)
+	if err != nil {
+		return err
+	}
+
+	return client.Logs(logsArgs)
+}
+
+func validateLogsArgs(c *cli.Context, logsArgs logs.Args) (logs.Args, error) {
+	err := logsArgs.MarkSetFlags(c)
+	if err != nil {
+		return logsArgs, fmt.Errorf("failed to mark set Logs flags: [%v]", err)
+	}
+
+	if err = logsArgs.Validate(); err != nil {
+		return logsArgs, fmt.Errorf("failed to validate Logs flags: [%v]", err)
+	}
+
+	return logsArgs, nil
+}
+
+var logsCmd = cli.Command{
+	Name:      "logs",
+	Usage:     "Collects job logs, BOSH state, terraform outputs and redacted config into a diagnostic bundle",
+	ArgsUsage: "<name>",
+	Flags:     logsFlags,
+	Action: func(c *cli.Context) error {
+		logsArgs, err := validateLogsArgs(c, initialLogsArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on logs: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(logsArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on logs: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, logsArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on logs: [%v]", err)
+		}
+		return logsAction(c, logsArgs, provider)
+	},
+}
@@ -0,0 +1,90 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+// Args are arguments passed to the logs command
+type Args struct {
+	Region              string
+	RegionIsSet         bool
+	Namespace           string
+	NamespaceIsSet      bool
+	IAAS                string
+	IAASIsSet           bool
+	InstanceGroups      string
+	InstanceGroupsIsSet bool
+	To                  string
+	ToIsSet             bool
+}
+
+//MarkSetFlags is marking which logs Args have been set
+func (a *Args) MarkSetFlags(c FlagSetChecker) error {
+	for _, f := range c.FlagNames() {
+		if c.IsSet(f) {
+			switch f {
+			case "region":
+				a.RegionIsSet = true
+			case "namespace":
+				a.NamespaceIsSet = true
+			case "iaas":
+				a.IAASIsSet = true
+			case "instance-groups":
+				a.InstanceGroupsIsSet = true
+			case "to":
+				a.ToIsSet = true
+			default:
+				return fmt.Errorf("flag %q is not supported by logs flags", f)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Args) Validate() error {
+	if !a.IAASIsSet {
+		return fmt.Errorf("--iaas flag not set")
+	}
+	for _, group := range strings.Split(a.InstanceGroups, ",") {
+		if strings.TrimSpace(group) == "" {
+			return fmt.Errorf("--instance-groups must be a comma separated list of instance groups, not [%s]", a.InstanceGroups)
+		}
+	}
+	if a.ToIsSet && a.To == "" {
+		return fmt.Errorf("--to must not be empty")
+	}
+	return nil
+}
+
+// Groups returns the instance groups to fetch logs from
+func (a *Args) Groups() []string {
+	var groups []string
+	for _, group := range strings.Split(a.InstanceGroups, ",") {
+		groups = append(groups, strings.TrimSpace(group))
+	}
+	return groups
+}
+
+// FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
+type FlagSetChecker interface {
+	IsSet(name string) bool
+	FlagNames() (names []string)
+}
+
+// ContextWrapper wraps a CLI context for testing
+type ContextWrapper struct {
+	c *cli.Context
+}
+
+// IsSet tells you if a user provided a flag
+func (t *ContextWrapper) IsSet(name string) bool {
+	return t.c.IsSet(name)
+}
+
+// FlagNames lists all flags it's possible for a user to provide
+func (t *ContextWrapper) FlagNames() (names []string) {
+	return t.c.FlagNames()
+}
@@ -0,0 +1,83 @@
+package logs_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/commands/logs"
+)
+
+func TestLogsArgs_Validate(t *testing.T) {
+	defaultFields := Args{
+		Region:         "eu-west-1",
+		IAAS:           "AWS",
+		IAASIsSet:      true,
+		InstanceGroups: "web,worker",
+	}
+	tests := []struct {
+		name         string
+		modification func() Args
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "Default args",
+			modification: func() Args {
+				return defaultFields
+			},
+			wantErr: false,
+		},
+		{
+			name: "IAAS not set",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--iaas flag not set",
+		},
+		{
+			name: "Empty instance group",
+			modification: func() Args {
+				args := defaultFields
+				args.InstanceGroups = "web,,worker"
+				args.InstanceGroupsIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--instance-groups must be a comma separated list of instance groups, not [web,,worker]",
+		},
+		{
+			name: "Empty to",
+			modification: func() Args {
+				args := defaultFields
+				args.ToIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--to must not be empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.modification()
+			err := args.Validate()
+			if (err != nil) != tt.wantErr || (err != nil && tt.wantErr && !strings.Contains(err.Error(), tt.expectedErr)) {
+				if err != nil {
+					t.Errorf("LogsArgs.Validate() %v test failed.\nFailed with error = %v,\nExpected error = %v,\nShould fail %v\nWith args: %#v", tt.name, err.Error(), tt.expectedErr, tt.wantErr, args)
+				} else {
+					t.Errorf("LogsArgs.Validate() %v test failed.\nShould fail %v\nWith args: %#v", tt.name, tt.wantErr, args)
+				}
+			}
+		})
+	}
+}
+
+func TestLogsArgs_Groups(t *testing.T) {
+	args := Args{InstanceGroups: "web, worker"}
+	if got := args.Groups(); !reflect.DeepEqual(got, []string{"web", "worker"}) {
+		t.Errorf("LogsArgs.Groups() = %v, want [web worker]", got)
+	}
+}
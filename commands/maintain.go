@@ -3,37 +3,26 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"text/tabwriter"
+	"time"
 
 	"gopkg.in/urfave/cli.v1"
 
-	"github.com/EngineerBetter/control-tower/bosh"
-	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/commands/maintain"
-	"github.com/EngineerBetter/control-tower/concourse"
-	"github.com/EngineerBetter/control-tower/config"
-	"github.com/EngineerBetter/control-tower/credhub"
-	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/iaas"
-	"github.com/EngineerBetter/control-tower/resource"
-	"github.com/EngineerBetter/control-tower/terraform"
-	"github.com/EngineerBetter/control-tower/util"
 )
 
 var initialMaintainArgs maintain.Args
 
-var maintainFlags = []cli.Flag{
+var maintainFlags = append([]cli.Flag{
 	cli.StringFlag{
 		Name:        "region",
 		Usage:       "(optional) AWS region",
 		EnvVar:      "AWS_REGION",
 		Destination: &initialMaintainArgs.Region,
 	},
-	cli.BoolFlag{
-		Name:        "renew-nats-cert",
-		Usage:       "(optional) Rotate nats certificate",
-		Destination: &initialMaintainArgs.RenewNatsCert,
-	},
 	cli.StringFlag{
 		Name:        "iaas",
 		Usage:       "(required) IAAS, can be AWS or GCP",
@@ -48,10 +37,69 @@ var maintainFlags = []cli.Flag{
 	},
 	cli.IntFlag{
 		Name:        "stage",
-		Usage:       "(optional) Set the desired stage for nats rotation tasks",
+		Usage:       "(optional) Set the desired stage for nats rotation, director cert renewal or encryption key rotation tasks",
 		EnvVar:      "STAGE",
 		Destination: &initialMaintainArgs.Stage,
 	},
+	cli.StringFlag{
+		Name:        "credentials",
+		Usage:       "(optional) Comma separated list of the credentials to rotate with --rotate-credentials. Can be director, rds, concourse, grafana or credhub (default: all of them)",
+		EnvVar:      "CREDENTIALS",
+		Destination: &initialMaintainArgs.Credentials,
+	},
+	cli.DurationFlag{
+		Name:        "drain-timeout",
+		Usage:       "(optional) How long to wait for each worker to drain with --recreate-workers",
+		EnvVar:      "DRAIN_TIMEOUT",
+		Value:       time.Hour,
+		Destination: &initialMaintainArgs.DrainTimeout,
+	},
+	cli.BoolFlag{
+		Name:        "all",
+		Usage:       "(optional) With --cleanup, delete every unused release and stemcell rather than keeping the two most recent, and every orphaned disk",
+		Destination: &initialMaintainArgs.All,
+	},
+	cli.DurationFlag{
+		Name:        "orphaned-disk-age",
+		Usage:       "(optional) With --cleanup, delete the disks orphaned longer ago than this, e.g. 168h, and keep the others",
+		EnvVar:      "ORPHANED_DISK_AGE",
+		Destination: &initialMaintainArgs.OrphanedDiskAge,
+	},
+	cli.BoolFlag{
+		Name:        "list",
+		Usage:       "(optional) List the maintenance procedures which can be run",
+		Destination: &initialMaintainArgs.List,
+	},
+	cli.BoolFlag{
+		Name:        "status",
+		Usage:       "(optional) Show the progress of the last run of each maintenance procedure",
+		Destination: &initialMaintainArgs.Status,
+	},
+	cli.StringFlag{
+		Name:        "output",
+		Usage:       "(optional) Output format. With json, a line of json is written to stdout as each phase finishes and all other output goes to stderr. Can be text or json",
+		EnvVar:      "OUTPUT",
+		Value:       "text",
+		Destination: &initialMaintainArgs.Output,
+	},
+}, procedureFlags()...)
+
+// procedureFlags returns a flag for each registered maintenance procedure, which runs it
+func procedureFlags() []cli.Flag {
+	var flags []cli.Flag
+	for _, p := range maintain.Procedures() {
+		usage := "(optional) " + p.Description
+		if p.Argument == "" {
+			flags = append(flags, cli.BoolFlag{Name: p.Name, Usage: usage})
+			continue
+		}
+		flags = append(flags, cli.StringFlag{
+			Name:        p.Name,
+			Usage:       usage,
+			Destination: &initialMaintainArgs.ProcedureArgument,
+		})
+	}
+	return flags
 }
 
 func maintainAction(c *cli.Context, maintainArgs maintain.Args, provider iaas.Provider) error {
@@ -60,9 +108,11 @@ func maintainAction(c *cli.Context, maintainArgs maintain.Args, provider iaas.Pr
 		return errors.New("Usage is `control-tower maintain <name>`")
 	}
 
+	recorder, stdout, stderr := outputWriters(maintainArgs.Output)
+
 	version := c.App.Version
 
-	client, err := buildMaintainClient(name, version, maintainArgs, provider)
+	client, err := buildConcourseClient(name, maintainArgs.Namespace, version, provider, nil, stdout, stderr, recorder)
 	if err != nil {
 		return err
 	}
@@ -74,6 +124,16 @@ func maintainAction(c *cli.Context, maintainArgs maintain.Args, provider iaas.Pr
 	return nil
 }
 
+// listMaintenanceProcedures prints the procedures which maintain can run, with the flag that runs each one
+func listMaintenanceProcedures(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "FLAG\tDESCRIPTION")
+	for _, p := range maintain.Procedures() {
+		fmt.Fprintf(tw, "--%s\t%s\n", p.Name, p.Description)
+	}
+	return tw.Flush()
+}
+
 func validateMaintainArgs(c *cli.Context, maintainArgs maintain.Args) (maintain.Args, error) {
 	err := maintainArgs.MarkSetFlags(c)
 	if err != nil {
@@ -87,46 +147,6 @@ func validateMaintainArgs(c *cli.Context, maintainArgs maintain.Args) (maintain.
 	return maintainArgs, nil
 }
 
-func buildMaintainClient(name, version string, maintainArgs maintain.Args, provider iaas.Provider) (*concourse.Client, error) {
-	versionFile, _ := provider.Choose(iaas.Choice{
-		AWS: resource.AWSVersionFile,
-		GCP: resource.GCPVersionFile,
-	}).([]byte)
-
-	terraformClient, err := terraform.New(provider.IAAS(), terraform.DownloadTerraform(versionFile))
-	if err != nil {
-		return nil, err
-	}
-
-	tfInputVarsFactory, err := concourse.NewTFInputVarsFactory(provider)
-	if err != nil {
-		return nil, fmt.Errorf("Error creating TFInputVarsFactory [%v]", err)
-	}
-
-	client := concourse.NewClient(
-		provider,
-		terraformClient,
-		tfInputVarsFactory,
-		bosh.New,
-		fly.New,
-		certs.Generate,
-		config.New(provider, name, maintainArgs.Namespace),
-		nil,
-		os.Stdout,
-		os.Stderr,
-		util.FindUserIP,
-		certs.NewAcmeClient,
-		util.GeneratePasswordWithLength,
-		util.EightRandomLetters,
-		util.GenerateSSHKeyPair,
-		version,
-		versionFile,
-		credhub.NewClient,
-	)
-
-	return client, nil
-}
-
 var maintainCmd = cli.Command{
 	Name:      "maintain",
 	Aliases:   []string{"m"},
@@ -138,6 +158,9 @@ var maintainCmd = cli.Command{
 		if err != nil {
 			return fmt.Errorf("Error validating args on maintain: [%v]", err)
 		}
+		if maintainArgs.ListIsSet {
+			return listMaintenanceProcedures(os.Stdout)
+		}
 		iaasName, err := iaas.Validate(maintainArgs.IAAS)
 		if err != nil {
 			return fmt.Errorf("Error mapping to supported IAASes on maintain: [%v]", err)
@@ -2,24 +2,44 @@ package maintain
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	cli "gopkg.in/urfave/cli.v1"
 )
 
 // Args are arguments passed to the info command
 type Args struct {
-	Region             string
-	RegionIsSet        bool
-	RenewNatsCert      bool
-	RenewNatsCertIsSet bool
-	Namespace          string
-	NamespaceIsSet     bool
-	IAAS               string
-	IAASIsSet          bool
-	Stage              int
-	StageIsSet         bool
+	Region               string
+	RegionIsSet          bool
+	Namespace            string
+	NamespaceIsSet       bool
+	IAAS                 string
+	IAASIsSet            bool
+	Stage                int
+	StageIsSet           bool
+	Output               string
+	Credentials          string
+	CredentialsIsSet     bool
+	DrainTimeout         time.Duration
+	DrainTimeoutIsSet    bool
+	List                 bool
+	ListIsSet            bool
+	Status               bool
+	StatusIsSet          bool
+	All                  bool
+	AllIsSet             bool
+	OrphanedDiskAge      time.Duration
+	OrphanedDiskAgeIsSet bool
+	// Procedures are the names of the registered procedures whose flags were given
+	Procedures []string
+	// ProcedureArgument is the value given to the flag of a procedure which takes one
+	ProcedureArgument string
 }
 
+// RotatableCredentials lists the credentials --rotate-credentials can rotate, in the order they are rotated
+var RotatableCredentials = []string{"director", "rds", "concourse", "grafana", "credhub"}
+
 //MarkSetFlags is marking which info Args have been set
 func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 	for _, f := range c.FlagNames() {
@@ -29,14 +49,29 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 				a.RegionIsSet = true
 			case "namespace":
 				a.NamespaceIsSet = true
-			case "renew-nats-cert":
-				a.RenewNatsCertIsSet = true
-			case "stage":
+			case OptionStage:
 				a.StageIsSet = true
 			case "iaas":
 				a.IAASIsSet = true
+			case OptionCredentials:
+				a.CredentialsIsSet = true
+			case OptionDrainTimeout:
+				a.DrainTimeoutIsSet = true
+			case "list":
+				a.ListIsSet = true
+			case "status":
+				a.StatusIsSet = true
+			case OptionAll:
+				a.AllIsSet = true
+			case OptionOrphanedDiskAge:
+				a.OrphanedDiskAgeIsSet = true
+			case "output":
+				//do nothing
 			default:
-				return fmt.Errorf("flag %q is not supported by maintain flags", f)
+				if _, ok := procedureNamed(f); !ok {
+					return fmt.Errorf("flag %q is not supported by maintain flags", f)
+				}
+				a.Procedures = append(a.Procedures, f)
 			}
 		}
 	}
@@ -44,12 +79,109 @@ func (a *Args) MarkSetFlags(c FlagSetChecker) error {
 }
 
 func (a *Args) Validate() error {
-	if !a.IAASIsSet {
+	// Listing the procedures doesn't involve a deployment
+	if !a.IAASIsSet && !a.ListIsSet {
 		return fmt.Errorf("--iaas flag not set")
 	}
+	if a.Output != "text" && a.Output != "json" {
+		return fmt.Errorf("--output must be text or json, not [%s]", a.Output)
+	}
+	operations := len(a.Procedures)
+	for _, isSet := range []bool{a.ListIsSet, a.StatusIsSet} {
+		if isSet {
+			operations++
+		}
+	}
+	if operations > 1 {
+		var names []string
+		for _, p := range procedures {
+			names = append(names, p.Name)
+		}
+		return fmt.Errorf("only one of %s can be used at a time", flagList(append(names, "list", "status"), "and"))
+	}
+
+	procedure, _ := procedureNamed(a.Procedure())
+	options := []struct {
+		name  string
+		isSet bool
+	}{
+		{OptionStage, a.StageIsSet},
+		{OptionCredentials, a.CredentialsIsSet},
+		{OptionDrainTimeout, a.DrainTimeoutIsSet},
+		{OptionAll, a.AllIsSet},
+		{OptionOrphanedDiskAge, a.OrphanedDiskAgeIsSet},
+	}
+	for _, option := range options {
+		if option.isSet && !procedure.Offers(option.name) {
+			var offeredBy []string
+			for _, p := range procedures {
+				if p.Offers(option.name) {
+					offeredBy = append(offeredBy, p.Name)
+				}
+			}
+			return fmt.Errorf("--%s can only be used with %s", option.name, flagList(offeredBy, "or"))
+		}
+	}
+	if procedure.Argument != "" && a.ProcedureArgument == "" {
+		return fmt.Errorf("--%s must be given %s", procedure.Name, procedure.Argument)
+	}
+	if a.OrphanedDiskAgeIsSet && a.OrphanedDiskAge <= 0 {
+		return fmt.Errorf("--orphaned-disk-age must be greater than zero, not [%s]", a.OrphanedDiskAge)
+	}
+	if procedure.Offers(OptionDrainTimeout) && a.DrainTimeout <= 0 {
+		return fmt.Errorf("--drain-timeout must be greater than zero, not [%s]", a.DrainTimeout)
+	}
+	for _, credential := range a.requestedCredentials() {
+		if !contains(RotatableCredentials, credential) {
+			return fmt.Errorf("unknown credential [%s], must be one of %s", credential, strings.Join(RotatableCredentials, ", "))
+		}
+	}
 	return nil
 }
 
+// Procedure returns the name of the maintenance procedure to run, which is the name of the flag that chose it
+func (a *Args) Procedure() string {
+	if len(a.Procedures) == 0 {
+		return ""
+	}
+	return a.Procedures[0]
+}
+
+// SelectedCredentials returns the credentials to rotate in the order they are rotated
+func (a *Args) SelectedCredentials() []string {
+	requested := a.requestedCredentials()
+	if len(requested) == 0 {
+		return RotatableCredentials
+	}
+
+	var selected []string
+	for _, credential := range RotatableCredentials {
+		if contains(requested, credential) {
+			selected = append(selected, credential)
+		}
+	}
+	return selected
+}
+
+func (a *Args) requestedCredentials() []string {
+	var requested []string
+	for _, credential := range strings.Split(a.Credentials, ",") {
+		if credential = strings.TrimSpace(credential); credential != "" {
+			requested = append(requested, credential)
+		}
+	}
+	return requested
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 // FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
 type FlagSetChecker interface {
 	IsSet(name string) bool
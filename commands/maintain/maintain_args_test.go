@@ -1,10 +1,14 @@
 package maintain_test
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/EngineerBetter/control-tower/commands/maintain"
+	// registers the maintenance procedures
+	_ "github.com/EngineerBetter/control-tower/concourse"
 )
 
 func TestMaintainArgs_Validate(t *testing.T) {
@@ -12,6 +16,7 @@ func TestMaintainArgs_Validate(t *testing.T) {
 		Region:    "eu-west-1",
 		IAAS:      "AWS",
 		IAASIsSet: true,
+		Output:    "text",
 	}
 	tests := []struct {
 		name         string
@@ -37,6 +42,287 @@ func TestMaintainArgs_Validate(t *testing.T) {
 			wantErr:     true,
 			expectedErr: "--iaas flag not set",
 		},
+		{
+			name: "Output format not supported",
+			modification: func() Args {
+				args := defaultFields
+				args.Output = "xml"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--output must be text or json, not [xml]",
+		},
+		{
+			name: "Rotating credentials and renewing the NATS cert",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "renew-nats-cert")
+				args.Procedures = append(args.Procedures, "rotate-credentials")
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --renew-nats-cert, --renew-director-certs, --rotate-credentials, --recreate-workers, --snapshot-db, --restore-db, --rotate-ssh-key, --rotate-encryption-key, --cleanup, --list and --status can be used at a time",
+		},
+		{
+			name: "Renewing the director certs and the NATS cert",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "renew-nats-cert")
+				args.Procedures = append(args.Procedures, "renew-director-certs")
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --renew-nats-cert, --renew-director-certs, --rotate-credentials, --recreate-workers, --snapshot-db, --restore-db, --rotate-ssh-key, --rotate-encryption-key, --cleanup, --list and --status can be used at a time",
+		},
+		{
+			name: "Stage when renewing the director certs",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "renew-director-certs")
+				args.Stage = 2
+				args.StageIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Stage when rotating credentials",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "rotate-credentials")
+				args.StageIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--stage can only be used with --renew-nats-cert, --renew-director-certs or --rotate-encryption-key",
+		},
+		{
+			name: "Credentials without rotating credentials",
+			modification: func() Args {
+				args := defaultFields
+				args.Credentials = "rds"
+				args.CredentialsIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--credentials can only be used with --rotate-credentials",
+		},
+		{
+			name: "Unknown credential",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "rotate-credentials")
+				args.Credentials = "rds,vault"
+				args.CredentialsIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "unknown credential [vault], must be one of director, rds, concourse, grafana, credhub",
+		},
+		{
+			name: "Selected credentials",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "rotate-credentials")
+				args.Credentials = "credhub, director"
+				args.CredentialsIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Recreating workers",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "recreate-workers")
+				args.DrainTimeout = time.Hour
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Recreating workers and rotating credentials",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "recreate-workers")
+				args.DrainTimeout = time.Hour
+				args.Procedures = append(args.Procedures, "rotate-credentials")
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --renew-nats-cert, --renew-director-certs, --rotate-credentials, --recreate-workers, --snapshot-db, --restore-db, --rotate-ssh-key, --rotate-encryption-key, --cleanup, --list and --status can be used at a time",
+		},
+		{
+			name: "Drain timeout without recreating workers",
+			modification: func() Args {
+				args := defaultFields
+				args.DrainTimeout = time.Minute
+				args.DrainTimeoutIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--drain-timeout can only be used with --recreate-workers",
+		},
+		{
+			name: "Drain timeout not positive",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "recreate-workers")
+				args.DrainTimeout = 0
+				args.DrainTimeoutIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--drain-timeout must be greater than zero, not [0s]",
+		},
+		{
+			name: "Snapshotting the database",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "snapshot-db")
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Snapshotting and restoring the database",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "snapshot-db")
+				args.ProcedureArgument = "snapshot-1"
+				args.Procedures = append(args.Procedures, "restore-db")
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --renew-nats-cert, --renew-director-certs, --rotate-credentials, --recreate-workers, --snapshot-db, --restore-db, --rotate-ssh-key, --rotate-encryption-key, --cleanup, --list and --status can be used at a time",
+		},
+		{
+			name: "Restoring the database without a snapshot",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "restore-db")
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--restore-db must be given the ID of a snapshot",
+		},
+		{
+			name: "Stage when snapshotting the database",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "snapshot-db")
+				args.StageIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--stage can only be used with --renew-nats-cert, --renew-director-certs or --rotate-encryption-key",
+		},
+		{
+			name: "Rotating the SSH key",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "rotate-ssh-key")
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Rotating the SSH key and credentials",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "rotate-ssh-key")
+				args.Procedures = append(args.Procedures, "rotate-credentials")
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --renew-nats-cert, --renew-director-certs, --rotate-credentials, --recreate-workers, --snapshot-db, --restore-db, --rotate-ssh-key, --rotate-encryption-key, --cleanup, --list and --status can be used at a time",
+		},
+		{
+			name: "Stage when rotating the encryption key",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "rotate-encryption-key")
+				args.Stage = 3
+				args.StageIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Cleaning up with an orphaned disk age",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "cleanup")
+				args.AllIsSet = true
+				args.OrphanedDiskAge = 7 * 24 * time.Hour
+				args.OrphanedDiskAgeIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "All without cleaning up",
+			modification: func() Args {
+				args := defaultFields
+				args.AllIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--all can only be used with --cleanup",
+		},
+		{
+			name: "Orphaned disk age without cleaning up",
+			modification: func() Args {
+				args := defaultFields
+				args.OrphanedDiskAge = time.Hour
+				args.OrphanedDiskAgeIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--orphaned-disk-age can only be used with --cleanup",
+		},
+		{
+			name: "Orphaned disk age not positive",
+			modification: func() Args {
+				args := defaultFields
+				args.Procedures = append(args.Procedures, "cleanup")
+				args.OrphanedDiskAge = -time.Hour
+				args.OrphanedDiskAgeIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--orphaned-disk-age must be greater than zero, not [-1h0m0s]",
+		},
+		{
+			name: "Listing procedures without an IAAS",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				args.ListIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Status and renewing the NATS cert",
+			modification: func() Args {
+				args := defaultFields
+				args.StatusIsSet = true
+				args.Procedures = append(args.Procedures, "renew-nats-cert")
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "only one of --renew-nats-cert, --renew-director-certs, --rotate-credentials, --recreate-workers, --snapshot-db, --restore-db, --rotate-ssh-key, --rotate-encryption-key, --cleanup, --list and --status can be used at a time",
+		},
+		{
+			name: "JSON output",
+			modification: func() Args {
+				args := defaultFields
+				args.Output = "json"
+				return args
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -80,5 +366,113 @@ func (f *FakeFlagSetChecker) IsSet(desired string) bool {
 }
 
 func (f *FakeFlagSetChecker) FlagNames() (names []string) {
-	return names
+	return f.names
+}
+
+func TestMaintainArgs_MarkSetFlags(t *testing.T) {
+	tests := []struct {
+		name           string
+		specifiedFlags []string
+		want           Args
+		wantErr        string
+	}{
+		{
+			name:           "options",
+			specifiedFlags: []string{"iaas", "stage"},
+			want:           Args{IAASIsSet: true, StageIsSet: true},
+		},
+		{
+			name:           "a registered procedure",
+			specifiedFlags: []string{"iaas", "restore-db"},
+			want:           Args{IAASIsSet: true, Procedures: []string{"restore-db"}},
+		},
+		{
+			name:           "several registered procedures",
+			specifiedFlags: []string{"rotate-ssh-key", "cleanup"},
+			want:           Args{Procedures: []string{"rotate-ssh-key", "cleanup"}},
+		},
+		{
+			name:           "an unknown flag",
+			specifiedFlags: []string{"renew-everything"},
+			wantErr:        `flag "renew-everything" is not supported by maintain flags`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewFakeFlagSetChecker(tt.specifiedFlags, tt.specifiedFlags)
+			var args Args
+			err := args.MarkSetFlags(&checker)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Args.MarkSetFlags() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Args.MarkSetFlags() error = %v", err)
+			}
+			if !reflect.DeepEqual(args, tt.want) {
+				t.Errorf("Args.MarkSetFlags() = %#v, want %#v", args, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaintainArgs_SelectedCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials string
+		want        []string
+	}{
+		{
+			name:        "defaults to every credential",
+			credentials: "",
+			want:        RotatableCredentials,
+		},
+		{
+			name:        "returns the selection in rotation order",
+			credentials: "credhub,grafana, director",
+			want:        []string{"director", "grafana", "credhub"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := Args{Credentials: tt.credentials}
+			got := args.SelectedCredentials()
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Args.SelectedCredentials() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaintainArgs_Procedure(t *testing.T) {
+	tests := []struct {
+		name string
+		args Args
+		want string
+	}{
+		{
+			name: "no procedure",
+			args: Args{StatusIsSet: true},
+			want: "",
+		},
+		{
+			name: "renewing the NATS cert",
+			args: Args{Procedures: []string{"renew-nats-cert"}},
+			want: "renew-nats-cert",
+		},
+		{
+			name: "restoring the database",
+			args: Args{Procedures: []string{"restore-db"}, ProcedureArgument: "snapshot-1"},
+			want: "restore-db",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.args.Procedure(); got != tt.want {
+				t.Errorf("Args.Procedure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
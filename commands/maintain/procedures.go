@@ -0,0 +1,67 @@
+package maintain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Options of maintain which only apply to some procedures
+const (
+	OptionStage           = "stage"
+	OptionCredentials     = "credentials"
+	OptionDrainTimeout    = "drain-timeout"
+	OptionAll             = "all"
+	OptionOrphanedDiskAge = "orphaned-disk-age"
+)
+
+// Procedure describes a maintenance procedure, which is run by maintain --<Name>
+type Procedure struct {
+	Name        string
+	Description string
+	// Argument describes the value --<Name> must be given, e.g. the ID of a snapshot.
+	// Procedures without one are run by a boolean flag.
+	Argument string
+	// Options are the flags which can only be used with this procedure and others which list them
+	Options []string
+}
+
+// Offers returns true if the option can be used with the procedure
+func (p Procedure) Offers(option string) bool {
+	return contains(p.Options, option)
+}
+
+var procedures []Procedure
+
+// Register adds a procedure to those that maintain can run, which each get a flag of their name
+func Register(p Procedure) {
+	if _, ok := procedureNamed(p.Name); ok {
+		panic(fmt.Sprintf("maintenance procedure %s is already registered", p.Name))
+	}
+	procedures = append(procedures, p)
+}
+
+// Procedures lists the procedures that maintain can run, in the order they were registered
+func Procedures() []Procedure {
+	return append([]Procedure(nil), procedures...)
+}
+
+func procedureNamed(name string) (Procedure, bool) {
+	for _, p := range procedures {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Procedure{}, false
+}
+
+// flagList joins flag names as "--a, --b <conjunction> --c"
+func flagList(names []string, conjunction string) string {
+	flags := make([]string, len(names))
+	for i, name := range names {
+		flags[i] = "--" + name
+	}
+	if len(flags) < 2 {
+		return strings.Join(flags, "")
+	}
+	return strings.Join(flags[:len(flags)-1], ", ") + " " + conjunction + " " + flags[len(flags)-1]
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/restore"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+var initialRestoreArgs restore.Args
+
+var restoreFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialRestoreArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialRestoreArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialRestoreArgs.Namespace,
+	},
+	cli.StringFlag{
+		Name:        "from",
+		Usage:       "(required) Path of the backup file to restore",
+		EnvVar:      "BACKUP_FILE",
+		Destination: &initialRestoreArgs.From,
+	},
+	cli.StringFlag{
+		Name:        "passphrase",
+		Usage:       "(required) Passphrase the backup was encrypted with",
+		EnvVar:      "BACKUP_PASSPHRASE",
+		Destination: &initialRestoreArgs.Passphrase,
+	},
+	cli.BoolFlag{
+		Name:        "force",
+		Usage:       "(optional) Overwrite the state of a deployment which already exists",
+		EnvVar:      "FORCE",
+		Destination: &initialRestoreArgs.Force,
+	},
+}
+
+func restoreAction(c *cli.Context, restoreArgs restore.Args, provider iaas.Provider) error {
+	name := c.Args().Get(0)
+	if name == "" {
+		return errors.New("Usage is `control-tower restore <name> --from <file>`")
+	}
+
+	data, err := ioutil.ReadFile(restoreArgs.From)
+	if err != nil {
+		return fmt.Errorf("Error reading backup from %s: [%v]", restoreArgs.From, err)
+	}
+
+	stateStore, err := buildStateStore(provider)
+	if err != nil {
+		return err
+	}
+	configClient := config.NewWithStateStore(provider, stateStore, name, restoreArgs.Namespace)
+	if configClient.BucketExists && !restoreArgs.Force {
+		exists, err := configClient.ConfigExists()
+		if err != nil {
+			return fmt.Errorf("Error determining if deployment %s exists: [%v]", name, err)
+		}
+		if exists {
+			return fmt.Errorf("deployment %s already exists, use --force to overwrite its state", name)
+		}
+	}
+
+	manifest, err := config.Restore(configClient, data, name, restoreArgs.Passphrase)
+	if err != nil {
+		return fmt.Errorf("Error restoring deployment: [%v]", err)
+	}
+
+	_, err = fmt.Fprintf(os.Stdout, "Restored %d files to deployment %s from backup taken at %s\n", len(manifest.Checksums), name, manifest.CreatedAt)
+	return err
+}
+
+func validateRestoreArgs(c *cli.Context, restoreArgs restore.Args) (restore.Args, error) {
+	err := restoreArgs.MarkSetFlags(c)
+	if err != nil {
+		return restoreArgs, fmt.Errorf("failed to mark set Restore flags: [%v]", err)
+	}
+
+	if err = restoreArgs.Validate(); err != nil {
+		return restoreArgs, fmt.Errorf("failed to validate Restore flags: [%v]", err)
+	}
+
+	return restoreArgs, nil
+}
+
+var restoreCmd = cli.Command{
+	Name:      "restore",
+	Usage:     "Restores all state of a deployment from an encrypted backup file",
+	ArgsUsage: "<name>",
+	Flags:     restoreFlags,
+	Action: func(c *cli.Context) error {
+		restoreArgs, err := validateRestoreArgs(c, initialRestoreArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on restore: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(restoreArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on restore: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, restoreArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on restore: [%v]", err)
+		}
+		return restoreAction(c, restoreArgs, provider)
+	},
+}
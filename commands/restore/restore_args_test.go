@@ -0,0 +1,81 @@
+package restore_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/commands/restore"
+)
+
+func TestRestoreArgs_Validate(t *testing.T) {
+	defaultFields := Args{
+		Region:     "eu-west-1",
+		IAAS:       "AWS",
+		IAASIsSet:  true,
+		From:       "backup.ctb",
+		Passphrase: "hunter2",
+	}
+	tests := []struct {
+		name         string
+		modification func() Args
+		outcomeCheck func(Args) bool
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "Default args",
+			modification: func() Args {
+				return defaultFields
+			},
+			wantErr: false,
+		},
+		{
+			name: "IAAS not set",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--iaas flag not set",
+		},
+		{
+			name: "From not set",
+			modification: func() Args {
+				args := defaultFields
+				args.From = ""
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--from flag not set",
+		},
+		{
+			name: "Passphrase not set",
+			modification: func() Args {
+				args := defaultFields
+				args.Passphrase = ""
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--passphrase flag not set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.modification()
+			err := args.Validate()
+			if (err != nil) != tt.wantErr || (err != nil && tt.wantErr && !strings.Contains(err.Error(), tt.expectedErr)) {
+				if err != nil {
+					t.Errorf("RestoreArgs.Validate() %v test failed.\nFailed with error = %v,\nExpected error = %v,\nShould fail %v\nWith args: %#v", tt.name, err.Error(), tt.expectedErr, tt.wantErr, args)
+				} else {
+					t.Errorf("RestoreArgs.Validate() %v test failed.\nShould fail %v\nWith args: %#v", tt.name, tt.wantErr, args)
+				}
+			}
+			if tt.outcomeCheck != nil {
+				if tt.outcomeCheck(args) {
+					t.Errorf("RestoreArgs.Validate() %v test failed.\nShould fail %v\nWith args: %#v", tt.name, tt.wantErr, args)
+				}
+			}
+		})
+	}
+}
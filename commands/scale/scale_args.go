@@ -0,0 +1,95 @@
+package scale
+
+import (
+	"errors"
+	"fmt"
+
+	cli "gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/deploy"
+)
+
+// Args are arguments passed to the scale command
+type Args struct {
+	Region           string
+	RegionIsSet      bool
+	Namespace        string
+	NamespaceIsSet   bool
+	IAAS             string
+	IAASIsSet        bool
+	WorkerCount      int
+	WorkerCountIsSet bool
+	WorkerSize       string
+	WorkerSizeIsSet  bool
+	Output           string
+}
+
+//MarkSetFlags is marking which scale Args have been set
+func (a *Args) MarkSetFlags(c FlagSetChecker) error {
+	for _, f := range c.FlagNames() {
+		if c.IsSet(f) {
+			switch f {
+			case "region":
+				a.RegionIsSet = true
+			case "namespace":
+				a.NamespaceIsSet = true
+			case "iaas":
+				a.IAASIsSet = true
+			case "workers":
+				a.WorkerCountIsSet = true
+			case "worker-size":
+				a.WorkerSizeIsSet = true
+			case "output":
+				//do nothing
+			default:
+				return fmt.Errorf("flag %q is not supported by scale flags", f)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Args) Validate() error {
+	if !a.IAASIsSet {
+		return fmt.Errorf("--iaas flag not set")
+	}
+	if a.Output != "text" && a.Output != "json" {
+		return fmt.Errorf("--output must be text or json, not [%s]", a.Output)
+	}
+	if !a.WorkerCountIsSet && !a.WorkerSizeIsSet {
+		return errors.New("at least one of --workers and --worker-size must be set")
+	}
+	if a.WorkerCountIsSet && a.WorkerCount < 1 {
+		return errors.New("minimum number of workers is 1")
+	}
+	if a.WorkerSizeIsSet {
+		for _, size := range deploy.WorkerSizes {
+			if size == a.WorkerSize {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown worker size: `%s`. Valid sizes are: %v", a.WorkerSize, deploy.WorkerSizes)
+	}
+	return nil
+}
+
+// FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
+type FlagSetChecker interface {
+	IsSet(name string) bool
+	FlagNames() (names []string)
+}
+
+// ContextWrapper wraps a CLI context for testing
+type ContextWrapper struct {
+	c *cli.Context
+}
+
+// IsSet tells you if a user provided a flag
+func (t *ContextWrapper) IsSet(name string) bool {
+	return t.c.IsSet(name)
+}
+
+// FlagNames lists all flags it's possible for a user to provide
+func (t *ContextWrapper) FlagNames() (names []string) {
+	return t.c.FlagNames()
+}
@@ -0,0 +1,108 @@
+package scale_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/commands/scale"
+)
+
+func TestScaleArgs_Validate(t *testing.T) {
+	defaultFields := Args{
+		Region:           "eu-west-1",
+		IAAS:             "AWS",
+		IAASIsSet:        true,
+		WorkerCount:      3,
+		WorkerCountIsSet: true,
+		Output:           "text",
+	}
+	tests := []struct {
+		name         string
+		modification func() Args
+		wantErr      bool
+		expectedErr  string
+	}{
+		{
+			name: "Default args",
+			modification: func() Args {
+				return defaultFields
+			},
+			wantErr: false,
+		},
+		{
+			name: "IAAS not set",
+			modification: func() Args {
+				args := defaultFields
+				args.IAASIsSet = false
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--iaas flag not set",
+		},
+		{
+			name: "Output format not supported",
+			modification: func() Args {
+				args := defaultFields
+				args.Output = "xml"
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "--output must be text or json, not [xml]",
+		},
+		{
+			name: "Neither workers nor worker size set",
+			modification: func() Args {
+				args := defaultFields
+				args.WorkerCountIsSet = false
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "at least one of --workers and --worker-size must be set",
+		},
+		{
+			name: "Zero workers",
+			modification: func() Args {
+				args := defaultFields
+				args.WorkerCount = 0
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "minimum number of workers is 1",
+		},
+		{
+			name: "Only worker size set",
+			modification: func() Args {
+				args := defaultFields
+				args.WorkerCountIsSet = false
+				args.WorkerSize = "xlarge"
+				args.WorkerSizeIsSet = true
+				return args
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unknown worker size",
+			modification: func() Args {
+				args := defaultFields
+				args.WorkerSize = "small"
+				args.WorkerSizeIsSet = true
+				return args
+			},
+			wantErr:     true,
+			expectedErr: "unknown worker size: `small`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.modification()
+			err := args.Validate()
+			if (err != nil) != tt.wantErr || (err != nil && tt.wantErr && !strings.Contains(err.Error(), tt.expectedErr)) {
+				if err != nil {
+					t.Errorf("ScaleArgs.Validate() %v test failed.\nFailed with error = %v,\nExpected error = %v,\nShould fail %v\nWith args: %#v", tt.name, err.Error(), tt.expectedErr, tt.wantErr, args)
+				} else {
+					t.Errorf("ScaleArgs.Validate() %v test failed.\nShould fail %v\nWith args: %#v", tt.name, tt.wantErr, args)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/ssh"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+var initialSSHArgs ssh.Args
+
+var sshFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialSSHArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialSSHArgs.IAAS,
+	},
+	cli.StringFlag{
+		Name:        "namespace",
+		Usage:       "(optional) Specify a namespace for deployments in order to group them in a meaningful way",
+		EnvVar:      "NAMESPACE",
+		Destination: &initialSSHArgs.Namespace,
+	},
+}
+
+func sshAction(c *cli.Context, sshArgs ssh.Args, provider iaas.Provider) error {
+	name := c.Args().Get(0)
+	if name == "" {
+		return errors.New("Usage is `control-tower ssh <name> [instance]`")
+	}
+
+	client, err := buildConcourseClient(name, sshArgs.Namespace, c.App.Version, provider, nil, os.Stdout, os.Stderr, nil)
+	if err != nil {
+		return err
+	}
+
+	var stdin io.Reader = os.Stdin
+	if NonInteractiveModeEnabled() {
+		stdin = nil
+	}
+	return client.SSH(c.Args().Get(1), stdin)
+}
+
+func validateSSHArgs(c *cli.Context, sshArgs ssh.Args) (ssh.Args, error) {
+	err := sshArgs.MarkSetFlags(c)
+	if err != nil {
+		return sshArgs, fmt.Errorf("failed to mark set SSH flags: [%v]", err)
+	}
+
+	if err = sshArgs.Validate(); err != nil {
+		return sshArgs, fmt.Errorf("failed to validate SSH flags: [%v]", err)
+	}
+
+	return sshArgs, nil
+}
+
+var sshCmd = cli.Command{
+	Name:      "ssh",
+	Usage:     "Opens a shell on a VM of a deployment, tunnelling through the director",
+	ArgsUsage: "<name> [instance]",
+	Flags:     sshFlags,
+	Action: func(c *cli.Context) error {
+		sshArgs, err := validateSSHArgs(c, initialSSHArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on ssh: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(sshArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on ssh: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, sshArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on ssh: [%v]", err)
+		}
+		return sshAction(c, sshArgs, provider)
+	},
+}
@@ -0,0 +1,295 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"text/tabwriter"
+
+	goversion "github.com/hashicorp/go-version"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/EngineerBetter/control-tower/commands/deploy"
+	"github.com/EngineerBetter/control-tower/commands/upgradeall"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+var initialUpgradeAllArgs upgradeall.Args
+
+var upgradeAllFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:        "region",
+		Usage:       "(optional) AWS region",
+		EnvVar:      "AWS_REGION",
+		Destination: &initialUpgradeAllArgs.Region,
+	},
+	cli.StringFlag{
+		Name:        "iaas",
+		Usage:       "(required) IAAS, can be AWS or GCP",
+		EnvVar:      "IAAS",
+		Destination: &initialUpgradeAllArgs.IAAS,
+	},
+	cli.IntFlag{
+		Name:        "concurrency",
+		Usage:       "(optional) Number of deployments to upgrade at the same time",
+		EnvVar:      "CONCURRENCY",
+		Value:       1,
+		Destination: &initialUpgradeAllArgs.Concurrency,
+	},
+	cli.BoolFlag{
+		Name:        "stop-on-failure",
+		Usage:       "(optional) Don't start any more upgrades once one has failed",
+		EnvVar:      "STOP_ON_FAILURE",
+		Destination: &initialUpgradeAllArgs.StopOnFailure,
+	},
+}
+
+const (
+	upgradeResultUpgraded = "upgraded"
+	upgradeResultSkipped  = "skipped"
+	upgradeResultFailed   = "failed"
+)
+
+type upgradeResult struct {
+	Name      string
+	Namespace string
+	Region    string
+	From      string
+	To        string
+	Result    string
+	Reason    string
+}
+
+// upgradeDeployments calls upgrade on every deployment older than version, running at most
+// concurrency upgrades at once. Results are returned in the same order as configs.
+func upgradeDeployments(configs []config.Config, version string, concurrency int, stopOnFailure bool, upgrade func(config.Config) error) []upgradeResult {
+	results := make([]upgradeResult, len(configs))
+	for i, conf := range configs {
+		results[i] = upgradeResult{
+			Name:      conf.Project,
+			Namespace: conf.Namespace,
+			Region:    conf.Region,
+			From:      conf.Version,
+			To:        version,
+		}
+	}
+
+	var mu sync.Mutex
+	var failed bool
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for i, conf := range configs {
+		if reason := upgradeSkipReason(conf.Version, version); reason != "" {
+			results[i].Result = upgradeResultSkipped
+			results[i].Reason = reason
+			continue
+		}
+
+		sem <- struct{}{}
+
+		mu.Lock()
+		stop := stopOnFailure && failed
+		mu.Unlock()
+		if stop {
+			<-sem
+			results[i].Result = upgradeResultSkipped
+			results[i].Reason = "not started as an earlier upgrade failed"
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, conf config.Config) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := upgrade(conf); err != nil {
+				mu.Lock()
+				failed = true
+				mu.Unlock()
+				results[i].Result = upgradeResultFailed
+				results[i].Reason = err.Error()
+				return
+			}
+			results[i].Result = upgradeResultUpgraded
+		}(i, conf)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// upgradeSkipReason returns why a deployment at deployed should not be upgraded to version, or
+// an empty string if it should be. Versions that are not semver are upgraded unless they match.
+func upgradeSkipReason(deployed, version string) string {
+	if deployed == version {
+		return "already at this version"
+	}
+	deployedVersion, err := goversion.NewVersion(deployed)
+	if err != nil {
+		return ""
+	}
+	binaryVersion, err := goversion.NewVersion(version)
+	if err != nil {
+		return ""
+	}
+	switch deployedVersion.Compare(binaryVersion) {
+	case 0:
+		return "already at this version"
+	case 1:
+		return "newer than this binary"
+	}
+	return ""
+}
+
+func upgradeAllAction(upgradeAllArgs upgradeall.Args, version string, provider iaas.Provider) error {
+	store, err := buildStateStore(provider)
+	if err != nil {
+		return err
+	}
+
+	configs, err := config.List(store)
+	if err != nil {
+		return fmt.Errorf("Error listing deployments: [%v]", err)
+	}
+
+	// Terraform and the BOSH CLI write straight to stdout, so the output of concurrent upgrades interleaves
+	results := upgradeDeployments(configs, version, upgradeAllArgs.Concurrency, upgradeAllArgs.StopOnFailure, func(conf config.Config) error {
+		fmt.Printf("=== %s ===\n", deploymentLabel(conf))
+		return upgradeDeployment(conf, version, provider, os.Stdout, os.Stderr)
+	})
+
+	if err = writeUpgradeTable(os.Stdout, results); err != nil {
+		return err
+	}
+
+	var failures int
+	for _, r := range results {
+		if r.Result == upgradeResultFailed {
+			failures++
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d deployments failed to upgrade", failures, len(results))
+	}
+	return nil
+}
+
+// upgradeDeployment runs the same deploy as the self-update pipeline of the deployment
+func upgradeDeployment(conf config.Config, version string, provider iaas.Provider, stdout, stderr io.Writer) error {
+	deploymentProvider := provider
+	if conf.Region != "" && conf.Region != provider.Region() {
+		var err error
+		deploymentProvider, err = iaas.New(provider.IAAS(), conf.Region)
+		if err != nil {
+			return fmt.Errorf("error creating IAAS provider for region [%v]: [%v]", conf.Region, err)
+		}
+	}
+
+	deployArgs, err := selfUpdateDeployArgs(conf, deploymentProvider)
+	if err != nil {
+		return err
+	}
+
+	client, err := buildClient(conf.Project, version, deployArgs, deploymentProvider, stdout, stderr, nil)
+	if err != nil {
+		return err
+	}
+	return client.Deploy()
+}
+
+// selfUpdateDeployArgs returns the deploy arguments the self-update pipeline would use for conf.
+// Sizes are taken from conf so that the upgrade doesn't resize anything.
+func selfUpdateDeployArgs(conf config.Config, provider iaas.Provider) (deploy.Args, error) {
+	if conf.AllowIPsUnformatted == "" {
+		return deploy.Args{}, errors.New("config does not record the IPs allowed to access the deployment, upgrade it with `deploy --allow-ips` instead")
+	}
+	influxDbRetention := conf.InfluxDbRetention
+	if influxDbRetention == "" {
+		influxDbRetention = "28d"
+	}
+
+	return deploy.Args{
+		IAAS:                provider.IAAS().String(),
+		IAASIsSet:           true,
+		Region:              provider.Region(),
+		RegionIsSet:         true,
+		Namespace:           conf.Namespace,
+		NamespaceIsSet:      conf.Namespace != "",
+		AllowIPs:            conf.AllowIPsUnformatted,
+		AllowIPsIsSet:       true,
+		SelfUpdate:          true,
+		SelfUpdateIsSet:     true,
+		InfluxDbRetention:   influxDbRetention,
+		PrometheusRetention: conf.PrometheusRetention,
+		WorkerCount:         conf.ConcourseWorkerCount,
+		WorkerSize:          conf.ConcourseWorkerSize,
+		WorkerType:          conf.WorkerType,
+		WebSize:             conf.ConcourseWebSize,
+		PersistentDiskSize:  conf.PersistentDisk,
+		DBSize:              dbSize(conf.RDSInstanceClass, provider),
+	}, nil
+}
+
+// dbSize returns the --db-size that maps to instanceClass on provider
+func dbSize(instanceClass string, provider iaas.Provider) string {
+	for _, size := range deploy.AllowedDBSizes {
+		if provider.DBType(size) == instanceClass {
+			return size
+		}
+	}
+	return ""
+}
+
+func deploymentLabel(conf config.Config) string {
+	if conf.Namespace == "" {
+		return conf.Project
+	}
+	return fmt.Sprintf("%s (%s)", conf.Project, conf.Namespace)
+}
+
+func writeUpgradeTable(w io.Writer, results []upgradeResult) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tNAMESPACE\tREGION\tFROM\tTO\tRESULT\tREASON")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Name, r.Namespace, r.Region, r.From, r.To, r.Result, r.Reason)
+	}
+	return tw.Flush()
+}
+
+func validateUpgradeAllArgs(c *cli.Context, upgradeAllArgs upgradeall.Args) (upgradeall.Args, error) {
+	err := upgradeAllArgs.MarkSetFlags(c)
+	if err != nil {
+		return upgradeAllArgs, fmt.Errorf("failed to mark set UpgradeAll flags: [%v]", err)
+	}
+
+	if err = upgradeAllArgs.Validate(); err != nil {
+		return upgradeAllArgs, fmt.Errorf("failed to validate UpgradeAll flags: [%v]", err)
+	}
+
+	return upgradeAllArgs, nil
+}
+
+var upgradeAllCmd = cli.Command{
+	Name:  "upgrade-all",
+	Usage: "Upgrades every deployment in the account to this version of control-tower",
+	Flags: upgradeAllFlags,
+	Action: func(c *cli.Context) error {
+		upgradeAllArgs, err := validateUpgradeAllArgs(c, initialUpgradeAllArgs)
+		if err != nil {
+			return fmt.Errorf("Error validating args on upgrade-all: [%v]", err)
+		}
+		iaasName, err := iaas.Validate(upgradeAllArgs.IAAS)
+		if err != nil {
+			return fmt.Errorf("Error mapping to supported IAASes on upgrade-all: [%v]", err)
+		}
+		provider, err := iaas.New(iaasName, upgradeAllArgs.Region)
+		if err != nil {
+			return fmt.Errorf("Error creating IAAS provider on upgrade-all: [%v]", err)
+		}
+		return upgradeAllAction(upgradeAllArgs, c.App.Version, provider)
+	},
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/iaas/iaasfakes"
+)
+
+func Test_upgradeDeployments(t *testing.T) {
+	configs := []config.Config{
+		{Project: "current", Version: "1.0.0"},
+		{Project: "old", Version: "0.9.0"},
+		{Project: "broken", Version: "0.9.0"},
+		{Project: "older", Version: "0.8.0"},
+		{Project: "newer", Version: "1.10.0"},
+		{Project: "prefixed", Version: "v1.0.0"},
+	}
+	upgrade := func(conf config.Config) error {
+		if conf.Project == "broken" {
+			return errors.New("bosh exploded")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name          string
+		stopOnFailure bool
+		want          []string
+		wantReasons   []string
+	}{
+		{
+			name:          "upgrades every outdated deployment and reports failures",
+			stopOnFailure: false,
+			want:          []string{upgradeResultSkipped, upgradeResultUpgraded, upgradeResultFailed, upgradeResultUpgraded, upgradeResultSkipped, upgradeResultSkipped},
+			wantReasons:   []string{"already at this version", "", "bosh exploded", "", "newer than this binary", "already at this version"},
+		},
+		{
+			name:          "doesn't start any more upgrades after a failure",
+			stopOnFailure: true,
+			want:          []string{upgradeResultSkipped, upgradeResultUpgraded, upgradeResultFailed, upgradeResultSkipped, upgradeResultSkipped, upgradeResultSkipped},
+			wantReasons:   []string{"already at this version", "", "bosh exploded", "not started as an earlier upgrade failed", "newer than this binary", "already at this version"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := upgradeDeployments(configs, "1.0.0", 1, tt.stopOnFailure, upgrade)
+			if len(results) != len(configs) {
+				t.Fatalf("upgradeDeployments() returned %d results, want %d", len(results), len(configs))
+			}
+			for i, r := range results {
+				if r.Name != configs[i].Project || r.From != configs[i].Version || r.To != "1.0.0" {
+					t.Errorf("upgradeDeployments() result %d = %+v, does not match config %+v", i, r, configs[i])
+				}
+				if r.Result != tt.want[i] || r.Reason != tt.wantReasons[i] {
+					t.Errorf("upgradeDeployments() result for %s = %s (%s), want %s (%s)", r.Name, r.Result, r.Reason, tt.want[i], tt.wantReasons[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_upgradeDeployments_concurrency(t *testing.T) {
+	var configs []config.Config
+	for i := 0; i < 6; i++ {
+		configs = append(configs, config.Config{Project: "deployment", Version: "0.9.0"})
+	}
+
+	var mu sync.Mutex
+	var running, maxRunning int
+	release := make(chan struct{})
+	started := make(chan struct{}, len(configs))
+
+	go func() {
+		for range configs {
+			<-started
+			release <- struct{}{}
+		}
+	}()
+
+	upgradeDeployments(configs, "1.0.0", 2, false, func(config.Config) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+
+	if maxRunning > 2 {
+		t.Errorf("upgradeDeployments() ran %d upgrades at once, want at most 2", maxRunning)
+	}
+}
+
+func Test_selfUpdateDeployArgs(t *testing.T) {
+	provider := &iaasfakes.FakeProvider{}
+	provider.IAASReturns(iaas.AWS)
+	provider.RegionReturns("eu-west-1")
+	provider.DBTypeStub = func(size string) string {
+		return iaas.AWSDBSizes[size]
+	}
+
+	conf := config.Config{
+		AllowIPsUnformatted:  "10.0.0.0/8",
+		ConcourseWorkerCount: 3,
+		ConcourseWorkerSize:  "2xlarge",
+		ConcourseWebSize:     "large",
+		WorkerType:           "m5",
+		PersistentDisk:       "100",
+		RDSInstanceClass:     iaas.AWSDBSizes["medium"],
+	}
+
+	args, err := selfUpdateDeployArgs(conf, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args.AllowIPs != "10.0.0.0/8" || args.WorkerCount != 3 || args.WorkerSize != "2xlarge" || args.WebSize != "large" ||
+		args.WorkerType != "m5" || args.PersistentDiskSize != "100" || args.DBSize != "medium" {
+		t.Errorf("selfUpdateDeployArgs() = %+v, does not keep the settings of %+v", args, conf)
+	}
+
+	conf.AllowIPsUnformatted = ""
+	if _, err = selfUpdateDeployArgs(conf, provider); err == nil {
+		t.Error("selfUpdateDeployArgs() succeeded without recorded allowed IPs, want an error")
+	}
+}
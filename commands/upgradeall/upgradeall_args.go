@@ -0,0 +1,71 @@
+package upgradeall
+
+import (
+	"errors"
+	"fmt"
+
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+// Args are arguments passed to the upgrade-all command
+type Args struct {
+	Region           string
+	RegionIsSet      bool
+	IAAS             string
+	IAASIsSet        bool
+	Concurrency      int
+	ConcurrencyIsSet bool
+	StopOnFailure    bool
+}
+
+//MarkSetFlags is marking which upgrade-all Args have been set
+func (a *Args) MarkSetFlags(c FlagSetChecker) error {
+	for _, f := range c.FlagNames() {
+		if c.IsSet(f) {
+			switch f {
+			case "region":
+				a.RegionIsSet = true
+			case "iaas":
+				a.IAASIsSet = true
+			case "concurrency":
+				a.ConcurrencyIsSet = true
+			case "stop-on-failure":
+				//do nothing
+			default:
+				return fmt.Errorf("flag %q is not supported by upgrade-all flags", f)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *Args) Validate() error {
+	if !a.IAASIsSet {
+		return fmt.Errorf("--iaas flag not set")
+	}
+	if a.Concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	return nil
+}
+
+// FlagSetChecker allows us to find out if flags were set, adn what the names of all flags are
+type FlagSetChecker interface {
+	IsSet(name string) bool
+	FlagNames() (names []string)
+}
+
+// ContextWrapper wraps a CLI context for testing
+type ContextWrapper struct {
+	c *cli.Context
+}
+
+// IsSet tells you if a user provided a flag
+func (t *ContextWrapper) IsSet(name string) bool {
+	return t.c.IsSet(name)
+}
+
+// FlagNames lists all flags it's possible for a user to provide
+func (t *ContextWrapper) FlagNames() (names []string) {
+	return t.c.FlagNames()
+}
@@ -0,0 +1,117 @@
+package concourse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/certs"
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+// Expiry statuses of a certificate, from least to most severe
+const (
+	CertificateOK       = "ok"
+	CertificateWarning  = "warning"
+	CertificateCritical = "critical"
+)
+
+// natsCAName is the certificate whose expiry is reported as the info's CertExpiry
+const natsCAName = "nats_server_tls/ca"
+
+// inspectCertificates parses the Concourse, director and CredHub certificates from the config,
+// followed by each certificate and CA in director-creds.yml in the order they appear there
+func inspectCertificates(conf config.Config, directorCreds []byte) ([]certs.Certificate, error) {
+	configCerts := []struct {
+		name string
+		pem  string
+	}{
+		{"concourse_cert", conf.ConcourseCert},
+		{"director_ca_cert", conf.DirectorCACert},
+		{"director_cert", conf.DirectorCert},
+		{"credhub_ca_cert", conf.CredhubCACert},
+	}
+
+	certificates := []certs.Certificate{}
+	for _, c := range configCerts {
+		inspected, err := certs.Inspect(c.name, "config.json", c.pem)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, inspected...)
+	}
+
+	if len(directorCreds) == 0 {
+		return certificates, nil
+	}
+
+	var vars yaml.MapSlice
+	if err := yaml.Unmarshal(directorCreds, &vars); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: [%v]", bosh.CredsFilename, err)
+	}
+	for _, item := range vars {
+		value, ok := item.Value.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		for _, field := range value {
+			key := fmt.Sprint(field.Key)
+			if key != "certificate" && key != "ca" {
+				continue
+			}
+			pemData, _ := field.Value.(string)
+			inspected, err := certs.Inspect(fmt.Sprintf("%s/%s", item.Key, key), bosh.CredsFilename, pemData)
+			if err != nil {
+				return nil, err
+			}
+			certificates = append(certificates, inspected...)
+		}
+	}
+	return certificates, nil
+}
+
+// certificateStatus compares the days left before a certificate expires with the thresholds.
+// A negative threshold is not checked.
+func certificateStatus(cert certs.Certificate, now time.Time, warningDays, criticalDays int) string {
+	daysLeft := cert.DaysLeft(now)
+	switch {
+	case criticalDays >= 0 && daysLeft < criticalDays:
+		return CertificateCritical
+	case warningDays >= 0 && daysLeft < warningDays:
+		return CertificateWarning
+	default:
+		return CertificateOK
+	}
+}
+
+// WriteCertificates writes a table of the certificates and their expiry, returning the most severe
+// status of any of them. A negative threshold is not checked.
+func (info *Info) WriteCertificates(w io.Writer, now time.Time, warningDays, criticalDays int) (string, error) {
+	worst := CertificateOK
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Certificate\tSource\tSubject\tIssuer\tSANs\tExpires\tDays left\tStatus")
+	for _, cert := range info.Certificates {
+		status := certificateStatus(cert, now, warningDays, criticalDays)
+		if status == CertificateCritical || (status == CertificateWarning && worst == CertificateOK) {
+			worst = status
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", cert.Name, cert.Source, cert.Subject, cert.Issuer,
+			strings.Join(cert.SANs, ","), formatTime(&cert.NotAfter), cert.DaysLeft(now), status)
+	}
+	return worst, tw.Flush()
+}
+
+// formatCertExpiry formats an expiry in the same way as `openssl x509 -dates`
+func formatCertExpiry(certificates []certs.Certificate, name string) string {
+	for _, cert := range certificates {
+		if cert.Name == name {
+			return cert.NotAfter.UTC().Format("Jan _2 15:04:05 2006") + " GMT"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package concourse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/resource"
+	"github.com/EngineerBetter/control-tower/terraform"
+)
+
+const checkpointFilename = "deploy-checkpoint.json"
+
+// Checkpoint records the phases of a deploy which have completed, so that a
+// failed deploy can be resumed without repeating them
+type Checkpoint struct {
+	Phases map[string]PhaseCheckpoint `json:"phases"`
+}
+
+// PhaseCheckpoint records a completed phase along with a digest of the inputs it used.
+// Only the digest is kept as the inputs include secrets.
+type PhaseCheckpoint struct {
+	InputsDigest string    `json:"inputs_digest"`
+	CompletedAt  time.Time `json:"completed_at"`
+}
+
+// loadCheckpoint retrieves the checkpoint of the last deploy when resuming. Otherwise
+// an empty checkpoint is returned, which replaces the stored one as phases complete.
+func (client *Client) loadCheckpoint() (*Checkpoint, error) {
+	checkpoint := Checkpoint{Phases: map[string]PhaseCheckpoint{}}
+	if !client.deployArgs.Resume {
+		return &checkpoint, nil
+	}
+
+	fileExists, err := client.configClient.HasAsset(checkpointFilename)
+	if err != nil {
+		return nil, err
+	}
+	if !fileExists {
+		return &checkpoint, nil
+	}
+
+	fileContents, err := client.configClient.LoadAsset(checkpointFilename)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(fileContents, &checkpoint); err != nil {
+		return nil, fmt.Errorf("error parsing %s: [%v]", checkpointFilename, err)
+	}
+	if checkpoint.Phases == nil {
+		checkpoint.Phases = map[string]PhaseCheckpoint{}
+	}
+	return &checkpoint, nil
+}
+
+// phaseUnchanged returns true when resuming and the phase last completed with inputs matching digest
+func (client *Client) phaseUnchanged(checkpoint *Checkpoint, phase, digest string) bool {
+	if !client.deployArgs.Resume {
+		return false
+	}
+	completed, ok := checkpoint.Phases[phase]
+	return ok && completed.InputsDigest == digest
+}
+
+// skipPhase tells the user that a phase is not being run
+func (client *Client) skipPhase(phase string) error {
+	client.recorder.Skip(phase)
+	_, err := fmt.Fprintf(client.stdout, "\nSKIPPING %s AS ITS INPUTS ARE UNCHANGED SINCE IT LAST COMPLETED\n", phase)
+	return err
+}
+
+// completePhase records that a phase completed with inputs matching digest
+func (client *Client) completePhase(checkpoint *Checkpoint, phase, digest string) error {
+	checkpoint.Phases[phase] = PhaseCheckpoint{
+		InputsDigest: digest,
+		CompletedAt:  time.Now().UTC(),
+	}
+	checkpointBytes, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return client.configClient.StoreAsset(checkpointFilename, checkpointBytes)
+}
+
+// clearCheckpoint removes the checkpoint once a deploy has completed, so that a later
+// --resume doesn't skip phases based on it
+func (client *Client) clearCheckpoint() error {
+	return client.configClient.DeleteAsset(checkpointFilename)
+}
+
+// terraformApplyDigest covers everything terraform apply depends on: the input vars, the template
+// they are rendered into and the version of control-tower which embeds that template
+func (client *Client) terraformApplyDigest(tfInputVars terraform.InputVars) (string, error) {
+	template, _ := client.provider.Choose(iaas.Choice{
+		AWS: resource.AWSTerraformConfig,
+		GCP: resource.GCPTerraformConfig,
+	}).(string)
+	rendered, err := tfInputVars.ConfigureTerraform(template)
+	if err != nil {
+		return "", fmt.Errorf("error rendering terraform template: [%v]", err)
+	}
+	return inputsDigest(struct {
+		Version   string
+		InputVars terraform.InputVars
+		Template  string
+	}{
+		Version:   client.version,
+		InputVars: tfInputVars,
+		Template:  rendered,
+	})
+}
+
+// certsDueForRenewal returns true if any certificate in the config expires within the renewal window
+func certsDueForRenewal(cfg config.ConfigView) bool {
+	for _, cert := range []string{cfg.GetConcourseCert(), cfg.GetDirectorCert()} {
+		if cert != "" && timeTillExpiry(cert) <= certRenewalWindow {
+			return true
+		}
+	}
+	return false
+}
+
+func inputsDigest(inputs interface{}) (string, error) {
+	inputsBytes, err := json.Marshal(inputs)
+	if err != nil {
+		return "", fmt.Errorf("error determining inputs of deploy phase: [%v]", err)
+	}
+	sum := sha256.Sum256(inputsBytes)
+	return hex.EncodeToString(sum[:]), nil
+}
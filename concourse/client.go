@@ -4,12 +4,14 @@ import (
 	"io"
 
 	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/commands/scale"
 	"github.com/EngineerBetter/control-tower/credhub"
 
 	"github.com/EngineerBetter/control-tower/bosh"
 	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/commands/deploy"
 	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/iaas"
 	"github.com/EngineerBetter/control-tower/terraform"
@@ -37,14 +39,17 @@ type Client struct {
 	version               string
 	versionFile           []byte
 	credhubClientFactory  func(server, id, secret, cert string) (credhub.IClient, error)
+	recorder              events.Recorder
 }
 
 // IClient represents a control-tower client
 type IClient interface {
 	Deploy() error
 	Destroy() error
+	Doctor() error
 	FetchInfo() (*Info, error)
 	Maintain(maintain.Args) error
+	Scale(scale.Args) error
 }
 
 // New returns a new client
@@ -65,7 +70,11 @@ func NewClient(
 	sshGenerator func() ([]byte, []byte, string, error),
 	version string,
 	versionFile []byte,
-	credhubClientFactory func(server, id, secret, cert string) (credhub.IClient, error)) *Client {
+	credhubClientFactory func(server, id, secret, cert string) (credhub.IClient, error),
+	recorder events.Recorder) *Client {
+	if recorder == nil {
+		recorder = events.NopRecorder{}
+	}
 	return &Client{
 		acmeClientConstructor: acmeClientConstructor,
 		boshClientFactory:     boshClientFactory,
@@ -85,6 +94,7 @@ func NewClient(
 		version:               version,
 		versionFile:           versionFile,
 		credhubClientFactory:  credhubClientFactory,
+		recorder:              recorder,
 	}
 }
 
@@ -97,5 +107,6 @@ func (client *Client) buildBoshClient(config config.ConfigView, tfOutputs terraf
 		client.stderr,
 		client.provider,
 		client.versionFile,
+		client.recorder,
 	)
 }
@@ -2,9 +2,12 @@ package concourse_test
 
 import (
 	_ "embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/go-acme/lego/v4/lego"
 	. "github.com/onsi/ginkgo/v2"
@@ -16,18 +19,22 @@ import (
 	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/certs/certsfakes"
 	"github.com/EngineerBetter/control-tower/commands/deploy"
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/commands/scale"
 	"github.com/EngineerBetter/control-tower/concourse"
 	"github.com/EngineerBetter/control-tower/concourse/concoursefakes"
 	"github.com/EngineerBetter/control-tower/config"
 	"github.com/EngineerBetter/control-tower/config/configfakes"
 	"github.com/EngineerBetter/control-tower/credhub"
 	"github.com/EngineerBetter/control-tower/credhub/credhubfakes"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/fly/flyfakes"
 	"github.com/EngineerBetter/control-tower/iaas"
 	"github.com/EngineerBetter/control-tower/iaas/iaasfakes"
 	"github.com/EngineerBetter/control-tower/terraform"
 	"github.com/EngineerBetter/control-tower/terraform/terraformfakes"
+	"github.com/EngineerBetter/control-tower/util/yaml"
 )
 
 //go:embed fixtures/director-state.json
@@ -52,6 +59,9 @@ var _ = Describe("client", func() {
 	var terraformCLI *terraformfakes.FakeCLIInterface
 	var configClient *configfakes.FakeIClient
 	var boshClient *boshfakes.FakeIClient
+	var boshInstances []bosh.Instance
+	var configureBoshClient func(*boshfakes.FakeIClient)
+	var awsClient *iaasfakes.FakeProvider
 	var credhubClient *credhubfakes.FakeIClient
 
 	var setupFakeAwsProvider = func() *iaasfakes.FakeProvider {
@@ -85,7 +95,7 @@ var _ = Describe("client", func() {
 
 		provider, err := iaas.New(iaas.AWS, "eu-west-1")
 		Expect(err).ToNot(HaveOccurred())
-		awsInputVarsFactory, err := concourse.NewTFInputVarsFactory(provider)
+		awsInputVarsFactory, err := concourse.NewTFInputVarsFactory(provider, config.NewBucketStateStore(provider))
 		Expect(err).ToNot(HaveOccurred())
 		tfInputVarsFactory.NewInputVarsStub = func(i config.ConfigView) terraform.InputVars {
 			actions = append(actions, "converting config.Config to TFInputVars")
@@ -144,7 +154,8 @@ var _ = Describe("client", func() {
 			}, nil
 		}
 
-		awsClient := setupFakeAwsProvider()
+		awsClient = setupFakeAwsProvider()
+		configureBoshClient = nil
 		tfInputVarsFactory = setupFakeTfInputVarsFactory()
 		configClient = setupFakeConfigClient()
 
@@ -198,6 +209,7 @@ var _ = Describe("client", func() {
 			DirectorUsername:         "admin",
 			EncryptionKey:            "123456789a123456789b123456789c",
 			IAAS:                     "AWS",
+			MetricsBackend:           config.MetricsBackendInfluxDB,
 			PersistentDisk:           "default",
 			PrivateKey:               privateKeyFixture,
 			Project:                  "happymeal",
@@ -240,7 +252,7 @@ var _ = Describe("client", func() {
 
 		terraformCLI = setupFakeTerraformCLI(terraformOutputs)
 
-		boshClientFactory := func(config config.ConfigView, outputs terraform.Outputs, stdout, stderr io.Writer, provider iaas.Provider, versionFile []byte) (bosh.IClient, error) {
+		boshClientFactory := func(config config.ConfigView, outputs terraform.Outputs, stdout, stderr io.Writer, provider iaas.Provider, versionFile []byte, recorder events.Recorder) (bosh.IClient, error) {
 			boshClient = &boshfakes.FakeIClient{}
 			boshClient.DeployStub = func(stateFileBytes, credsFileBytes []byte, detach bool) ([]byte, []byte, error) {
 				if detach {
@@ -248,15 +260,35 @@ var _ = Describe("client", func() {
 				} else {
 					actions = append(actions, "deploying director")
 				}
+				// Like a vars store, the creds given are returned so that changes to them are kept
+				if len(credsFileBytes) > 0 {
+					return directorStateFixture, credsFileBytes, nil
+				}
 				return directorStateFixture, directorCredsFixture, nil
 			}
+			boshClient.CreateEnvStub = func(stateFileBytes, credsFileBytes []byte, customOps string) ([]byte, []byte, error) {
+				actions = append(actions, "running create-env")
+				return stateFileBytes, credsFileBytes, nil
+			}
 			boshClient.CleanupStub = func() error {
 				actions = append(actions, "cleaning up bosh init")
 				return nil
 			}
 			boshClient.InstancesStub = func() ([]bosh.Instance, error) {
 				actions = append(actions, "listing bosh instances")
-				return nil, nil
+				return boshInstances, nil
+			}
+			boshClient.DeployConcourseStub = func(credsFileBytes []byte) ([]byte, error) {
+				actions = append(actions, "deploying concourse")
+				return credsFileBytes, nil
+			}
+			boshClient.RecreateInstanceStub = func(instance string) error {
+				actions = append(actions, fmt.Sprintf("recreating %s", instance))
+				return nil
+			}
+			boshClient.LocksReturns([]byte(`{"Tables":[{"Content":"locks","Rows":[]}]}`), nil)
+			if configureBoshClient != nil {
+				configureBoshClient(boshClient)
 			}
 
 			return boshClient, nil
@@ -295,6 +327,7 @@ var _ = Describe("client", func() {
 				func(server, id, secret, cert string) (credhub.IClient, error) {
 					return credhubClient, nil
 				},
+				nil,
 			)
 		}
 	})
@@ -386,4 +419,703 @@ var _ = Describe("client", func() {
 			})
 		})
 	})
+
+	Describe("Scale", func() {
+		var updatedConfig config.Config
+
+		BeforeEach(func() {
+			configClient.UpdateStub = func(conf config.Config) error {
+				actions = append(actions, "updating config file")
+				updatedConfig = conf
+				return nil
+			}
+			configClient.HasAssetReturns(true, nil)
+			configClient.LoadAssetReturns(directorCredsFixture, nil)
+		})
+
+		It("deploys only concourse and saves the new worker count and size", func() {
+			args := scale.Args{WorkerCount: 4, WorkerCountIsSet: true, WorkerSize: "2xlarge", WorkerSizeIsSet: true}
+			Expect(buildClient().Scale(args)).To(Succeed())
+
+			Expect(actions).To(ContainElement("deploying concourse"))
+			Expect(actions).ToNot(ContainElement("applying terraform"))
+			Expect(actions).ToNot(ContainElement("deploying director"))
+			Expect(actions).ToNot(ContainElement("running create-env"))
+			Expect(actions).ToNot(ContainElement(ContainSubstring("generating cert")))
+			Expect(updatedConfig.ConcourseWorkerCount).To(Equal(4))
+			Expect(updatedConfig.ConcourseWorkerSize).To(Equal("2xlarge"))
+			Eventually(stdout).Should(gbytes.Say("Scaled control-tower-happymeal to 4 2xlarge workers"))
+		})
+
+		It("keeps the worker size when only the count is changed", func() {
+			Expect(buildClient().Scale(scale.Args{WorkerCount: 2, WorkerCountIsSet: true})).To(Succeed())
+
+			Expect(updatedConfig.ConcourseWorkerCount).To(Equal(2))
+			Expect(updatedConfig.ConcourseWorkerSize).To(Equal(configInBucket.ConcourseWorkerSize))
+		})
+	})
+
+	Describe("Maintain", func() {
+		var storedAssets map[string][]byte
+		var storedConfig config.Config
+
+		BeforeEach(func() {
+			storedAssets = map[string][]byte{
+				bosh.StateFilename: directorStateFixture,
+				bosh.CredsFilename: directorCredsFixture,
+			}
+			storedConfig = configInBucket
+
+			configClient.LoadStub = func() (config.Config, error) {
+				return storedConfig, nil
+			}
+			configClient.UpdateStub = func(conf config.Config) error {
+				actions = append(actions, "updating config file")
+				storedConfig = conf
+				return nil
+			}
+			configClient.HasAssetStub = func(filename string) (bool, error) {
+				_, ok := storedAssets[filename]
+				return ok, nil
+			}
+			configClient.LoadAssetStub = func(filename string) ([]byte, error) {
+				return storedAssets[filename], nil
+			}
+			configClient.StoreAssetStub = func(filename string, contents []byte) error {
+				storedAssets[filename] = contents
+				return nil
+			}
+		})
+
+		storedMaintenance := func() concourse.Maintenance {
+			var maintenance concourse.Maintenance
+			Expect(json.Unmarshal(storedAssets["maintenance.json"], &maintenance)).To(Succeed())
+			return maintenance
+		}
+
+		Context("when renewing the director certs", func() {
+			BeforeEach(func() {
+				storedConfig.PublicCIDR = "10.0.0.0/24"
+				// The vars create-env would generate from the add-new-director-ca ops file
+				storedAssets[bosh.CredsFilename] = append(directorCredsFixture, []byte(`mbus_bootstrap_ssl:
+  ca: old-ca
+  certificate: old-mbus-cert
+  private_key: old-mbus-key
+default_ca_2:
+  ca: new-ca
+  certificate: new-ca
+  private_key: new-ca-key
+mbus_bootstrap_ssl_2:
+  ca: new-ca
+  certificate: new-mbus-cert
+  private_key: new-mbus-key
+`)...)
+			})
+
+			It("runs create-env with a new default CA and renewed director certificate", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"renew-director-certs"}})).To(Succeed())
+
+				Expect(actions).To(ContainElement("generating cert ca: control-tower-happymeal, cn: [99.99.99.99 10.0.0.6]"))
+				Expect(boshClient.CreateEnvCallCount()).To(Equal(1))
+				_, _, customOps := boshClient.CreateEnvArgsForCall(0)
+				Expect(customOps).To(ContainSubstring("default_ca_2"))
+			})
+
+			It("saves the renewed director certificate to the config", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"renew-director-certs"}})).To(Succeed())
+
+				Expect(storedConfig.DirectorCACert).To(Equal("----EXAMPLE CERT----"))
+			})
+
+			It("replaces the default CA and mbus certificate with the renewed ones", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"renew-director-certs"}})).To(Succeed())
+
+				creds := storedAssets[bosh.CredsFilename]
+				mbusCert, err := yaml.Path(creds, "mbus_bootstrap_ssl/certificate")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.TrimSpace(mbusCert)).To(Equal("new-mbus-cert"))
+				defaultCAKey, err := yaml.Path(creds, "default_ca/private_key")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.TrimSpace(defaultCAKey)).To(Equal("new-ca-key"))
+				Expect(string(creds)).ToNot(ContainSubstring("_2:"))
+				Expect(storedAssets["director-creds-backup.yml"]).To(ContainSubstring("old-mbus-cert"))
+			})
+
+			It("records each step of the renewal, without the renewed certificates", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"renew-director-certs"}})).To(Succeed())
+
+				run := storedMaintenance().Procedures["renew-director-certs"]
+				Expect(run.Status).To(Equal(concourse.ProcedureSucceeded))
+				Expect(run.FinishedAt).ToNot(BeNil())
+				Expect(run.Values).To(BeEmpty())
+				var steps []string
+				for _, step := range run.Steps {
+					Expect(step.Status).To(Equal(concourse.StepSucceeded))
+					Expect(step.StartedAt).ToNot(BeNil())
+					Expect(step.Log).ToNot(BeEmpty())
+					steps = append(steps, step.ID)
+				}
+				Expect(steps).To(Equal([]string{"director-cert-generation", "add-new-director-ca", "director-creds-cleanup"}))
+			})
+
+			Context("and an earlier renewal was interrupted after generating the certificates", func() {
+				BeforeEach(func() {
+					storedAssets["maintenance.json"] = []byte(`{
+						"procedures": {
+							"renew-director-certs": {
+								"status": "failed",
+								"steps": [
+									{"id": "director-cert-generation", "status": "succeeded"},
+									{"id": "add-new-director-ca", "status": "failed", "error": "timed out"},
+									{"id": "director-creds-cleanup", "status": "pending"}
+								],
+								"values": {
+									"director_ca_cert": "renewed-ca",
+									"director_cert": "renewed-cert",
+									"director_key": "renewed-key"
+								}
+							}
+						}
+					}`)
+				})
+
+				It("continues from the step that failed with the certificates generated last time", func() {
+					Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"renew-director-certs"}})).To(Succeed())
+
+					Expect(actions).ToNot(ContainElement(ContainSubstring("generating cert")))
+					Expect(storedConfig.DirectorCACert).To(Equal("renewed-ca"))
+					Expect(storedMaintenance().Procedures["renew-director-certs"].Status).To(Equal(concourse.ProcedureSucceeded))
+				})
+			})
+		})
+
+		Context("when rotating credentials", func() {
+			It("rotates every credential and saves them", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-credentials"}})).To(Succeed())
+
+				Expect(storedConfig.DirectorPassword).To(Equal("generatedPassword20"))
+				Expect(storedConfig.RDSPassword).To(Equal("generatedPassword20"))
+				Expect(storedConfig.ConcoursePassword).To(Equal("generatedPassword20"))
+				Expect(storedConfig.GrafanaPassword).To(Equal("generatedPassword20"))
+				Expect(storedConfig.CredhubAdminClientSecret).To(Equal("generatedPassword20"))
+
+				atcPassword, err := yaml.Path(storedAssets[bosh.CredsFilename], "atc_password")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.TrimSpace(atcPassword)).To(Equal("generatedPassword20"))
+				credhubSecret, err := yaml.Path(storedAssets[bosh.CredsFilename], "credhub_admin_client_secret")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.TrimSpace(credhubSecret)).To(Equal("generatedPassword20"))
+			})
+
+			It("rotates the credentials in stages", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-credentials"}})).To(Succeed())
+
+				Expect(actions).To(ContainElements(
+					"running create-env",
+					"applying terraform",
+					"deploying director",
+					"deploying director",
+					"deploying director",
+				))
+				Eventually(stdout).Should(gbytes.Say("current action: Rotating director admin password"))
+				Eventually(stdout).Should(gbytes.Say("current action: Rotating RDS master password"))
+				Eventually(stdout).Should(gbytes.Say("current action: Rotating Concourse and Grafana admin password"))
+				Eventually(stdout).Should(gbytes.Say("current action: Rotating CredHub admin client secret"))
+			})
+
+			It("records when each credential was rotated and clears the progress", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-credentials"}})).To(Succeed())
+
+				maintenance := storedMaintenance()
+				Expect(maintenance.Procedures["rotate-credentials"].Values).To(BeEmpty())
+				Expect(maintenance.CredentialsRotatedAt).To(HaveLen(5))
+			})
+
+			It("only rotates the selected credentials", func() {
+				args := maintain.Args{Procedures: []string{"rotate-credentials"}, Credentials: "credhub", CredentialsIsSet: true}
+				Expect(buildClient().Maintain(args)).To(Succeed())
+
+				Expect(storedConfig.CredhubAdminClientSecret).To(Equal("generatedPassword20"))
+				Expect(storedConfig.DirectorPassword).To(Equal("secret123"))
+				Expect(storedConfig.RDSPassword).To(Equal("s3cret"))
+				Expect(terraformCLI.ApplyCallCount()).To(BeZero())
+				Expect(storedMaintenance().CredentialsRotatedAt).To(HaveKey("credhub"))
+				Expect(storedMaintenance().CredentialsRotatedAt).To(HaveLen(1))
+			})
+
+			Context("and redeploying with the new Concourse password fails", func() {
+				BeforeEach(func() {
+					configureBoshClient = func(fake *boshfakes.FakeIClient) {
+						fake.DeployStub = func(stateFileBytes, credsFileBytes []byte, detach bool) ([]byte, []byte, error) {
+							return directorStateFixture, credsFileBytes, errors.New("deploy failed")
+						}
+					}
+				})
+
+				It("puts the old password back in director-creds.yml and records the step as compensated", func() {
+					args := maintain.Args{Procedures: []string{"rotate-credentials"}, Credentials: "concourse", CredentialsIsSet: true}
+					Expect(buildClient().Maintain(args)).To(MatchError("deploy failed"))
+
+					atcPassword, err := yaml.Path(storedAssets[bosh.CredsFilename], "atc_password")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(strings.TrimSpace(atcPassword)).To(Equal("s3cret"))
+					Expect(storedConfig.ConcoursePassword).To(Equal("s3cret"))
+					run := storedMaintenance().Procedures["rotate-credentials"]
+					Expect(run.Status).To(Equal(concourse.ProcedureFailed))
+					Expect(run.Steps[0].Status).To(Equal(concourse.StepCompensated))
+				})
+			})
+
+			Context("and an earlier rotation was interrupted", func() {
+				BeforeEach(func() {
+					storedAssets["maintenance.json"] = []byte(`{
+						"procedures": {
+							"rotate-credentials": {
+								"status": "failed",
+								"steps": [
+									{"id": "rotate-director-password", "status": "succeeded"},
+									{"id": "rotate-rds-password", "status": "failed", "error": "timed out"}
+								],
+								"values": {
+									"credentials": "director,rds",
+									"rotate-rds-password": "pending-password"
+								}
+							}
+						}
+					}`)
+				})
+
+				It("continues from the next stage with the value generated last time", func() {
+					Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-credentials"}})).To(Succeed())
+
+					Expect(storedConfig.DirectorPassword).To(Equal("secret123"))
+					Expect(storedConfig.RDSPassword).To(Equal("pending-password"))
+					Expect(storedMaintenance().Procedures["rotate-credentials"].Status).To(Equal(concourse.ProcedureSucceeded))
+				})
+
+				It("refuses to start a rotation of different credentials", func() {
+					args := maintain.Args{Procedures: []string{"rotate-credentials"}, Credentials: "credhub", CredentialsIsSet: true}
+					err := buildClient().Maintain(args)
+					Expect(err).To(MatchError("a rotation of [director, rds] is already in progress, run --rotate-credentials without --credentials to finish it"))
+				})
+			})
+		})
+
+		Context("when recreating workers", func() {
+			var landed map[string]bool
+
+			BeforeEach(func() {
+				landed = map[string]bool{}
+				boshInstances = []bosh.Instance{
+					{Name: "web/web-1", IP: "10.0.0.1", State: "running"},
+					{Name: "worker/abc-123", IP: "10.0.0.2", State: "running"},
+					{Name: "worker/def-456", IP: "10.0.0.3", State: "running"},
+				}
+				// A worker stays landed until its instance is recreated, when it registers itself as running again
+				flyClient.WorkersStub = func() ([]fly.Worker, error) {
+					var workers []fly.Worker
+					for _, name := range []string{"abc-123", "def-456"} {
+						state := "running"
+						if landed[name] && boshClient.RecreateInstanceCallCount() < len(landed) {
+							state = "landed"
+						}
+						workers = append(workers, fly.Worker{Name: name, State: state})
+					}
+					return workers, nil
+				}
+				flyClient.LandWorkerStub = func(name string) error {
+					actions = append(actions, fmt.Sprintf("landing %s", name))
+					landed[name] = true
+					return nil
+				}
+			})
+
+			AfterEach(func() {
+				boshInstances = nil
+			})
+
+			It("lands and recreates each worker in turn", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"recreate-workers"}, DrainTimeout: time.Minute})).To(Succeed())
+
+				var workerActions []string
+				for _, action := range actions {
+					if strings.HasPrefix(action, "landing") || strings.HasPrefix(action, "recreating") {
+						workerActions = append(workerActions, action)
+					}
+				}
+				Expect(workerActions).To(Equal([]string{
+					"landing abc-123",
+					"recreating worker/abc-123",
+					"landing def-456",
+					"recreating worker/def-456",
+				}))
+				Expect(boshClient.RecreateInstanceCallCount()).To(Equal(2))
+				Expect(boshClient.RecreateCallCount()).To(BeZero())
+				Eventually(stdout).Should(gbytes.Say("current action: Recreating worker worker/abc-123 \\(1 of 2\\)"))
+				Eventually(stdout).Should(gbytes.Say("current action: Recreating worker worker/def-456 \\(2 of 2\\)"))
+			})
+
+			Context("and a worker has no matching concourse worker", func() {
+				BeforeEach(func() {
+					boshInstances = []bosh.Instance{
+						{Name: "worker/xyz-789", IP: "10.0.0.4", State: "running"},
+					}
+				})
+
+				It("returns an error without recreating anything", func() {
+					err := buildClient().Maintain(maintain.Args{Procedures: []string{"recreate-workers"}, DrainTimeout: time.Minute})
+					Expect(err).To(MatchError("no concourse worker found for instance worker/xyz-789"))
+					Expect(boshClient.RecreateInstanceCallCount()).To(BeZero())
+				})
+			})
+
+			Context("and landing a worker fails", func() {
+				BeforeEach(func() {
+					flyClient.LandWorkerReturns(errors.New("forbidden"))
+				})
+
+				It("does not recreate it", func() {
+					err := buildClient().Maintain(maintain.Args{Procedures: []string{"recreate-workers"}, DrainTimeout: time.Minute})
+					Expect(err).To(MatchError("forbidden"))
+					Expect(boshClient.RecreateInstanceCallCount()).To(BeZero())
+				})
+
+				It("records the failure of the step", func() {
+					Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"recreate-workers"}, DrainTimeout: time.Minute})).ToNot(Succeed())
+
+					run := storedMaintenance().Procedures["recreate-workers"]
+					Expect(run.Status).To(Equal(concourse.ProcedureFailed))
+					Expect(run.Steps[0].ID).To(Equal("land-worker:worker/abc-123"))
+					Expect(run.Steps[0].Status).To(Equal(concourse.StepFailed))
+					Expect(run.Steps[0].Error).To(Equal("forbidden"))
+					Expect(run.Steps[1].Status).To(Equal(concourse.StepPending))
+				})
+			})
+		})
+
+		Context("when showing the status", func() {
+			It("says when nothing has been run", func() {
+				Expect(buildClient().Maintain(maintain.Args{StatusIsSet: true})).To(Succeed())
+				Expect(stdout).To(gbytes.Say("No maintenance procedures have been run"))
+			})
+
+			It("shows each step of the procedures which have been run", func() {
+				storedAssets["maintenance.json"] = []byte(`{
+					"procedures": {
+						"rotate-credentials": {
+							"status": "failed",
+							"started_at": "2020-01-01T12:00:00Z",
+							"steps": [
+								{"id": "rotate-rds-password", "status": "failed", "error": "quota exceeded"}
+							]
+						}
+					},
+					"credentials_rotated_at": {"director": "2019-12-01T12:00:00Z"}
+				}`)
+
+				Expect(buildClient().Maintain(maintain.Args{StatusIsSet: true})).To(Succeed())
+				Expect(stdout).To(gbytes.Say(`rotate-credentials\s+failed\s+started 2020-01-01T12:00:00Z`))
+				Expect(stdout).To(gbytes.Say(`rotate-rds-password\s+failed`))
+				Expect(stdout).To(gbytes.Say(`error: quota exceeded`))
+				Expect(stdout).To(gbytes.Say(`director\s+2019-12-01T12:00:00Z`))
+			})
+		})
+
+		Context("when snapshotting the database", func() {
+			BeforeEach(func() {
+				terraformCLI.BuildOutputReturns(&terraform.AWSOutputs{
+					BoshDBAddress: terraform.MetadataStringValue{Value: "control-tower-happymeal.abc123.eu-west-1.rds.amazonaws.com"},
+				}, nil)
+				awsClient.SnapshotDBReturns("control-tower-happymeal-20201010-101010", nil)
+			})
+
+			It("snapshots the RDS instance and saves the snapshot ID", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"snapshot-db"}})).To(Succeed())
+
+				Expect(awsClient.SnapshotDBCallCount()).To(Equal(1))
+				instance, name := awsClient.SnapshotDBArgsForCall(0)
+				Expect(instance).To(Equal("control-tower-happymeal"))
+				Expect(name).To(HavePrefix("control-tower-happymeal-"))
+				Expect(storedConfig.DBSnapshotID).To(Equal("control-tower-happymeal-20201010-101010"))
+				Expect(stdout).To(gbytes.Say("Snapshot of the database taken with ID control-tower-happymeal-20201010-101010"))
+			})
+		})
+
+		Context("when restoring the database", func() {
+			BeforeEach(func() {
+				terraformCLI.BuildOutputReturns(&terraform.AWSOutputs{
+					BoshDBAddress: terraform.MetadataStringValue{Value: "control-tower-happymeal.abc123.eu-west-1.rds.amazonaws.com"},
+				}, nil)
+			})
+
+			It("restores the RDS instance from the snapshot, keeping the instance it replaces under a name unique to the restore", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"restore-db"}, ProcedureArgument: "some-snapshot"})).To(Succeed())
+
+				Expect(awsClient.RestoreDBCallCount()).To(Equal(1))
+				instance, snapshot, replaced := awsClient.RestoreDBArgsForCall(0)
+				Expect(instance).To(Equal("control-tower-happymeal"))
+				Expect(snapshot).To(Equal("some-snapshot"))
+				Expect(replaced).To(MatchRegexp(`^control-tower-happymeal-replaced-\d{8}-\d{6}$`))
+				Expect(awsClient.UndoRestoreDBCallCount()).To(Equal(0))
+				Expect(stderr).To(gbytes.Say("WARNING: the RDS instance replaced by the restore has been kept as " + replaced))
+				Expect(stderr).To(gbytes.Say("aws rds delete-db-instance --db-instance-identifier " + replaced))
+			})
+
+			Context("and the restore fails", func() {
+				BeforeEach(func() {
+					awsClient.RestoreDBReturns(errors.New("snapshot not found"))
+				})
+
+				It("puts the replaced instance back and records the step as compensated", func() {
+					err := buildClient().Maintain(maintain.Args{Procedures: []string{"restore-db"}, ProcedureArgument: "some-snapshot"})
+					Expect(err).To(MatchError("snapshot not found"))
+
+					Expect(awsClient.UndoRestoreDBCallCount()).To(Equal(1))
+					_, _, replaced := awsClient.RestoreDBArgsForCall(0)
+					instance, undone := awsClient.UndoRestoreDBArgsForCall(0)
+					Expect(instance).To(Equal("control-tower-happymeal"))
+					Expect(undone).To(Equal(replaced))
+					run := storedMaintenance().Procedures["restore-db"]
+					Expect(run.Status).To(Equal(concourse.ProcedureFailed))
+					Expect(run.Steps[0].Status).To(Equal(concourse.StepCompensated))
+				})
+
+				It("uses the same name for the replaced instance when it is run again", func() {
+					Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"restore-db"}, ProcedureArgument: "some-snapshot"})).ToNot(Succeed())
+					awsClient.RestoreDBReturns(nil)
+					Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"restore-db"}, ProcedureArgument: "some-snapshot"})).To(Succeed())
+
+					Expect(awsClient.RestoreDBCallCount()).To(Equal(2))
+					_, _, first := awsClient.RestoreDBArgsForCall(0)
+					_, _, second := awsClient.RestoreDBArgsForCall(1)
+					Expect(second).To(Equal(first))
+				})
+			})
+		})
+
+		Context("when rotating the SSH key", func() {
+			It("registers the new public key with terraform before recreating the director with it", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-ssh-key"}})).To(Succeed())
+
+				Expect(terraformCLI.ApplyCallCount()).To(Equal(1))
+				inputVars, ok := terraformCLI.ApplyArgsForCall(0).(*terraform.AWSInputVars)
+				Expect(ok).To(BeTrue())
+				Expect(inputVars.PublicKey).To(Equal("public"))
+				Expect(boshClient.CreateEnvCallCount()).To(Equal(1))
+				Expect(actions).To(ContainElements("applying terraform", "updating config file", "running create-env", "updating config file"))
+			})
+
+			It("saves the new key pair to the config", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-ssh-key"}})).To(Succeed())
+
+				Expect(storedConfig.PrivateKey).To(Equal("private"))
+				Expect(storedConfig.PublicKey).To(Equal("public"))
+			})
+
+			It("records each step of the rotation, without the new key pair", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-ssh-key"}})).To(Succeed())
+
+				run := storedMaintenance().Procedures["rotate-ssh-key"]
+				Expect(run.Status).To(Equal(concourse.ProcedureSucceeded))
+				Expect(run.Values).To(BeEmpty())
+				var steps []string
+				for _, step := range run.Steps {
+					steps = append(steps, step.ID)
+				}
+				Expect(steps).To(Equal([]string{"ssh-key-generation", "ssh-key-registration", "bosh-create-env"}))
+			})
+
+			Context("and an earlier rotation failed after registering the new key", func() {
+				BeforeEach(func() {
+					storedAssets["maintenance.json"] = []byte(`{
+						"procedures": {
+							"rotate-ssh-key": {
+								"status": "failed",
+								"steps": [
+									{"id": "ssh-key-generation", "status": "succeeded"},
+									{"id": "ssh-key-registration", "status": "succeeded"},
+									{"id": "bosh-create-env", "status": "failed", "error": "timed out"}
+								],
+								"values": {
+									"private_key": "pending-private",
+									"public_key": "pending-public"
+								}
+							}
+						}
+					}`)
+				})
+
+				It("recreates the director with the key generated last time", func() {
+					Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-ssh-key"}})).To(Succeed())
+
+					Expect(terraformCLI.ApplyCallCount()).To(BeZero())
+					Expect(boshClient.CreateEnvCallCount()).To(Equal(1))
+					Expect(storedConfig.PrivateKey).To(Equal("pending-private"))
+					Expect(storedConfig.PublicKey).To(Equal("pending-public"))
+				})
+			})
+		})
+
+		Context("when rotating the encryption key", func() {
+			var updatedConfigs []config.Config
+
+			BeforeEach(func() {
+				updatedConfigs = nil
+				configClient.UpdateStub = func(conf config.Config) error {
+					actions = append(actions, "updating config file")
+					updatedConfigs = append(updatedConfigs, conf)
+					storedConfig = conf
+					return nil
+				}
+				flyClient.CanConnectReturns(true, nil)
+			})
+
+			It("deploys Concourse with the new and old keys, then without the old key", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-encryption-key"}})).To(Succeed())
+
+				Expect(updatedConfigs[0].EncryptionKey).To(Equal("generatedPassword32"))
+				Expect(updatedConfigs[0].OldEncryptionKey).To(Equal("123456789a123456789b123456789c"))
+				deploys := 0
+				for _, action := range actions {
+					if action == "deploying concourse" {
+						deploys++
+					}
+				}
+				Expect(deploys).To(Equal(2))
+				Expect(actions[len(actions)-1]).To(Equal("updating config file"))
+				Expect(flyClient.CanConnectCallCount()).To(Equal(1))
+			})
+
+			It("saves the new key and removes the old one", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-encryption-key"}})).To(Succeed())
+
+				Expect(storedConfig.EncryptionKey).To(Equal("generatedPassword32"))
+				Expect(storedConfig.OldEncryptionKey).To(BeEmpty())
+				run := storedMaintenance().Procedures["rotate-encryption-key"]
+				Expect(run.Status).To(Equal(concourse.ProcedureSucceeded))
+				var steps []string
+				for _, step := range run.Steps {
+					steps = append(steps, step.ID)
+				}
+				Expect(steps).To(Equal([]string{"encryption-key-generation", "add-new-encryption-key", "wait-for-reencryption", "remove-old-encryption-key"}))
+			})
+
+			Context("and an earlier rotation was interrupted after generating the new key", func() {
+				BeforeEach(func() {
+					storedConfig.EncryptionKey = "pending-key"
+					storedConfig.OldEncryptionKey = "123456789a123456789b123456789c"
+				})
+
+				It("does not replace the key that the data is still encrypted with", func() {
+					Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-encryption-key"}, Stage: 0, StageIsSet: true})).To(Succeed())
+
+					Expect(storedConfig.EncryptionKey).To(Equal("pending-key"))
+					Expect(storedConfig.OldEncryptionKey).To(BeEmpty())
+					Expect(stdout).To(gbytes.Say("Continuing with the encryption key generated by an earlier rotation"))
+				})
+
+				It("only removes the old key when started from the last stage", func() {
+					Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"rotate-encryption-key"}, Stage: 3, StageIsSet: true})).To(Succeed())
+
+					Expect(actions).To(ContainElement("deploying concourse"))
+					Expect(updatedConfigs).To(HaveLen(1))
+					Expect(flyClient.CanConnectCallCount()).To(BeZero())
+					Expect(storedConfig.EncryptionKey).To(Equal("pending-key"))
+					Expect(storedConfig.OldEncryptionKey).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when cleaning up the director", func() {
+			var lockChecks int
+			var lockedFrom int
+
+			BeforeEach(func() {
+				lockChecks = 0
+				lockedFrom = -1
+				configureBoshClient = func(fake *boshfakes.FakeIClient) {
+					fake.LocksStub = func() ([]byte, error) {
+						lockChecks++
+						if lockedFrom >= 0 && lockChecks >= lockedFrom {
+							return []byte(`{"Tables":[{"Content":"locks","Rows":[{"type":"deployment","resource":"concourse"}]}]}`), nil
+						}
+						return []byte(`{"Tables":[{"Content":"locks","Rows":[]}]}`), nil
+					}
+					fake.PlanCleanUpReturns(bosh.CleanUpPlan{
+						Releases:  []string{"concourse/6.7.0", "concourse/6.7.1"},
+						Stemcells: []string{"bosh-aws-xen-hvm-ubuntu-xenial-go_agent/621.90"},
+					}, nil)
+					fake.CleanUpStub = func(all bool) error {
+						actions = append(actions, fmt.Sprintf("cleaning up the director (all: %t)", all))
+						return nil
+					}
+					fake.OrphanedDisksReturns([]bosh.OrphanedDisk{
+						{CID: "vol-old", Instance: "worker/abc-123", SizeMB: 10240, OrphanedAt: time.Now().Add(-30 * 24 * time.Hour)},
+						{CID: "vol-new", Instance: "worker/def-456", SizeMB: 10240, OrphanedAt: time.Now().Add(-time.Hour)},
+					}, nil)
+					fake.DeleteOrphanedDiskStub = func(cid string) error {
+						actions = append(actions, fmt.Sprintf("deleting orphaned disk %s", cid))
+						return nil
+					}
+				}
+			})
+
+			It("runs bosh clean-up and reports what it planned to delete, without deleting orphaned disks", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"cleanup"}})).To(Succeed())
+
+				Expect(actions).To(ContainElement("cleaning up the director (all: false)"))
+				Expect(actions).ToNot(ContainElement(HavePrefix("deleting orphaned disk")))
+				Expect(stdout).To(gbytes.Say("bosh clean-up plans to delete 2 releases and 1 stemcell"))
+				Expect(stdout).To(gbytes.Say("release concourse/6.7.0"))
+				Expect(stdout).To(gbytes.Say("Finished the planned clean-up of 2 releases and 1 stemcell"))
+			})
+
+			It("records the report in the log of the step", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"cleanup"}})).To(Succeed())
+
+				run := storedMaintenance().Procedures["cleanup"]
+				Expect(run.Status).To(Equal(concourse.ProcedureSucceeded))
+				Expect(run.Steps).To(HaveLen(1))
+				Expect(run.Steps[0].Log[len(run.Steps[0].Log)-1].Message).To(HavePrefix("Finished the planned clean-up"))
+			})
+
+			It("only deletes the disks orphaned longer ago than the given age, keeping the rest from clean-up", func() {
+				args := maintain.Args{Procedures: []string{"cleanup"}, OrphanedDiskAge: 7 * 24 * time.Hour, OrphanedDiskAgeIsSet: true}
+				Expect(buildClient().Maintain(args)).To(Succeed())
+
+				Expect(actions).To(ContainElement("cleaning up the director (all: false)"))
+				Expect(actions).To(ContainElement("deleting orphaned disk vol-old"))
+				Expect(actions).ToNot(ContainElement("deleting orphaned disk vol-new"))
+				Expect(stdout).To(gbytes.Say("Deleted 1 orphaned disk, reclaiming 10 GiB of disk space"))
+			})
+
+			It("deletes everything unused with --all, deleting the orphaned disks itself", func() {
+				Expect(buildClient().Maintain(maintain.Args{Procedures: []string{"cleanup"}, AllIsSet: true})).To(Succeed())
+
+				Expect(actions).To(ContainElement("cleaning up the director (all: true)"))
+				Expect(actions).To(ContainElements("deleting orphaned disk vol-old", "deleting orphaned disk vol-new"))
+				Expect(stdout).To(gbytes.Say("Deleted 2 orphaned disks, reclaiming 20 GiB of disk space"))
+			})
+
+			It("keeps the disks orphaned more recently than the given age with --all", func() {
+				args := maintain.Args{Procedures: []string{"cleanup"}, AllIsSet: true, OrphanedDiskAge: 7 * 24 * time.Hour, OrphanedDiskAgeIsSet: true}
+				Expect(buildClient().Maintain(args)).To(Succeed())
+
+				Expect(actions).To(ContainElement("deleting orphaned disk vol-old"))
+				Expect(actions).ToNot(ContainElement("deleting orphaned disk vol-new"))
+			})
+
+			Context("and the director has started a task since the procedure began", func() {
+				BeforeEach(func() {
+					lockedFrom = 2
+				})
+
+				It("refuses to clean up everything unused", func() {
+					err := buildClient().Maintain(maintain.Args{Procedures: []string{"cleanup"}, AllIsSet: true})
+					Expect(err).To(MatchError("the director has tasks in progress, run --cleanup --all again once they have finished"))
+					Expect(actions).ToNot(ContainElement(HavePrefix("cleaning up the director")))
+				})
+			})
+		})
+	})
 })
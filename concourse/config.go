@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 
@@ -46,10 +47,16 @@ func (client *Client) getInitialConfig() (config.Config, bool, error) {
 			return config.Config{}, false, err
 		}
 
+		previousMetricsBackend := conf.MetricsBackend
 		conf, isDomainUpdated, err = applyArgumentsToConfig(conf, client.deployArgs, client.provider)
 		if err != nil {
 			return config.Config{}, false, fmt.Errorf("error merging new options with existing config: [%v]", err)
 		}
+
+		err = writeMetricsBackendChangeWarning(client.stderr, previousMetricsBackend, conf.MetricsBackend)
+		if err != nil {
+			return config.Config{}, false, err
+		}
 	} else {
 		conf, _, err = applyArgumentsToConfig(defaultConf, client.deployArgs, client.provider)
 		if err != nil {
@@ -69,6 +76,21 @@ func (client *Client) getInitialConfig() (config.Config, bool, error) {
 	return conf, isDomainUpdated, nil
 }
 
+// writeMetricsBackendChangeWarning warns that the metrics collected so far are lost when switching backends,
+// as the jobs of the old backend are removed from the web VM on the next deploy
+func writeMetricsBackendChangeWarning(w io.Writer, previous, next string) error {
+	if previous == next || previous == config.MetricsBackendNone {
+		return nil
+	}
+	var err error
+	if next == config.MetricsBackendNone {
+		_, err = fmt.Fprintf(w, "\nWARNING: removing the %s metrics stack, the metrics it has collected will be lost\n\n", previous)
+	} else {
+		_, err = fmt.Fprintf(w, "\nWARNING: switching metrics backend from %s to %s, the metrics collected by %s will not be migrated\n\n", previous, next, previous)
+	}
+	return err
+}
+
 func assertImmutableFieldsNotChanging(deployArgs *deploy.Args, conf config.ConfigView) error {
 	if deployArgs.NetworkCIDRIsSet || deployArgs.PrivateCIDRIsSet || deployArgs.PublicCIDRIsSet {
 		return fmt.Errorf("custom CIDRs cannot be applied after intial deploy")
@@ -109,7 +131,7 @@ func populateConfigWithDefaults(conf config.Config, provider iaas.Provider, pass
 	conf.PersistentDisk = "default"
 	conf.PrivateKey = strings.TrimSpace(string(privateKey))
 	conf.PublicKey = strings.TrimSpace(string(publicKey))
-	conf.NoMetrics = false
+	conf.MetricsBackend = config.MetricsBackendInfluxDB
 	conf.RDSInstanceClass = provider.DBType("small")
 	conf.RDSPassword = passwordGenerator(defaultPasswordLength)
 	conf.RDSUsername = "admin" + passwordGenerator(7)
@@ -148,6 +170,8 @@ func applyArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provide
 
 	conf.AllowIPs = allowedIPs
 	conf.AllowIPsUnformatted = deployArgs.AllowIPs
+	conf.StateStore = deployArgs.StateStore
+	conf.StateStoreLocation = deployArgs.StateStoreLocation
 
 	if deployArgs.ZoneIsSet {
 		conf.AvailabilityZone = deployArgs.Zone
@@ -193,7 +217,14 @@ func applyArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provide
 		conf.MicrosoftTenant = deployArgs.MicrosoftAuthTenant
 	}
 	if deployArgs.NoMetricsIsSet {
-		conf.NoMetrics = deployArgs.NoMetrics
+		if deployArgs.NoMetrics {
+			conf.MetricsBackend = config.MetricsBackendNone
+		} else if conf.MetricsBackend == config.MetricsBackendNone {
+			conf.MetricsBackend = config.MetricsBackendInfluxDB
+		}
+	}
+	if deployArgs.MetricsBackendIsSet {
+		conf.MetricsBackend = deployArgs.MetricsBackend
 	}
 	if deployArgs.TagsIsSet {
 		conf.Tags = deployArgs.Tags
@@ -214,6 +245,10 @@ func applyArgumentsToConfig(conf config.Config, deployArgs *deploy.Args, provide
 
 	// Flag has default value, hence it's always set.
 	conf.InfluxDbRetention = deployArgs.InfluxDbRetention
+	// Keep the stored retention when redeploying without the flag, as switching backends back and forth should not reset it
+	if deployArgs.PrometheusRetentionIsSet || conf.PrometheusRetention == "" {
+		conf.PrometheusRetention = deployArgs.PrometheusRetention
+	}
 
 	var isDomainUpdated bool
 	if deployArgs.DomainIsSet {
@@ -0,0 +1,120 @@
+package concourse
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/commands/deploy"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas/iaasfakes"
+)
+
+func TestApplyArgumentsToConfig_MetricsBackend(t *testing.T) {
+	tests := []struct {
+		name          string
+		stored        config.Config
+		args          deploy.Args
+		wantBackend   string
+		wantRetention string
+	}{
+		{
+			name:          "the stored backend is kept when no metrics flags are given",
+			stored:        config.Config{MetricsBackend: config.MetricsBackendPrometheus, PrometheusRetention: "90d"},
+			args:          deploy.Args{PrometheusRetention: "28d"},
+			wantBackend:   config.MetricsBackendPrometheus,
+			wantRetention: "90d",
+		},
+		{
+			name:          "--metrics-backend switches backend",
+			stored:        config.Config{MetricsBackend: config.MetricsBackendInfluxDB},
+			args:          deploy.Args{MetricsBackend: config.MetricsBackendPrometheus, MetricsBackendIsSet: true, PrometheusRetention: "28d"},
+			wantBackend:   config.MetricsBackendPrometheus,
+			wantRetention: "28d",
+		},
+		{
+			name:          "--prometheus-retention-period replaces the stored retention",
+			stored:        config.Config{MetricsBackend: config.MetricsBackendPrometheus, PrometheusRetention: "90d"},
+			args:          deploy.Args{PrometheusRetention: "7d", PrometheusRetentionIsSet: true},
+			wantBackend:   config.MetricsBackendPrometheus,
+			wantRetention: "7d",
+		},
+		{
+			name:        "--no-metrics removes the metrics stack",
+			stored:      config.Config{MetricsBackend: config.MetricsBackendPrometheus},
+			args:        deploy.Args{NoMetrics: true, NoMetricsIsSet: true},
+			wantBackend: config.MetricsBackendNone,
+		},
+		{
+			name:        "--no-metrics=false brings back the default metrics stack",
+			stored:      config.Config{MetricsBackend: config.MetricsBackendNone},
+			args:        deploy.Args{NoMetrics: false, NoMetricsIsSet: true},
+			wantBackend: config.MetricsBackendInfluxDB,
+		},
+		{
+			name:          "--no-metrics=false keeps a backend which is already deployed",
+			stored:        config.Config{MetricsBackend: config.MetricsBackendPrometheus, PrometheusRetention: "28d"},
+			args:          deploy.Args{NoMetrics: false, NoMetricsIsSet: true},
+			wantBackend:   config.MetricsBackendPrometheus,
+			wantRetention: "28d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args.AllowIPs = "0.0.0.0/0"
+			conf, _, err := applyArgumentsToConfig(tt.stored, &tt.args, &iaasfakes.FakeProvider{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if conf.MetricsBackend != tt.wantBackend || conf.PrometheusRetention != tt.wantRetention {
+				t.Errorf("applyArgumentsToConfig() backend = %q, retention = %q, want %q, %q",
+					conf.MetricsBackend, conf.PrometheusRetention, tt.wantBackend, tt.wantRetention)
+			}
+		})
+	}
+}
+
+func TestApplyArgumentsToConfig_StateStore(t *testing.T) {
+	stored := config.Config{StateStore: config.StateStoreLocal}
+	args := deploy.Args{AllowIPs: "0.0.0.0/0", StateStore: config.StateStoreS3, StateStoreLocation: "eu-west-2"}
+
+	conf, _, err := applyArgumentsToConfig(stored, &args, &iaasfakes.FakeProvider{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.StateStore != config.StateStoreS3 || conf.StateStoreLocation != "eu-west-2" {
+		t.Errorf("applyArgumentsToConfig() state store = %q, location = %q, want %q, %q",
+			conf.StateStore, conf.StateStoreLocation, config.StateStoreS3, "eu-west-2")
+	}
+}
+
+func TestWriteMetricsBackendChangeWarning(t *testing.T) {
+	tests := []struct {
+		previous string
+		next     string
+		want     string
+	}{
+		{previous: config.MetricsBackendInfluxDB, next: config.MetricsBackendInfluxDB, want: ""},
+		{previous: config.MetricsBackendNone, next: config.MetricsBackendPrometheus, want: ""},
+		{
+			previous: config.MetricsBackendInfluxDB,
+			next:     config.MetricsBackendPrometheus,
+			want:     "\nWARNING: switching metrics backend from influxdb to prometheus, the metrics collected by influxdb will not be migrated\n\n",
+		},
+		{
+			previous: config.MetricsBackendPrometheus,
+			next:     config.MetricsBackendNone,
+			want:     "\nWARNING: removing the prometheus metrics stack, the metrics it has collected will be lost\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.previous+" to "+tt.next, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeMetricsBackendChangeWarning(&buf, tt.previous, tt.next); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("writeMetricsBackendChangeWarning() wrote %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package concourse
+
+import (
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/cost"
+)
+
+// EstimateCost estimates the monthly cost of the deployment from its stored config
+func (client *Client) EstimateCost() (cost.Estimate, error) {
+	conf, err := client.configClient.Load()
+	if err != nil {
+		return cost.Estimate{}, err
+	}
+	return cost.EstimateMonthly(conf)
+}
+
+// writeCostSummary writes the estimated monthly cost of deploying conf. The estimate is only
+// informative, so failing to make one is a warning rather than an error.
+func (client *Client) writeCostSummary(conf config.ConfigView) error {
+	estimate, err := cost.EstimateMonthly(conf)
+	if err != nil {
+		_, err = fmt.Fprintf(client.stderr, "\nWARNING: could not estimate the monthly cost of the deployment: %s\n\n", err)
+		return err
+	}
+	if _, err = client.stdout.Write([]byte("\nESTIMATED MONTHLY COST\n\n")); err != nil {
+		return err
+	}
+	return estimate.Write(client.stdout)
+}
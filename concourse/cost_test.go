@@ -0,0 +1,52 @@
+package concourse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+func TestWriteCostSummary(t *testing.T) {
+	conf := config.Config{
+		IAAS:                 "AWS",
+		Region:               "eu-west-1",
+		ConcourseWebSize:     "small",
+		ConcourseWorkerSize:  "xlarge",
+		ConcourseWorkerCount: 1,
+		RDSInstanceClass:     "db.t3.small",
+		PersistentDisk:       "default",
+		VMProvisioningType:   config.SPOT,
+	}
+	var stdout, stderr bytes.Buffer
+	client := &Client{stdout: &stdout, stderr: &stderr}
+
+	if err := client.writeCostSummary(conf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"ESTIMATED MONTHLY COST", "concourse-web-small (t3.small)", "concourse-xlarge (m4.xlarge, spot)", "Workers run on spot VMs, saving an estimated"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("writeCostSummary() wrote %q, want it to contain %q", stdout.String(), want)
+		}
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("writeCostSummary() warned %q", stderr.String())
+	}
+}
+
+func TestWriteCostSummary_WarnsWhenItCannotEstimate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	client := &Client{stdout: &stdout, stderr: &stderr}
+
+	if err := client.writeCostSummary(config.Config{IAAS: "AWS", ConcourseWebSize: "huge"}); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("writeCostSummary() wrote %q, want nothing", stdout.String())
+	}
+	want := "\nWARNING: could not estimate the monthly cost of the deployment: unknown web size [huge]\n\n"
+	if stderr.String() != want {
+		t.Errorf("writeCostSummary() warned %q, want %q", stderr.String(), want)
+	}
+}
@@ -0,0 +1,135 @@
+package concourse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/terraform"
+)
+
+var invalidSnapshotNameChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// dbInstance returns the name of the RDS or Cloud SQL instance that holds the concourse_atc, uaa and credhub databases
+func (client *Client) dbInstance(tfOutputs terraform.Outputs) (string, error) {
+	switch client.provider.IAAS() {
+	case iaas.AWS:
+		// RDS addresses are <identifier>.<id>.<region>.rds.amazonaws.com
+		address, err := tfOutputs.Get("BoshDBAddress")
+		if err != nil {
+			return "", err
+		}
+		return strings.SplitN(address, ".", 2)[0], nil
+	case iaas.GCP:
+		return tfOutputs.Get("DBName")
+	}
+	return "", fmt.Errorf("IAAS not supported: %s", client.provider.IAAS())
+}
+
+// dbSnapshotName returns a name for a snapshot which is valid on both RDS and Cloud SQL
+func dbSnapshotName(deployment string, takenAt time.Time) string {
+	name := invalidSnapshotNameChars.ReplaceAllString(deployment, "-")
+	return fmt.Sprintf("%s-%s", strings.Trim(name, "-"), takenAt.UTC().Format("20060102-150405"))
+}
+
+// snapshotDB snapshots the database and records the snapshot ID in the stored config as well as conf
+func (client *Client) snapshotDB(conf *config.Config, tfOutputs terraform.Outputs) error {
+	instance, err := client.dbInstance(tfOutputs)
+	if err != nil {
+		return err
+	}
+
+	snapshotID, err := client.provider.SnapshotDB(instance, dbSnapshotName(conf.GetDeployment(), time.Now()))
+	if err != nil {
+		return err
+	}
+	conf.DBSnapshotID = snapshotID
+
+	// The snapshot is recorded straight away so that it isn't lost if a later step fails
+	storedConf, err := client.configClient.Load()
+	if err != nil {
+		return err
+	}
+	storedConf.DBSnapshotID = snapshotID
+	if err = client.configClient.Update(storedConf); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(client.stdout, "Snapshot of the database taken with ID %s\n", snapshotID)
+	return err
+}
+
+func (client *Client) snapshotDBSteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	return []step{
+		{id: events.PhaseDBSnapshot, description: "Snapshotting the database", phase: events.PhaseDBSnapshot, run: client.takeDBSnapshot},
+	}, nil, nil
+}
+
+// replacedDBInstance is the value of a run which holds the name that the RDS instance is kept under once the restore replaces it.
+// It is kept between attempts so that a retried restore, or its compensation, finds the instance it renamed.
+const replacedDBInstance = "replaced_db_instance"
+
+// replacedDBInstanceName returns a name for an RDS instance replaced by a restore, which is unique to the restore and
+// within the 63 characters RDS allows
+func replacedDBInstanceName(instance string, restoredAt time.Time) string {
+	suffix := "-replaced-" + restoredAt.UTC().Format("20060102-150405")
+	if maxLength := 63 - len(suffix); len(instance) > maxLength {
+		instance = instance[:maxLength]
+	}
+	return strings.TrimRight(instance, "-") + suffix
+}
+
+func (client *Client) restoreDBSteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	_, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return nil, nil, err
+	}
+	instance, err := client.dbInstance(tfOutputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// RDS can't restore into an existing instance, so the restore renames it out of the way
+	if client.provider.IAAS() == iaas.AWS && run.Values[replacedDBInstance] == "" {
+		run.Values[replacedDBInstance] = replacedDBInstanceName(instance, time.Now())
+	}
+	replaced := run.Values[replacedDBInstance]
+
+	restore := step{id: events.PhaseDBRestore, description: fmt.Sprintf("Restoring the database from snapshot %s", m.ProcedureArgument), phase: events.PhaseDBRestore, run: func() error {
+		return client.restoreDBSnapshot(instance, m.ProcedureArgument, replaced)
+	}}
+	if replaced != "" {
+		restore.compensate = func() error {
+			return client.provider.UndoRestoreDB(instance, replaced)
+		}
+	}
+	return []step{restore}, nil, nil
+}
+
+// takeDBSnapshot snapshots the database of an existing deployment
+func (client *Client) takeDBSnapshot() error {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+	return client.snapshotDB(&conf, tfOutputs)
+}
+
+// restoreDBSnapshot restores the database of an existing deployment from a snapshot. On AWS, the instance it
+// replaces is renamed to replaced, which is left for the user to delete once they have checked the restore.
+func (client *Client) restoreDBSnapshot(instance, snapshotID, replaced string) error {
+	if err := client.provider.RestoreDB(instance, snapshotID, replaced); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(client.stdout, "Database %s restored from snapshot %s\n", instance, snapshotID); err != nil || replaced == "" {
+		return err
+	}
+	_, err := fmt.Fprintf(client.stderr, "\nWARNING: the RDS instance replaced by the restore has been kept as %s, and is still charged for. Once you have checked the restored database, delete it with:\n  aws rds delete-db-instance --db-instance-identifier %s --skip-final-snapshot\n\n", replaced, replaced)
+	return err
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/EngineerBetter/control-tower/bosh"
 	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/EngineerBetter/control-tower/fly"
 	"github.com/EngineerBetter/control-tower/terraform"
 	"github.com/go-acme/lego/v4/lego"
@@ -49,29 +50,96 @@ func stripVersion(tags []string) []string {
 
 // Deploy deploys a concourse instance
 func (client *Client) Deploy() error {
-	err := client.configClient.EnsureBucketExists()
-	if err != nil {
-		return fmt.Errorf("error ensuring config bucket exists before deploy: [%v]", err)
+	if client.deployArgs.DryRun {
+		return client.plan()
+	}
+
+	// The state of a deployment in a local store only exists on the machine that created it, so a
+	// worker running the self-update pipeline would find no deployment and create a second one
+	if client.deployArgs.SelfUpdate && client.deployArgs.StateStore == config.StateStoreLocal {
+		return fmt.Errorf("--self-update can't be used with a %s state store, as it can't be read from the deployment's workers", config.StateStoreLocal)
 	}
 
-	conf, isDomainUpdated, err := client.getInitialConfig()
+	err := client.recorder.Record(events.PhaseBucketEnsure, func() error {
+		if err := client.configClient.EnsureBucketExists(); err != nil {
+			return fmt.Errorf("error ensuring config bucket exists before deploy: [%v]", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("error getting initial config before deploy: [%v]", err)
+		return err
 	}
 
-	r, err := client.checkPreTerraformConfigRequirements(conf, client.deployArgs.SelfUpdate)
+	var conf config.Config
+	var isDomainUpdated bool
+	err = client.recorder.Record(events.PhaseConfigMerge, func() error {
+		var err error
+		conf, isDomainUpdated, err = client.getInitialConfig()
+		if err != nil {
+			return fmt.Errorf("error getting initial config before deploy: [%v]", err)
+		}
+
+		r, err := client.checkPreTerraformConfigRequirements(conf, client.deployArgs.SelfUpdate, client.stderr)
+		if err != nil {
+			return err
+		}
+		conf.Region = r.Region
+		conf.SourceAccessIP = r.SourceAccessIP
+		conf.HostedZoneID = r.HostedZoneID
+		conf.HostedZoneRecordPrefix = r.HostedZoneRecordPrefix
+		conf.Domain = r.Domain
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	conf.Region = r.Region
-	conf.SourceAccessIP = r.SourceAccessIP
-	conf.HostedZoneID = r.HostedZoneID
-	conf.HostedZoneRecordPrefix = r.HostedZoneRecordPrefix
-	conf.Domain = r.Domain
+
+	if err = client.writeCostSummary(conf); err != nil {
+		return err
+	}
+
+	checkpoint, err := client.loadCheckpoint()
+	if err != nil {
+		return fmt.Errorf("error loading deploy checkpoint: [%v]", err)
+	}
 
 	tfInputVars := client.tfInputVarsFactory.NewInputVars(conf)
 
-	err = client.tfCLI.Apply(tfInputVars)
+	// Only an existing deployment has a database to snapshot
+	if client.deployArgs.SnapshotDB && conf.Version != "" {
+		if client.phaseUnchanged(checkpoint, events.PhaseDBSnapshot, client.version) {
+			err = client.skipPhase(events.PhaseDBSnapshot)
+		} else {
+			err = client.recorder.Record(events.PhaseDBSnapshot, func() error {
+				tfOutputs, err := client.tfCLI.BuildOutput(tfInputVars)
+				if err != nil {
+					return err
+				}
+				return client.snapshotDB(&conf, tfOutputs)
+			})
+			if err == nil {
+				err = client.completePhase(checkpoint, events.PhaseDBSnapshot, client.version)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	tfDigest, err := client.terraformApplyDigest(tfInputVars)
+	if err != nil {
+		return err
+	}
+	if client.phaseUnchanged(checkpoint, events.PhaseTerraformApply, tfDigest) {
+		err = client.skipPhase(events.PhaseTerraformApply)
+	} else {
+		err = client.recorder.Record(events.PhaseTerraformApply, func() error {
+			return client.tfCLI.Apply(tfInputVars)
+		})
+		if err == nil {
+			err = client.completePhase(checkpoint, events.PhaseTerraformApply, tfDigest)
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -81,7 +149,9 @@ func (client *Client) Deploy() error {
 		return err
 	}
 
-	err = client.configClient.Update(conf)
+	err = client.recorder.Record(events.PhaseConfigSave, func() error {
+		return client.configClient.Update(conf)
+	})
 	if err != nil {
 		return err
 	}
@@ -90,7 +160,28 @@ func (client *Client) Deploy() error {
 
 	conf.Version = client.version
 
-	cr, err := client.checkPreDeployConfigRequirements(client.acmeClientConstructor, isDomainUpdated, conf, tfOutputs)
+	var cr Requirements
+	certDigest, err := inputsDigest(client.certInputs(conf, isDomainUpdated, tfOutputs))
+	if err != nil {
+		return err
+	}
+	// Certs are only persisted with the config at the end of the deploy, so can only be reused if they made it there.
+	// Certs due for renewal are renewed by this phase, so it is never skipped for them.
+	if client.phaseUnchanged(checkpoint, events.PhaseCertGeneration, certDigest) && conf.DirectorCACert != "" && conf.ConcourseCert != "" && !certsDueForRenewal(conf) {
+		cr, err = requirementsFromConfig(conf, tfOutputs)
+		if err == nil {
+			err = client.skipPhase(events.PhaseCertGeneration)
+		}
+	} else {
+		err = client.recorder.Record(events.PhaseCertGeneration, func() error {
+			var err error
+			cr, err = client.checkPreDeployConfigRequirements(client.acmeClientConstructor, isDomainUpdated, conf, tfOutputs)
+			return err
+		})
+		if err == nil {
+			err = client.completePhase(checkpoint, events.PhaseCertGeneration, certDigest)
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -123,47 +214,147 @@ func (client *Client) Deploy() error {
 	conf.DirectorPassword = bp.DirectorPassword
 	conf.DirectorCACert = bp.DirectorCACert
 
-	err1 := client.configClient.Update(conf)
+	err1 := client.recorder.Record(events.PhaseConfigSave, func() error {
+		return client.configClient.Update(conf)
+	})
 	if err == nil {
 		err = err1
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return client.clearCheckpoint()
 }
 
-func (client *Client) deployBoshAndPipeline(c config.ConfigView, tfOutputs terraform.Outputs) (BoshParams, error) {
-	// When we are deploying for the first time rather than updating
-	// ensure that the pipeline is set _after_ the concourse is deployed
+// plan prints the infrastructure and Concourse manifest changes that a deploy would make
+// without applying them or persisting any config
+func (client *Client) plan() error {
+	priorConfigExists, err := client.configClient.ConfigExists()
+	if err != nil {
+		return fmt.Errorf("error determining if config already exists [%v]", err)
+	}
+	if !priorConfigExists {
+		return fmt.Errorf("--dry-run can only be used against an existing deployment")
+	}
 
-	bp, err := client.deployBosh(c, tfOutputs, false)
+	conf, _, err := client.getInitialConfig()
 	if err != nil {
-		return bp, err
+		return fmt.Errorf("error getting initial config before dry run: [%v]", err)
 	}
 
-	credhubClient, err := client.credhubClientFactory(bp.CredhubURL, "credhub_admin", bp.CredhubAdminClientSecret, bp.CredhubCACert)
+	// Nothing is changed by a dry run, so the warnings about allowing the IP and adding the DNS record are left out
+	r, err := client.checkPreTerraformConfigRequirements(conf, false, io.Discard)
 	if err != nil {
-		return bp, err
+		return err
 	}
+	conf.Region = r.Region
+	conf.SourceAccessIP = r.SourceAccessIP
+	conf.HostedZoneID = r.HostedZoneID
+	conf.HostedZoneRecordPrefix = r.HostedZoneRecordPrefix
+	conf.Domain = r.Domain
 
-	if err = credhubClient.SetSelfUpdateCreds(client.provider, tfOutputs); err != nil {
-		return bp, err
+	tfInputVars := client.tfInputVarsFactory.NewInputVars(conf)
+
+	_, err = client.stdout.Write([]byte("\nINFRASTRUCTURE CHANGES\n\n"))
+	if err != nil {
+		return err
 	}
 
-	flyClient, err := client.flyClientFactory(client.provider, fly.Credentials{
-		Target:   c.GetDeployment(),
-		API:      fmt.Sprintf("https://%s", c.GetDomain()),
-		Username: bp.ConcourseUsername,
-		Password: bp.ConcoursePassword,
-	},
-		client.stdout,
-		client.stderr,
-		client.versionFile,
-	)
+	err = client.tfCLI.Plan(tfInputVars)
+	if err != nil {
+		return err
+	}
+
+	tfOutputs, err := client.tfCLI.BuildOutput(tfInputVars)
+	if err != nil {
+		return err
+	}
+
+	if conf.Domain == "" {
+		conf.Domain, err = tfOutputs.Get("ATCPublicIP")
+		if err != nil {
+			return err
+		}
+	}
+	if client.deployArgs.TLSCert != "" {
+		conf.ConcourseCert = client.deployArgs.TLSCert
+		conf.ConcourseKey = client.deployArgs.TLSKey
+	}
+	conf.Tags = stripVersion(conf.Tags)
+	conf.Tags = append([]string{fmt.Sprintf("control-tower-version=%s", client.version)}, conf.Tags...)
+	conf.Version = client.version
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	boshCredsBytes, err := loadDirectorCreds(client.configClient)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.stdout.Write([]byte("\nCONCOURSE MANIFEST CHANGES\n\n"))
+	if err != nil {
+		return err
+	}
+
+	err = boshClient.Diff(boshCredsBytes)
+	if err != nil {
+		return err
+	}
+
+	if err = client.writeCostSummary(conf); err != nil {
+		return err
+	}
+
+	_, err = client.stdout.Write([]byte("\nDRY RUN COMPLETE. No changes have been applied\n"))
+	return err
+}
+
+func (client *Client) deployBoshAndPipeline(c config.ConfigView, tfOutputs terraform.Outputs) (BoshParams, error) {
+	// When we are deploying for the first time rather than updating
+	// ensure that the pipeline is set _after_ the concourse is deployed
+
+	bp, err := client.deployBosh(c, tfOutputs, false)
 	if err != nil {
 		return bp, err
 	}
-	defer flyClient.Cleanup()
 
-	if err := flyClient.SetDefaultPipeline(c, false); err != nil {
+	err = client.recorder.Record(events.PhaseSetPipeline, func() error {
+		if c.GetStateStore() == config.StateStoreLocal {
+			_, err := fmt.Fprintf(client.stderr, "\nWARNING: not setting the self-update pipeline, as the deployment's state is in a %s state store which its workers can't read. Upgrade it by running deploy again\n\n", config.StateStoreLocal)
+			return err
+		}
+
+		credhubClient, err := client.credhubClientFactory(bp.CredhubURL, "credhub_admin", bp.CredhubAdminClientSecret, bp.CredhubCACert)
+		if err != nil {
+			return err
+		}
+
+		if err = credhubClient.SetSelfUpdateCreds(client.provider, tfOutputs); err != nil {
+			return err
+		}
+
+		flyClient, err := client.flyClientFactory(client.provider, fly.Credentials{
+			Target:   c.GetDeployment(),
+			API:      fmt.Sprintf("https://%s", c.GetDomain()),
+			Username: bp.ConcourseUsername,
+			Password: bp.ConcoursePassword,
+		},
+			client.stdout,
+			client.stderr,
+			client.versionFile,
+		)
+		if err != nil {
+			return err
+		}
+		defer flyClient.Cleanup()
+
+		return flyClient.SetDefaultPipeline(c, false)
+	})
+	if err != nil {
 		return bp, err
 	}
 
@@ -215,17 +406,20 @@ func (client *Client) updateBoshAndPipeline(c config.ConfigView, tfOutputs terra
 	}
 	defer flyClient.Cleanup()
 
-	concourseAlreadyRunning, err := flyClient.CanConnect()
-	if err != nil {
-		return bp, err
-	}
+	err = client.recorder.Record(events.PhaseSetPipeline, func() error {
+		concourseAlreadyRunning, err := flyClient.CanConnect()
+		if err != nil {
+			return err
+		}
 
-	if !concourseAlreadyRunning {
-		return bp, fmt.Errorf("In detach mode but it seems that concourse is not currently running")
-	}
+		if !concourseAlreadyRunning {
+			return fmt.Errorf("In detach mode but it seems that concourse is not currently running")
+		}
 
-	// Allow a fly version discrepancy since we might be targetting an older Concourse
-	if err = flyClient.SetDefaultPipeline(c, true); err != nil {
+		// Allow a fly version discrepancy since we might be targetting an older Concourse
+		return flyClient.SetDefaultPipeline(c, true)
+	})
+	if err != nil {
 		return bp, err
 	}
 
@@ -248,7 +442,8 @@ type TerraformRequirements struct {
 	Domain                 string
 }
 
-func (client *Client) checkPreTerraformConfigRequirements(conf config.ConfigView, selfUpdate bool) (TerraformRequirements, error) {
+// checkPreTerraformConfigRequirements works out the values terraform needs, writing warnings about the changes they make to warnings
+func (client *Client) checkPreTerraformConfigRequirements(conf config.ConfigView, selfUpdate bool, warnings io.Writer) (TerraformRequirements, error) {
 	r := TerraformRequirements{
 		Region:                 conf.GetRegion(),
 		SourceAccessIP:         conf.GetSourceAccessIP(),
@@ -269,13 +464,13 @@ func (client *Client) checkPreTerraformConfigRequirements(conf config.ConfigView
 	// When in self-update mode do not override the user IP, since we already have access to the worker
 	if !selfUpdate {
 		var err error
-		r.SourceAccessIP, err = client.setUserIP(conf)
+		r.SourceAccessIP, err = client.setUserIP(conf, warnings)
 		if err != nil {
 			return r, err
 		}
 	}
 
-	zone, err := client.setHostedZone(conf, conf.GetDomain())
+	zone, err := client.setHostedZone(conf, conf.GetDomain(), warnings)
 	if err != nil {
 		return r, err
 	}
@@ -356,13 +551,70 @@ func (client *Client) checkPreDeployConfigRequirements(c func(u *certs.User) (*l
 	return cr, nil
 }
 
+// certInputs returns the values which the certificates generated by checkPreDeployConfigRequirements depend on
+func (client *Client) certInputs(cfg config.ConfigView, isDomainUpdated bool, tfOutputs terraform.Outputs) interface{} {
+	directorPublicIP, _ := tfOutputs.Get("DirectorPublicIP")
+	atcPublicIP, _ := tfOutputs.Get("ATCPublicIP")
+	return struct {
+		Deployment       string
+		Domain           string
+		IsDomainUpdated  bool
+		DirectorPublicIP string
+		ATCPublicIP      string
+		PublicCIDR       string
+		TLSCert          string
+		TLSKey           string
+	}{
+		Deployment:       cfg.GetDeployment(),
+		Domain:           cfg.GetDomain(),
+		IsDomainUpdated:  isDomainUpdated,
+		DirectorPublicIP: directorPublicIP,
+		ATCPublicIP:      atcPublicIP,
+		PublicCIDR:       cfg.GetPublicCIDR(),
+		TLSCert:          client.deployArgs.TLSCert,
+		TLSKey:           client.deployArgs.TLSKey,
+	}
+}
+
+// requirementsFromConfig returns the pre deployment requirements using the certificates already in the config
+func requirementsFromConfig(cfg config.ConfigView, tfOutputs terraform.Outputs) (Requirements, error) {
+	cr := Requirements{
+		Domain: cfg.GetDomain(),
+		DirectorCerts: DirectorCerts{
+			DirectorCACert: cfg.GetDirectorCACert(),
+			DirectorCert:   cfg.GetDirectorCert(),
+			DirectorKey:    cfg.GetDirectorKey(),
+		},
+		Certs: Certs{
+			ConcourseCert:   cfg.GetConcourseCert(),
+			ConcourseKey:    cfg.GetConcourseKey(),
+			ConcourseCACert: cfg.GetConcourseCACert(),
+		},
+	}
+
+	var err error
+	if cr.Domain == "" {
+		cr.Domain, err = tfOutputs.Get("ATCPublicIP")
+		if err != nil {
+			return cr, err
+		}
+	}
+	cr.DirectorPublicIP, err = tfOutputs.Get("DirectorPublicIP")
+	return cr, err
+}
+
 func (client *Client) ensureDirectorCerts(c func(u *certs.User) (*lego.Client, error), dc DirectorCerts, deployment string, tfOutputs terraform.Outputs, publicCIDR string) (DirectorCerts, error) {
 	// If we already have director certificates, don't regenerate as changing them will
 	// force a bosh director re-deploy even if there are no other changes
-	certs := dc
-	if certs.DirectorCACert != "" {
-		return certs, nil
+	if dc.DirectorCACert != "" {
+		return dc, nil
 	}
+	return client.generateDirectorCerts(c, dc, deployment, tfOutputs, publicCIDR)
+}
+
+// generateDirectorCerts returns new director certificates valid for its public and internal IPs
+func (client *Client) generateDirectorCerts(c func(u *certs.User) (*lego.Client, error), dc DirectorCerts, deployment string, tfOutputs terraform.Outputs, publicCIDR string) (DirectorCerts, error) {
+	certs := dc
 
 	// @Note: Duplicate code retrieving director internal IP needs to find a home
 	_, pubCIDR, err1 := net.ParseCIDR(publicCIDR)
@@ -396,6 +648,9 @@ func (client *Client) ensureDirectorCerts(c func(u *certs.User) (*lego.Client, e
 	return certs, nil
 }
 
+// certRenewalWindow is how long before expiry a certificate is renewed on deploy
+const certRenewalWindow = 28 * 24 * time.Hour
+
 func timeTillExpiry(cert string) time.Duration {
 	block, _ := pem.Decode([]byte(cert))
 	if block == nil {
@@ -419,7 +674,7 @@ func (client *Client) ensureConcourseCerts(c func(u *certs.User) (*lego.Client,
 
 	// Skip concourse re-deploy if certs have already been set,
 	// unless domain has changed
-	if certs.ConcourseCert != "" && !domainUpdated && timeTillExpiry(certs.ConcourseCert) > 28*24*time.Hour {
+	if certs.ConcourseCert != "" && !domainUpdated && timeTillExpiry(certs.ConcourseCert) > certRenewalWindow {
 		return certs, nil
 	}
 
@@ -507,7 +762,7 @@ func (client *Client) deployBosh(config config.ConfigView, tfOutputs terraform.O
 	return bp, nil
 }
 
-func (client *Client) setUserIP(c config.ConfigView) (string, error) {
+func (client *Client) setUserIP(c config.ConfigView, warnings io.Writer) (string, error) {
 	sourceAccessIP := c.GetSourceAccessIP()
 	userIP, err := client.ipChecker()
 	if err != nil {
@@ -516,7 +771,7 @@ func (client *Client) setUserIP(c config.ConfigView) (string, error) {
 
 	if sourceAccessIP != userIP {
 		sourceAccessIP = userIP
-		_, err = client.stderr.Write([]byte(fmt.Sprintf(
+		_, err = warnings.Write([]byte(fmt.Sprintf(
 			"\nWARNING: allowing access from local machine (address: %s)\n\n", userIP)))
 		if err != nil {
 			return sourceAccessIP, err
@@ -533,7 +788,7 @@ type HostedZone struct {
 	Domain                 string
 }
 
-func (client *Client) setHostedZone(c config.ConfigView, domain string) (HostedZone, error) {
+func (client *Client) setHostedZone(c config.ConfigView, domain string, warnings io.Writer) (HostedZone, error) {
 	zone := HostedZone{
 		HostedZoneID:           c.GetHostedZoneID(),
 		HostedZoneRecordPrefix: c.GetHostedZoneRecordPrefix(),
@@ -559,7 +814,7 @@ func (client *Client) setHostedZone(c config.ConfigView, domain string) (HostedZ
 	}
 	zone.Domain = domain
 
-	_, err = client.stderr.Write([]byte(fmt.Sprintf(
+	_, err = warnings.Write([]byte(fmt.Sprintf(
 		"\nWARNING: adding record %s to DNS zone %s with name %s\n\n", domain, hostedZoneName, hostedZoneID)))
 	if err != nil {
 		return zone, err
@@ -570,7 +825,7 @@ func (client *Client) setHostedZone(c config.ConfigView, domain string) (HostedZ
 const deployMsg = `DEPLOY SUCCESSFUL. Log in with:
 fly --target {{.Project}} login{{if not .ConcourseUserProvidedCert}} --insecure{{end}} --concourse-url https://{{.Domain}} --username {{.ConcourseUsername}} --password {{.ConcoursePassword}}
 
-Metrics available at https://{{.Domain}}:3000 using the same username and password (unless you have disabled metrics with --no-metrics)
+Metrics available at https://{{.Domain}}:3000 using the same username and password (unless you have disabled metrics with --metrics-backend none)
 
 Run the following to authenticate with both CredHub and your BOSH director:
 eval "$(control-tower info --region {{.Region}} {{ if ne .Namespace .Region }} --namespace {{ .Namespace }} {{ end }} --iaas {{ .IAAS }} --env {{.Project}})"
@@ -4,13 +4,20 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
 	"github.com/EngineerBetter/control-tower/iaas"
 )
 
 // Destroy destroys a concourse instance
 func (client *Client) Destroy() error {
 
-	conf, err := client.configClient.Load()
+	var conf config.Config
+	err := client.recorder.Record(events.PhaseConfigLoad, func() error {
+		var err error
+		conf, err = client.configClient.Load()
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -19,49 +26,63 @@ func (client *Client) Destroy() error {
 
 	var volumesToDelete []string
 
-	switch client.provider.IAAS() {
+	err = client.recorder.Record(events.PhaseVMDeletion, func() error {
+		switch client.provider.IAAS() {
 
-	case iaas.AWS:
-		tfOutputs, err1 := client.tfCLI.BuildOutput(tfInputVars)
-		if err1 != nil {
-			return err1
-		}
-		vpcID, err2 := tfOutputs.Get("VPCID")
-		if err2 != nil {
-			return err2
-		}
-		volumesToDelete, err1 = client.provider.DeleteVMsInVPC(vpcID)
-		if err1 != nil {
-			return err1
-		}
+		case iaas.AWS:
+			tfOutputs, err1 := client.tfCLI.BuildOutput(tfInputVars)
+			if err1 != nil {
+				return err1
+			}
+			vpcID, err2 := tfOutputs.Get("VPCID")
+			if err2 != nil {
+				return err2
+			}
+			volumesToDelete, err1 = client.provider.DeleteVMsInVPC(vpcID)
+			if err1 != nil {
+				return err1
+			}
 
-	case iaas.GCP:
-		project, err1 := client.provider.Attr("project")
-		if err1 != nil {
-			return err1
-		}
-		zone := client.provider.Zone("", "")
-		err1 = client.provider.DeleteVMsInDeployment(zone, project, conf.GetDeployment())
-		if err1 != nil {
-			return err1
+		case iaas.GCP:
+			project, err1 := client.provider.Attr("project")
+			if err1 != nil {
+				return err1
+			}
+			zone := client.provider.Zone("", "")
+			err1 = client.provider.DeleteVMsInDeployment(zone, project, conf.GetDeployment())
+			if err1 != nil {
+				return err1
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	err = client.tfCLI.Destroy(tfInputVars)
+	err = client.recorder.Record(events.PhaseTerraformDestroy, func() error {
+		return client.tfCLI.Destroy(tfInputVars)
+	})
 	if err != nil {
 		return err
 	}
 
 	if client.provider.IAAS() == iaas.AWS {
-		if len(volumesToDelete) > 0 {
-			fmt.Printf("Scheduling to delete %v volumes\n", len(volumesToDelete))
-		}
-		if err1 := client.provider.DeleteVolumes(volumesToDelete, iaas.DeleteVolume); err1 != nil {
-			return err1
+		err = client.recorder.Record(events.PhaseVolumeDeletion, func() error {
+			if len(volumesToDelete) > 0 {
+				fmt.Fprintf(client.stdout, "Scheduling to delete %v volumes\n", len(volumesToDelete))
+			}
+			return client.provider.DeleteVolumes(volumesToDelete, iaas.DeleteVolume)
+		})
+		if err != nil {
+			return err
 		}
 	}
 
-	if err = client.configClient.DeleteAll(conf); err != nil {
+	err = client.recorder.Record(events.PhaseConfigDelete, func() error {
+		return client.configClient.DeleteAll(conf)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,120 @@
+package concourse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/events"
+)
+
+// cleanupDirectorSteps plans the deletion of the releases, stemcells and orphaned disks the director no longer needs
+func (client *Client) cleanupDirectorSteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { boshClient.Cleanup() }
+
+	// bosh clean-up always keeps orphaned disks, so that those younger than --orphaned-disk-age are kept even with
+	// --all, and they are only deleted by the step which filters them by age. --all alone deletes all of them.
+	steps := []step{
+		{id: events.PhaseBOSHCleanup, description: "Cleaning up the director", phase: events.PhaseBOSHCleanup, run: func() error {
+			return client.cleanupDirector(run, boshClient, m.AllIsSet)
+		}},
+	}
+	if m.OrphanedDiskAgeIsSet || m.AllIsSet {
+		description := "Deleting every orphaned disk"
+		if m.OrphanedDiskAgeIsSet {
+			description = fmt.Sprintf("Deleting orphaned disks older than %s", m.OrphanedDiskAge)
+		}
+		steps = append(steps, step{id: events.PhaseOrphanedDiskDeletion, description: description, phase: events.PhaseOrphanedDiskDeletion, run: func() error {
+			return client.deleteOrphanedDisks(run, boshClient, m.OrphanedDiskAge)
+		}})
+	}
+	return steps, cleanup, nil
+}
+
+// cleanupDirector runs bosh clean-up, reporting the releases and stemcells its dry run planned to delete. With all,
+// every unused release and stemcell is deleted rather than all but the two most recent, so it refuses to run while
+// the director has tasks in progress in case they are about to use one.
+func (client *Client) cleanupDirector(run *ProcedureRun, boshClient bosh.IClient, all bool) error {
+	if all {
+		locked, err := client.checkIfLocked()
+		if err != nil {
+			return err
+		}
+		if locked {
+			return fmt.Errorf("the director has tasks in progress, run --cleanup --all again once they have finished")
+		}
+	}
+
+	plan, err := boshClient.PlanCleanUp(all)
+	if err != nil {
+		return err
+	}
+	client.logf(run, "bosh clean-up plans to delete %s and %s", countOf(len(plan.Releases), "release"), countOf(len(plan.Stemcells), "stemcell"))
+	for _, release := range plan.Releases {
+		client.logf(run, "  release %s", release)
+	}
+	for _, stemcell := range plan.Stemcells {
+		client.logf(run, "  stemcell %s", stemcell)
+	}
+
+	if err = boshClient.CleanUp(all); err != nil {
+		return err
+	}
+	client.logf(run, "Finished the planned clean-up of %s and %s", countOf(len(plan.Releases), "release"), countOf(len(plan.Stemcells), "stemcell"))
+	return nil
+}
+
+// deleteOrphanedDisks deletes the orphaned disks which were orphaned longer ago than maxAge, or all of them when it is zero
+func (client *Client) deleteOrphanedDisks(run *ProcedureRun, boshClient bosh.IClient, maxAge time.Duration) error {
+	disks, err := boshClient.OrphanedDisks()
+	if err != nil {
+		return err
+	}
+
+	var deleted []bosh.OrphanedDisk
+	for _, disk := range disks {
+		if time.Since(disk.OrphanedAt) < maxAge {
+			continue
+		}
+		if err = boshClient.DeleteOrphanedDisk(disk.CID); err != nil {
+			return err
+		}
+		client.logf(run, "Deleted orphaned disk %s of %s (%s), orphaned at %s", disk.CID, disk.Instance, formatDiskSize(disk.SizeMB), formatTime(&disk.OrphanedAt))
+		deleted = append(deleted, disk)
+	}
+	client.logf(run, "Deleted %s, reclaiming %s of disk space", countOf(len(deleted), "orphaned disk"), formatDiskSize(totalSizeMB(deleted)))
+	return nil
+}
+
+func totalSizeMB(disks []bosh.OrphanedDisk) uint64 {
+	var total uint64
+	for _, disk := range disks {
+		total += disk.SizeMB
+	}
+	return total
+}
+
+func formatDiskSize(sizeMB uint64) string {
+	if sizeMB < 1024 {
+		return fmt.Sprintf("%d MiB", sizeMB)
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(sizeMB)/1024), ".0") + " GiB"
+}
+
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
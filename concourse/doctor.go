@@ -0,0 +1,350 @@
+package concourse
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/fly"
+	"github.com/EngineerBetter/control-tower/terraform"
+)
+
+// Statuses of a doctor check
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// Names of the doctor checks, in the order they run
+const (
+	checkConfigBucket     = "config-bucket"
+	checkTerraformOutputs = "terraform-outputs"
+	checkIPAllowed        = "ip-allowed"
+	checkDNS              = "dns"
+	checkTLS              = "tls"
+	checkConcourseAPI     = "concourse-api"
+	checkBOSHInstances    = "bosh-instances"
+	checkBOSHLocks        = "bosh-locks"
+)
+
+// tlsExpiryWarning is how soon before the Concourse certificate expires the tls check warns
+const tlsExpiryWarning = 30 * 24 * time.Hour
+
+// CheckResult is the outcome of one of the checks run by doctor
+type CheckResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+type check struct {
+	name     string
+	requires []string
+	run      func() (string, string)
+}
+
+// doctor holds what the checks have found out about the deployment so far, so that
+// later checks can use the config and terraform outputs loaded by earlier ones
+type doctor struct {
+	client     *Client
+	lookupHost func(host string) ([]string, error)
+	dialTLS    func(domain string, config *tls.Config) (*tls.Conn, error)
+	now        func() time.Time
+
+	conf       config.Config
+	tfOutputs  terraform.Outputs
+	boshClient bosh.IClient
+}
+
+func dialTLS(domain string, config *tls.Config) (*tls.Conn, error) {
+	return tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", net.JoinHostPort(domain, "443"), config)
+}
+
+// Doctor runs each check against the deployment and writes whether it passed, warned or failed.
+// It returns an error if any check failed.
+func (client *Client) Doctor() error {
+	d := &doctor{
+		client:     client,
+		lookupHost: net.LookupHost,
+		dialTLS:    dialTLS,
+		now:        time.Now,
+	}
+	defer d.cleanup()
+
+	results := d.run()
+	if err := writeCheckResults(client.stdout, results); err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Status == CheckFail {
+			failed = append(failed, result.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d checks failed: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (d *doctor) checks() []check {
+	return []check{
+		{name: checkConfigBucket, run: d.checkConfigBucket},
+		{name: checkTerraformOutputs, requires: []string{checkConfigBucket}, run: d.checkTerraformOutputs},
+		{name: checkIPAllowed, requires: []string{checkTerraformOutputs}, run: d.checkIPAllowed},
+		{name: checkDNS, requires: []string{checkTerraformOutputs}, run: d.checkDNS},
+		{name: checkTLS, requires: []string{checkConfigBucket}, run: d.checkTLS},
+		{name: checkConcourseAPI, requires: []string{checkConfigBucket}, run: d.checkConcourseAPI},
+		{name: checkBOSHInstances, requires: []string{checkIPAllowed}, run: d.checkBOSHInstances},
+		{name: checkBOSHLocks, requires: []string{checkIPAllowed}, run: d.checkBOSHLocks},
+	}
+}
+
+// run runs the checks in order. A check fails without running if a check it requires failed.
+func (d *doctor) run() []CheckResult {
+	statuses := map[string]string{}
+	var results []CheckResult
+	for _, c := range d.checks() {
+		status, detail := d.runCheck(c, statuses)
+		statuses[c.name] = status
+		results = append(results, CheckResult{Name: c.name, Status: status, Detail: detail})
+	}
+	return results
+}
+
+func (d *doctor) runCheck(c check, statuses map[string]string) (string, string) {
+	for _, required := range c.requires {
+		if statuses[required] == CheckFail {
+			return CheckFail, fmt.Sprintf("not checked as %s failed", required)
+		}
+	}
+	return c.run()
+}
+
+func (d *doctor) cleanup() {
+	if d.boshClient != nil {
+		d.boshClient.Cleanup()
+	}
+}
+
+func (d *doctor) checkConfigBucket() (string, string) {
+	conf, err := d.client.configClient.Load()
+	if err != nil {
+		return CheckFail, fmt.Sprintf("could not load the config: %s", err)
+	}
+	d.conf = conf
+	return CheckPass, "config loaded"
+}
+
+func (d *doctor) checkTerraformOutputs() (string, string) {
+	tfOutputs, err := d.client.tfCLI.BuildOutput(d.client.tfInputVarsFactory.NewInputVars(d.conf))
+	if err != nil {
+		return CheckFail, fmt.Sprintf("could not read the terraform outputs: %s", err)
+	}
+	if err = tfOutputs.AssertValid(); err != nil {
+		return CheckFail, fmt.Sprintf("the terraform outputs are invalid: %s", err)
+	}
+	d.tfOutputs = tfOutputs
+	return CheckPass, "all terraform outputs are present"
+}
+
+func (d *doctor) checkIPAllowed() (string, string) {
+	userIP, err := d.client.ipChecker()
+	if err != nil {
+		return CheckFail, fmt.Sprintf("could not find your IP: %s", err)
+	}
+	directorSecurityGroupID, err := d.tfOutputs.Get("DirectorSecurityGroupID")
+	if err != nil {
+		return CheckFail, err.Error()
+	}
+	whitelisted, err := d.client.provider.CheckForWhitelistedIP(userIP, directorSecurityGroupID)
+	if err != nil {
+		return CheckFail, err.Error()
+	}
+	if !whitelisted {
+		return CheckFail, fmt.Sprintf("your IP %s is not allowed by %s, add it with deploy --allow-ips", userIP, directorSecurityGroupID)
+	}
+	return CheckPass, fmt.Sprintf("your IP %s is allowed to reach the director", userIP)
+}
+
+func (d *doctor) checkDNS() (string, string) {
+	atcPublicIP, err := d.tfOutputs.Get("ATCPublicIP")
+	if err != nil {
+		return CheckFail, err.Error()
+	}
+	domain := d.conf.Domain
+	if net.ParseIP(domain) != nil {
+		if domain != atcPublicIP {
+			return CheckFail, fmt.Sprintf("Concourse is reached at %s, not its public IP %s", domain, atcPublicIP)
+		}
+		return CheckPass, fmt.Sprintf("no domain is set, Concourse is reached at %s", domain)
+	}
+
+	addresses, err := d.lookupHost(domain)
+	if err != nil {
+		return CheckFail, fmt.Sprintf("could not resolve %s: %s", domain, err)
+	}
+	for _, address := range addresses {
+		if address == atcPublicIP {
+			return CheckPass, fmt.Sprintf("%s resolves to %s", domain, atcPublicIP)
+		}
+	}
+	return CheckFail, fmt.Sprintf("%s resolves to %s, not the public IP of Concourse %s", domain, strings.Join(addresses, ","), atcPublicIP)
+}
+
+func (d *doctor) checkTLS() (string, string) {
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if d.conf.ConcourseCACert != "" {
+		roots.AppendCertsFromPEM([]byte(d.conf.ConcourseCACert))
+	}
+
+	conn, err := d.dialTLS(d.conf.Domain, &tls.Config{ServerName: d.conf.Domain, RootCAs: roots})
+	if err != nil {
+		return CheckFail, fmt.Sprintf("could not make a verified TLS connection to %s: %s", d.conf.Domain, err)
+	}
+	defer conn.Close()
+
+	leaf := conn.ConnectionState().PeerCertificates[0]
+	expiry := leaf.NotAfter.UTC().Format(time.RFC3339)
+	if leaf.NotAfter.Sub(d.now()) < tlsExpiryWarning {
+		return CheckWarn, fmt.Sprintf("the certificate of %s expires soon, on %s", d.conf.Domain, expiry)
+	}
+	return CheckPass, fmt.Sprintf("the certificate of %s is valid until %s", d.conf.Domain, expiry)
+}
+
+func (d *doctor) checkConcourseAPI() (string, string) {
+	flyClient, err := d.client.flyClientFactory(d.client.provider, fly.Credentials{
+		Target:   d.conf.GetDeployment(),
+		API:      fmt.Sprintf("https://%s", d.conf.GetDomain()),
+		Username: d.conf.GetConcourseUsername(),
+		Password: d.conf.GetConcoursePassword(),
+	},
+		io.Discard,
+		d.client.stderr,
+		d.client.versionFile,
+	)
+	if err != nil {
+		return CheckFail, err.Error()
+	}
+	defer flyClient.Cleanup()
+
+	connected, err := flyClient.CanConnect()
+	if err != nil {
+		return CheckFail, fmt.Sprintf("could not log in to Concourse: %s", err)
+	}
+	if !connected {
+		return CheckFail, fmt.Sprintf("could not reach Concourse at https://%s", d.conf.GetDomain())
+	}
+	return CheckPass, "logged in to Concourse"
+}
+
+// bosh returns a BOSH client, building it the first time it is needed
+func (d *doctor) bosh() (bosh.IClient, error) {
+	if d.boshClient == nil {
+		boshClient, err := d.client.buildBoshClient(d.conf, d.tfOutputs)
+		if err != nil {
+			return nil, err
+		}
+		d.boshClient = boshClient
+	}
+	return d.boshClient, nil
+}
+
+func (d *doctor) checkBOSHInstances() (string, string) {
+	boshClient, err := d.bosh()
+	if err != nil {
+		return CheckFail, err.Error()
+	}
+	instances, err := boshClient.Instances()
+	if err != nil {
+		return CheckFail, fmt.Sprintf("could not list the BOSH instances: %s", err)
+	}
+	if len(instances) == 0 {
+		return CheckFail, "there are no BOSH instances"
+	}
+
+	var notRunning []string
+	for _, instance := range instances {
+		if instance.State != "running" {
+			notRunning = append(notRunning, fmt.Sprintf("%s is %s", instance.Name, instance.State))
+		}
+	}
+	if len(notRunning) > 0 {
+		return CheckFail, strings.Join(notRunning, ", ")
+	}
+	return CheckPass, fmt.Sprintf("all %d instances are running", len(instances))
+}
+
+// boshLock is a row of `bosh locks --json`
+type boshLock struct {
+	Type      string `json:"type"`
+	Resource  string `json:"resource"`
+	TaskID    string `json:"task_id"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+func (d *doctor) checkBOSHLocks() (string, string) {
+	boshClient, err := d.bosh()
+	if err != nil {
+		return CheckFail, err.Error()
+	}
+	lockBytes, err := boshClient.Locks()
+	if err != nil {
+		return CheckFail, fmt.Sprintf("could not list the BOSH locks: %s", err)
+	}
+
+	var tables struct {
+		Tables []struct {
+			Content string
+			Rows    []boshLock
+		}
+	}
+	if err = json.Unmarshal(lockBytes, &tables); err != nil {
+		return CheckFail, fmt.Sprintf("could not parse the BOSH locks: %s", err)
+	}
+
+	var stale, held []string
+	for _, table := range tables.Tables {
+		if table.Content != "locks" {
+			continue
+		}
+		for _, lock := range table.Rows {
+			description := fmt.Sprintf("%s %s held by task %s", lock.Type, lock.Resource, lock.TaskID)
+			expiresAt, err := time.Parse(time.UnixDate, lock.ExpiresAt)
+			if err == nil && expiresAt.Before(d.now()) {
+				stale = append(stale, fmt.Sprintf("%s expired at %s", description, expiresAt.UTC().Format(time.RFC3339)))
+				continue
+			}
+			held = append(held, description)
+		}
+	}
+	switch {
+	case len(stale) > 0:
+		return CheckFail, "stale locks: " + strings.Join(stale, ", ")
+	case len(held) > 0:
+		return CheckWarn, "tasks in progress: " + strings.Join(held, ", ")
+	default:
+		return CheckPass, "no locks are held"
+	}
+}
+
+func writeCheckResults(w io.Writer, results []CheckResult) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Name, result.Status, result.Detail)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,241 @@
+package concourse
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/bosh/boshfakes"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/fly"
+	"github.com/EngineerBetter/control-tower/fly/flyfakes"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/iaas/iaasfakes"
+	"github.com/EngineerBetter/control-tower/terraform/terraformfakes"
+)
+
+type doctorFixture struct {
+	conf       config.Config
+	tfOutputs  *terraformfakes.FakeOutputs
+	provider   *iaasfakes.FakeProvider
+	flyClient  *flyfakes.FakeIClient
+	boshClient *boshfakes.FakeIClient
+	server     *httptest.Server
+	stdout     *bytes.Buffer
+}
+
+func newDoctorFixture(t *testing.T) *doctorFixture {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	f := &doctorFixture{
+		conf: config.Config{
+			Deployment:      "control-tower-foo",
+			Domain:          "ci.example.com",
+			ConcourseCACert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+		},
+		tfOutputs:  &terraformfakes.FakeOutputs{},
+		provider:   &iaasfakes.FakeProvider{},
+		flyClient:  &flyfakes.FakeIClient{},
+		boshClient: &boshfakes.FakeIClient{},
+		server:     server,
+		stdout:     new(bytes.Buffer),
+	}
+	f.tfOutputs.GetStub = func(key string) (string, error) {
+		return map[string]string{"ATCPublicIP": "99.99.99.99", "DirectorSecurityGroupID": "sg-123"}[key], nil
+	}
+	f.provider.CheckForWhitelistedIPReturns(true, nil)
+	f.flyClient.CanConnectReturns(true, nil)
+	f.boshClient.InstancesReturns([]bosh.Instance{{Name: "web/0", State: "running"}, {Name: "worker/0", State: "running"}}, nil)
+	f.boshClient.LocksReturns([]byte(`{"Tables":[{"Content":"locks","Rows":[]}]}`), nil)
+	return f
+}
+
+func (f *doctorFixture) doctor() *doctor {
+	client := newDeploymentClient(f.conf, f.tfOutputs, f.boshClient)
+	client.provider = f.provider
+	client.ipChecker = func() (string, error) { return "1.2.3.4", nil }
+	client.flyClientFactory = func(iaas.Provider, fly.Credentials, io.Writer, io.Writer, []byte) (fly.IClient, error) {
+		return f.flyClient, nil
+	}
+	client.stdout = f.stdout
+	return &doctor{
+		client: client,
+		lookupHost: func(host string) ([]string, error) {
+			if host == "ci.example.com" {
+				return []string{"99.99.99.99"}, nil
+			}
+			return nil, fmt.Errorf("no such host %s", host)
+		},
+		dialTLS: func(domain string, config *tls.Config) (*tls.Conn, error) {
+			config.ServerName = "example.com"
+			return tls.Dial("tcp", f.server.Listener.Addr().String(), config)
+		},
+		now: func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}
+}
+
+func resultsByName(results []CheckResult) map[string]CheckResult {
+	byName := map[string]CheckResult{}
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+	return byName
+}
+
+func TestDoctor_AllChecksPass(t *testing.T) {
+	f := newDoctorFixture(t)
+	d := f.doctor()
+
+	results := d.run()
+	if len(results) != 8 {
+		t.Fatalf("doctor.run() ran %d checks, want 8", len(results))
+	}
+	for _, result := range results {
+		if result.Status != CheckPass {
+			t.Errorf("check %s = %s (%s), want %s", result.Name, result.Status, result.Detail, CheckPass)
+		}
+	}
+	if f.tfOutputs.AssertValidCallCount() != 1 {
+		t.Errorf("doctor.run() did not validate the terraform outputs")
+	}
+}
+
+func TestDoctor_Checks(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*doctorFixture)
+		check      string
+		wantStatus string
+		wantDetail string
+	}{
+		{
+			name: "the terraform outputs are invalid",
+			setup: func(f *doctorFixture) {
+				f.tfOutputs.AssertValidReturns(errors.New("ATCPublicIP: non zero value required"))
+			},
+			check:      checkTerraformOutputs,
+			wantStatus: CheckFail,
+			wantDetail: "the terraform outputs are invalid: ATCPublicIP: non zero value required",
+		},
+		{
+			name: "checks which need the terraform outputs are not run when they are invalid",
+			setup: func(f *doctorFixture) {
+				f.tfOutputs.AssertValidReturns(errors.New("ATCPublicIP: non zero value required"))
+			},
+			check:      checkBOSHLocks,
+			wantStatus: CheckFail,
+			wantDetail: "not checked as ip-allowed failed",
+		},
+		{
+			name: "the user IP is not allowed",
+			setup: func(f *doctorFixture) {
+				f.provider.CheckForWhitelistedIPReturns(false, nil)
+			},
+			check:      checkIPAllowed,
+			wantStatus: CheckFail,
+			wantDetail: "your IP 1.2.3.4 is not allowed by sg-123",
+		},
+		{
+			name: "the domain resolves elsewhere",
+			setup: func(f *doctorFixture) {
+				f.conf.Domain = "old.example.com"
+			},
+			check:      checkDNS,
+			wantStatus: CheckFail,
+			wantDetail: "could not resolve old.example.com",
+		},
+		{
+			name: "no domain is set",
+			setup: func(f *doctorFixture) {
+				f.conf.Domain = "99.99.99.99"
+			},
+			check:      checkDNS,
+			wantStatus: CheckPass,
+			wantDetail: "no domain is set",
+		},
+		{
+			name: "the certificate is not trusted",
+			setup: func(f *doctorFixture) {
+				f.conf.ConcourseCACert = ""
+			},
+			check:      checkTLS,
+			wantStatus: CheckFail,
+			wantDetail: "could not make a verified TLS connection",
+		},
+		{
+			name: "the Concourse API can't be reached",
+			setup: func(f *doctorFixture) {
+				f.flyClient.CanConnectReturns(false, nil)
+			},
+			check:      checkConcourseAPI,
+			wantStatus: CheckFail,
+			wantDetail: "could not reach Concourse at https://ci.example.com",
+		},
+		{
+			name: "an instance is not running",
+			setup: func(f *doctorFixture) {
+				f.boshClient.InstancesReturns([]bosh.Instance{{Name: "web/0", State: "running"}, {Name: "worker/0", State: "failing"}}, nil)
+			},
+			check:      checkBOSHInstances,
+			wantStatus: CheckFail,
+			wantDetail: "worker/0 is failing",
+		},
+		{
+			name: "a task holds a lock",
+			setup: func(f *doctorFixture) {
+				f.boshClient.LocksReturns([]byte(`{"Tables":[{"Content":"locks","Rows":[{"type":"deployment","resource":"concourse","task_id":"42","expires_at":"Wed Jan  1 00:01:00 UTC 2020"}]}]}`), nil)
+			},
+			check:      checkBOSHLocks,
+			wantStatus: CheckWarn,
+			wantDetail: "tasks in progress: deployment concourse held by task 42",
+		},
+		{
+			name: "a lock has expired",
+			setup: func(f *doctorFixture) {
+				f.boshClient.LocksReturns([]byte(`{"Tables":[{"Content":"locks","Rows":[{"type":"deployment","resource":"concourse","task_id":"42","expires_at":"Tue Dec 31 23:00:00 UTC 2019"}]}]}`), nil)
+			},
+			check:      checkBOSHLocks,
+			wantStatus: CheckFail,
+			wantDetail: "stale locks: deployment concourse held by task 42 expired at 2019-12-31T23:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newDoctorFixture(t)
+			tt.setup(f)
+
+			result := resultsByName(f.doctor().run())[tt.check]
+			if result.Status != tt.wantStatus || !strings.Contains(result.Detail, tt.wantDetail) {
+				t.Errorf("check %s = %s (%s), want %s (%s)", tt.check, result.Status, result.Detail, tt.wantStatus, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestWriteCheckResults(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeCheckResults(&buf, []CheckResult{
+		{Name: checkConfigBucket, Status: CheckPass, Detail: "config loaded"},
+		{Name: checkBOSHLocks, Status: CheckWarn, Detail: "tasks in progress"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "config-bucket  pass  config loaded\nbosh-locks     warn  tasks in progress\n"
+	if buf.String() != want {
+		t.Errorf("writeCheckResults() wrote %q, want %q", buf.String(), want)
+	}
+}
@@ -5,32 +5,32 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
-	"regexp"
 	"strings"
 	"text/template"
 
 	"github.com/EngineerBetter/control-tower/iaas"
 
 	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/config"
-	"github.com/EngineerBetter/control-tower/util/yaml"
 	"github.com/fatih/color"
 )
 
 // Info represents the compound fields for info templates
 type Info struct {
-	Terraform   TerraformInfo   `json:"terraform"`
-	Config      config.Config   `json:"config"`
-	Instances   []bosh.Instance `json:"instances"`
-	CertExpiry  string          `json:"cert_expiry"`
-	GatewayUser string
+	Terraform    TerraformInfo       `json:"terraform"`
+	Config       config.Config       `json:"config"`
+	Instances    []bosh.Instance     `json:"instances"`
+	CertExpiry   string              `json:"cert_expiry"`
+	Certificates []certs.Certificate `json:"certificates"`
+	GatewayUser  string
 }
 
 // TerraformInfo represents the terraform output fields needed for the info templates
 type TerraformInfo struct {
 	DirectorPublicIP string
 	NatGatewayIP     string
+	ATCPublicIP      string
 }
 
 // FetchInfo fetches and builds the info
@@ -46,28 +46,9 @@ func (client *Client) FetchInfo() (*Info, error) {
 		return nil, err
 	}
 
-	var certExpiry string
-	if len(directorCredsBytes) > 0 {
-		natsCA, err1 := yaml.Path(directorCredsBytes, "nats_server_tls/ca")
-		if err1 != nil {
-			return nil, err1
-		}
-
-		var re = regexp.MustCompile(`\n\s*`)
-
-		openSSL := exec.Command("openssl", "x509", "-noout", "-dates")
-		openSSL.Stdin = strings.NewReader(re.ReplaceAllString(natsCA, "\n"))
-		var out bytes.Buffer
-		openSSL.Stdout = &out
-		err1 = openSSL.Run()
-		if err1 != nil {
-			return nil, err1
-		}
-		if strings.Contains(out.String(), "notAfter=") {
-			certExpiry = strings.Split(out.String(), "notAfter=")[1]
-		} else {
-			return nil, fmt.Errorf("openssl output is not as expected. got: %s", out.String())
-		}
+	certificates, err := inspectCertificates(conf, directorCredsBytes)
+	if err != nil {
+		return nil, err
 	}
 
 	tfInputVars := client.tfInputVarsFactory.NewInputVars(conf)
@@ -94,9 +75,15 @@ func (client *Client) FetchInfo() (*Info, error) {
 		return nil, err
 	}
 
+	atcPublicIP, err := tfOutputs.Get("ATCPublicIP")
+	if err != nil {
+		return nil, err
+	}
+
 	terraformInfo := TerraformInfo{
 		DirectorPublicIP: directorPublicIP,
 		NatGatewayIP:     natGatewayIP,
+		ATCPublicIP:      atcPublicIP,
 	}
 
 	userIP, err1 := client.ipChecker()
@@ -130,11 +117,12 @@ func (client *Client) FetchInfo() (*Info, error) {
 	}
 
 	return &Info{
-		Terraform:   terraformInfo,
-		Config:      conf,
-		Instances:   instances,
-		GatewayUser: gatewayUser,
-		CertExpiry:  certExpiry,
+		Terraform:    terraformInfo,
+		Config:       conf,
+		Instances:    instances,
+		GatewayUser:  gatewayUser,
+		CertExpiry:   formatCertExpiry(certificates, natsCAName),
+		Certificates: certificates,
 	}, nil
 }
 
@@ -184,6 +172,13 @@ Uses Control-Tower version {{.Config.Version}}
 Built by {{"EngineerBetter http://engineerbetter.com" | blue}}
 `
 
+// Redacted returns a copy of the info with all secrets in the config redacted
+func (info *Info) Redacted() *Info {
+	redacted := *info
+	redacted.Config = config.Redact(info.Config)
+	return &redacted
+}
+
 func (info *Info) String() string {
 	t := template.Must(template.New("info").Funcs(template.FuncMap{
 		"replace": func(old, new, s string) string {
@@ -0,0 +1,234 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/EngineerBetter/control-tower/certs"
+)
+
+// InfoSchemaVersion is the version of InfoOutput. It is increased whenever a field is removed
+// or changes meaning, but not when one is added.
+const InfoSchemaVersion = 1
+
+// InfoOutput is the stable schema of info --format and --template, documented in docs/info.md
+type InfoOutput struct {
+	SchemaVersion int                 `json:"schema_version"`
+	Deployment    DeploymentOutput    `json:"deployment"`
+	Concourse     ConcourseOutput     `json:"concourse"`
+	Workers       WorkersOutput       `json:"workers"`
+	CredHub       CredHubOutput       `json:"credhub"`
+	Director      DirectorOutput      `json:"director"`
+	Grafana       GrafanaOutput       `json:"grafana"`
+	Instances     []InstanceOutput    `json:"instances"`
+	Certificates  []certs.Certificate `json:"certificates"`
+}
+
+// DeploymentOutput describes the deployment as a whole
+type DeploymentOutput struct {
+	Name                string `json:"name"`
+	Deployment          string `json:"deployment"`
+	Namespace           string `json:"namespace"`
+	IAAS                string `json:"iaas"`
+	Region              string `json:"region"`
+	ControlTowerVersion string `json:"control_tower_version"`
+}
+
+// ConcourseOutput is how to reach and log in to Concourse
+type ConcourseOutput struct {
+	URL         string `json:"url"`
+	ATCPublicIP string `json:"atc_public_ip"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	CACert      string `json:"ca_cert"`
+}
+
+// WorkersOutput describes the Concourse workers
+type WorkersOutput struct {
+	Count            int    `json:"count"`
+	Size             string `json:"size"`
+	OutboundPublicIP string `json:"outbound_public_ip"`
+}
+
+// CredHubOutput is how to reach and log in to CredHub
+type CredHubOutput struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	CACert   string `json:"ca_cert"`
+}
+
+// DirectorOutput is how to reach and log in to the BOSH director
+type DirectorOutput struct {
+	IP       string `json:"ip"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	CACert   string `json:"ca_cert"`
+}
+
+// GrafanaOutput is how to reach Grafana, which is empty if metrics are disabled, and the metrics backend it reads from
+type GrafanaOutput struct {
+	URL     string `json:"url"`
+	Backend string `json:"backend"`
+}
+
+// InstanceOutput is a BOSH instance
+type InstanceOutput struct {
+	Name  string `json:"name"`
+	IP    string `json:"ip"`
+	State string `json:"state"`
+}
+
+// Output returns the info in the stable schema
+func (info *Info) Output() InfoOutput {
+	conf := info.Config
+	output := InfoOutput{
+		SchemaVersion: InfoSchemaVersion,
+		Deployment: DeploymentOutput{
+			Name:                conf.Project,
+			Deployment:          conf.Deployment,
+			Namespace:           conf.Namespace,
+			IAAS:                conf.IAAS,
+			Region:              conf.Region,
+			ControlTowerVersion: conf.Version,
+		},
+		Concourse: ConcourseOutput{
+			URL:         fmt.Sprintf("https://%s", conf.Domain),
+			ATCPublicIP: info.Terraform.ATCPublicIP,
+			Username:    conf.ConcourseUsername,
+			Password:    conf.ConcoursePassword,
+			CACert:      conf.ConcourseCACert,
+		},
+		Workers: WorkersOutput{
+			Count:            conf.ConcourseWorkerCount,
+			Size:             conf.ConcourseWorkerSize,
+			OutboundPublicIP: info.Terraform.NatGatewayIP,
+		},
+		CredHub: CredHubOutput{
+			URL:      conf.CredhubURL,
+			Username: conf.CredhubUsername,
+			Password: conf.CredhubPassword,
+			CACert:   conf.CredhubCACert,
+		},
+		Director: DirectorOutput{
+			IP:       info.Terraform.DirectorPublicIP,
+			Username: conf.DirectorUsername,
+			Password: conf.DirectorPassword,
+			CACert:   conf.DirectorCACert,
+		},
+		Instances:    []InstanceOutput{},
+		Certificates: info.Certificates,
+	}
+	output.Grafana.Backend = conf.MetricsBackend
+	if !conf.MetricsIsDisabled() {
+		output.Grafana.URL = fmt.Sprintf("https://%s:3000", conf.Domain)
+	}
+	for _, instance := range info.Instances {
+		output.Instances = append(output.Instances, InstanceOutput{Name: instance.Name, IP: instance.IP, State: instance.State})
+	}
+	if output.Certificates == nil {
+		output.Certificates = []certs.Certificate{}
+	}
+	return output
+}
+
+// outputValues returns the schema as it is encoded, keyed by the names in the json and yaml output
+func (info *Info) outputValues() (map[string]interface{}, error) {
+	b, err := json.Marshal(info.Output())
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	err = json.Unmarshal(b, &values)
+	return values, err
+}
+
+// WriteFormat writes the info in the stable schema as json, yaml or a table of fields and values
+func (info *Info) WriteFormat(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info.Output())
+	case "yaml":
+		out, err := yaml.Marshal(info.Output())
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	case "table":
+		values, err := info.outputValues()
+		if err != nil {
+			return err
+		}
+		rows := map[string]string{}
+		flatten("", values, rows)
+		var fields []string
+		for field := range rows {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "Field\tValue")
+		for _, field := range fields {
+			fmt.Fprintf(tw, "%s\t%s\n", field, rows[field])
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown format [%s]", format)
+	}
+}
+
+// flatten adds a row for each value, named by its path through the schema, e.g. concourse.url or instances[0].ip.
+// Newlines in values are escaped so that each value fits on one row.
+func flatten(path string, value interface{}, rows map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if path == "" {
+				flatten(key, child, rows)
+			} else {
+				flatten(path+"."+key, child, rows)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			flatten(fmt.Sprintf("%s[%d]", path, i), child, rows)
+		}
+	case nil:
+		rows[path] = ""
+	default:
+		rows[path] = strings.TrimSuffix(strings.Replace(fmt.Sprint(v), "\n", `\n`, -1), `\n`)
+	}
+}
+
+// ExecuteTemplate executes a Go template against the info in the stable schema. Fields are named
+// as in the json and yaml output, e.g. {{.concourse.atc_public_ip}}, and naming one which
+// doesn't exist is an error.
+func (info *Info) ExecuteTemplate(w io.Writer, text string) error {
+	t, err := template.New("info").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse --template: [%v]", err)
+	}
+	values, err := info.outputValues()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, values); err != nil {
+		return fmt.Errorf("failed to execute --template: [%v]", err)
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
@@ -1,10 +1,14 @@
 package concourse
 
 import (
+	"bytes"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/certs"
 	"github.com/EngineerBetter/control-tower/config"
 )
 
@@ -66,3 +70,161 @@ func TestInfo_String(t *testing.T) {
 		})
 	}
 }
+
+func TestInfo_Redacted(t *testing.T) {
+	info := &Info{
+		Config: config.Config{
+			ConcourseUsername: "admin",
+			ConcoursePassword: "s3cret",
+			RDSPassword:       "s3cret",
+			CredhubPassword:   "s3cret",
+			DirectorPassword:  "s3cret",
+		},
+		CertExpiry: "2019-02-01",
+	}
+
+	redacted := info.Redacted()
+	if strings.Contains(redacted.String(), "s3cret") {
+		t.Errorf("Info.Redacted().String() includes secrets, got %v", redacted.String())
+	}
+	if redacted.Config.ConcoursePassword != config.RedactedValue || redacted.Config.RDSPassword != config.RedactedValue {
+		t.Errorf("Info.Redacted() did not redact secrets, got %+v", redacted.Config)
+	}
+	if redacted.Config.ConcourseUsername != "admin" || redacted.CertExpiry != "2019-02-01" {
+		t.Errorf("Info.Redacted() modified non-secret fields, got %+v", redacted)
+	}
+	if info.Config.ConcoursePassword != "s3cret" {
+		t.Errorf("Info.Redacted() modified the original info")
+	}
+}
+
+func TestInfo_WriteCertificates(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	info := &Info{
+		Certificates: []certs.Certificate{
+			{Name: "concourse_cert", Source: "config.json", Subject: "CN=ci.example.com", SANs: []string{"ci.example.com"}, NotAfter: now.AddDate(0, 0, 90)},
+			{Name: "nats_server_tls/ca", Source: "director-creds.yml", Subject: "CN=default.nats-ca.bosh-internal", NotAfter: now.AddDate(0, 0, 20)},
+		},
+	}
+	tests := []struct {
+		name         string
+		warningDays  int
+		criticalDays int
+		want         string
+	}{
+		{name: "no thresholds", warningDays: -1, criticalDays: -1, want: CertificateOK},
+		{name: "within the warning threshold", warningDays: 30, criticalDays: 7, want: CertificateWarning},
+		{name: "within the critical threshold", warningDays: 120, criticalDays: 30, want: CertificateCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			got, err := info.WriteCertificates(&buf, now, tt.warningDays, tt.criticalDays)
+			if err != nil {
+				t.Fatalf("Info.WriteCertificates() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Info.WriteCertificates() = %v, want %v", got, tt.want)
+			}
+			if !strings.Contains(buf.String(), "nats_server_tls/ca") || !strings.Contains(buf.String(), "ci.example.com") {
+				t.Errorf("Info.WriteCertificates() wrote %v, want each certificate", buf.String())
+			}
+		})
+	}
+}
+
+func TestInspectCertificates(t *testing.T) {
+	directorCreds, err := os.ReadFile("fixtures/director-creds.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificates, err := inspectCertificates(config.Config{}, directorCreds)
+	if err != nil {
+		t.Fatalf("inspectCertificates() error = %v", err)
+	}
+	var names []string
+	for _, cert := range certificates {
+		names = append(names, cert.Name)
+	}
+	for _, want := range []string{"default_ca/certificate", "nats_server_tls/certificate", "nats_server_tls/ca"} {
+		found := false
+		for _, name := range names {
+			found = found || name == want
+		}
+		if !found {
+			t.Errorf("inspectCertificates() = %v, want %v", names, want)
+		}
+	}
+	if formatCertExpiry(certificates, natsCAName) == "" {
+		t.Errorf("formatCertExpiry() found no expiry for %s", natsCAName)
+	}
+}
+
+func TestInfo_WriteFormat(t *testing.T) {
+	info := &Info{
+		Terraform: TerraformInfo{DirectorPublicIP: "4.3.2.1", NatGatewayIP: "1.2.3.4", ATCPublicIP: "9.9.9.9"},
+		Config: config.Config{
+			Project:        "foo",
+			Deployment:     "control-tower-foo",
+			Domain:         "ci.example.com",
+			CredhubURL:     "https://ci.example.com:8844",
+			MetricsBackend: config.MetricsBackendNone,
+		},
+		Instances: []bosh.Instance{{Name: "web/0", IP: "10.0.0.6", State: "running"}},
+	}
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{format: "json", want: []string{`"schema_version": 1`, `"name": "foo"`, `"deployment": "control-tower-foo"`, `"atc_public_ip": "9.9.9.9"`, `"url": ""`, `"backend": "none"`}},
+		{format: "yaml", want: []string{"schema_version: 1", "name: foo", "deployment: control-tower-foo", "atc_public_ip: 9.9.9.9", "url: https://ci.example.com:8844"}},
+		{format: "table", want: []string{"concourse.atc_public_ip", "instances[0].name", "schema_version"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := info.WriteFormat(&buf, tt.format); err != nil {
+				t.Fatalf("Info.WriteFormat() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Info.WriteFormat() = %v, want %v", buf.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestInfo_ExecuteTemplate(t *testing.T) {
+	info := &Info{
+		Terraform: TerraformInfo{ATCPublicIP: "9.9.9.9"},
+		Config:    config.Config{CredhubURL: "https://ci.example.com:8844"},
+	}
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  string
+	}{
+		{name: "single value", template: "{{.concourse.atc_public_ip}}", want: "9.9.9.9"},
+		{name: "several values", template: "{{.credhub.url}} v{{.schema_version}}", want: "https://ci.example.com:8844 v1"},
+		{name: "unknown field", template: "{{.concourse.ip}}", wantErr: "failed to execute --template"},
+		{name: "invalid template", template: "{{.concourse", wantErr: "failed to parse --template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := info.ExecuteTemplate(&buf, tt.template)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Info.ExecuteTemplate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || buf.String() != tt.want {
+				t.Errorf("Info.ExecuteTemplate() = %q, %v, want %q", buf.String(), err, tt.want)
+			}
+		})
+	}
+}
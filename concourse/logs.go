@@ -0,0 +1,146 @@
+package concourse
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/commands/logs"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/terraform"
+)
+
+// bundleLogsDir is the directory of a diagnostic bundle which holds the tarballs from bosh logs
+const bundleLogsDir = "bosh-logs"
+
+// Logs collects a diagnostic bundle to attach to support issues. It holds the job logs of the instance
+// groups in l, the output of `bosh instances --ps`, `bosh tasks --recent` and `bosh locks`, and the
+// terraform outputs and config.json with their secrets redacted, in one gzipped tarball.
+func (client *Client) Logs(l logs.Args) error {
+	return client.collectLogs(l, time.Now())
+}
+
+func (client *Client) collectLogs(l logs.Args, now time.Time) error {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	logsDir, err := os.MkdirTemp("", "control-tower-logs")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(logsDir)
+
+	if err = boshClient.FetchLogs(l.Groups(), logsDir); err != nil {
+		return err
+	}
+
+	files, err := boshClient.Diagnostics()
+	if err != nil {
+		return err
+	}
+	if files["config.json"], err = json.MarshalIndent(config.Redact(conf), "", "  "); err != nil {
+		return err
+	}
+	if files["terraform-outputs.json"], err = json.MarshalIndent(terraform.Redact(tfOutputs), "", "  "); err != nil {
+		return err
+	}
+
+	bundleName := fmt.Sprintf("%s-logs-%s", conf.GetDeployment(), now.UTC().Format("20060102T150405Z"))
+	bundlePath := l.To
+	if !l.ToIsSet {
+		bundlePath = bundleName + ".tgz"
+	}
+	if err = writeBundle(bundlePath, bundleName, files, logsDir); err != nil {
+		os.Remove(bundlePath)
+		return fmt.Errorf("failed to write the diagnostic bundle: [%v]", err)
+	}
+
+	_, err = fmt.Fprintf(client.stdout, "Wrote diagnostic bundle for deployment %s to %s\n", conf.GetDeployment(), bundlePath)
+	return err
+}
+
+// writeBundle writes files and the files in logsDir, under bosh-logs, to a gzipped tarball at bundlePath.
+// Every entry is in a top level directory called bundleName.
+func writeBundle(bundlePath, bundleName string, files map[string][]byte, logsDir string) error {
+	f, err := os.OpenFile(bundlePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err = writeBundleEntry(tw, path.Join(bundleName, name), int64(len(files[name])), bytes.NewReader(files[name])); err != nil {
+			return err
+		}
+	}
+
+	logFiles, err := os.ReadDir(logsDir)
+	if err != nil {
+		return err
+	}
+	for _, logFile := range logFiles {
+		if logFile.IsDir() {
+			continue
+		}
+		if err = writeBundleFile(tw, path.Join(bundleName, bundleLogsDir, logFile.Name()), filepath.Join(logsDir, logFile.Name())); err != nil {
+			return err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	if err = gw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func writeBundleFile(tw *tar.Writer, name, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return writeBundleEntry(tw, name, info.Size(), f)
+}
+
+func writeBundleEntry(tw *tar.Writer, name string, size int64, contents io.Reader) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0600,
+		Size: size,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, contents)
+	return err
+}
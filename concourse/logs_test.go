@@ -0,0 +1,107 @@
+package concourse
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh/boshfakes"
+	"github.com/EngineerBetter/control-tower/commands/logs"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/terraform"
+)
+
+func readBundle(t *testing.T, bundlePath string) map[string]string {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+
+	entries := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[header.Name] = string(contents)
+	}
+}
+
+func TestCollectLogs(t *testing.T) {
+	boshClient := &boshfakes.FakeIClient{}
+	var groups []string
+	boshClient.FetchLogsStub = func(instanceGroups []string, dir string) error {
+		groups = instanceGroups
+		return os.WriteFile(filepath.Join(dir, "concourse.web.20200101-000000.tgz"), []byte("web logs"), 0600)
+	}
+	boshClient.DiagnosticsReturns(map[string][]byte{"bosh-locks.txt": []byte("no locks")}, nil)
+
+	var stdout bytes.Buffer
+	conf := config.Config{Deployment: "control-tower-foo", DirectorPassword: "s3cret"}
+	client := newDeploymentClient(conf, &terraform.AWSOutputs{
+		DirectorPublicIP:    terraform.MetadataStringValue{Value: "1.2.3.4"},
+		BoshSecretAccessKey: terraform.MetadataStringValue{Value: "s3cret"},
+	}, boshClient)
+	client.stdout = &stdout
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tgz")
+	err := client.collectLogs(logs.Args{InstanceGroups: "web,worker", To: bundlePath, ToIsSet: true}, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(groups, []string{"web", "worker"}) {
+		t.Errorf("collectLogs() fetched the logs of %v, want [web worker]", groups)
+	}
+	if boshClient.CleanupCallCount() != 1 {
+		t.Errorf("collectLogs() did not clean up the BOSH client")
+	}
+
+	entries := readBundle(t, bundlePath)
+	prefix := "control-tower-foo-logs-20200101T000000Z/"
+	var names []string
+	for name := range entries {
+		names = append(names, name)
+	}
+	for _, name := range []string{"config.json", "terraform-outputs.json", "bosh-locks.txt", "bosh-logs/concourse.web.20200101-000000.tgz"} {
+		if _, ok := entries[prefix+name]; !ok {
+			t.Errorf("bundle has %v, want %s", names, prefix+name)
+		}
+	}
+	if entries[prefix+"bosh-logs/concourse.web.20200101-000000.tgz"] != "web logs" {
+		t.Errorf("bundle has web logs %q, want %q", entries[prefix+"bosh-logs/concourse.web.20200101-000000.tgz"], "web logs")
+	}
+	for name, contents := range entries {
+		if strings.Contains(contents, "s3cret") {
+			t.Errorf("bundle entry %s contains a secret: %s", name, contents)
+		}
+	}
+	if !strings.Contains(entries[prefix+"terraform-outputs.json"], `"director_public_ip": "1.2.3.4"`) {
+		t.Errorf("bundle has terraform outputs %s, want the director IP", entries[prefix+"terraform-outputs.json"])
+	}
+
+	want := "Wrote diagnostic bundle for deployment control-tower-foo to " + bundlePath + "\n"
+	if stdout.String() != want {
+		t.Errorf("collectLogs() wrote %q, want %q", stdout.String(), want)
+	}
+}
@@ -11,17 +11,17 @@ import (
 
 	"github.com/EngineerBetter/control-tower/bosh"
 	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/events"
 )
 
-type tasks struct {
-	description string
-	operation   string
-	action      func(string, string) error
-}
-
 // Maintenance is a struct representing values used by the maintenance command
 type Maintenance struct {
-	StatusIndex int `json:"status_index"`
+	Procedures           map[string]*ProcedureRun `json:"procedures,omitempty"`
+	CredentialsRotatedAt map[string]time.Time     `json:"credentials_rotated_at,omitempty"`
+
+	// StatusIndex is the progress of a NATS certificate renewal recorded by versions of control-tower from before
+	// procedures had named steps, which is converted into a procedure run when loaded so that it can be continued
+	StatusIndex *int `json:"status_index,omitempty"`
 }
 
 // Tables represents the output of bosh locks
@@ -37,59 +37,129 @@ type Table struct {
 
 const maintenanceFilename = "maintenance.json"
 
-// Maintain fetches and builds the info
-func (client *Client) Maintain(m maintain.Args) error {
-	switch {
-	case m.RenewNatsCertIsSet:
-		return client.renewCert(m)
-	}
-	return nil
-}
-
-func (client *Client) renewCert(m maintain.Args) error {
-
-	_ = client.waitForBOSHLocks(10 * time.Minute)
-
-	maintenance, err := client.retrieveStage()
-	if err != nil {
-		return err
-	}
+// natsCertRenewalSteps are the IDs of the steps of a NATS certificate renewal, in the order they run
+var natsCertRenewalSteps = []string{"add-new-ca", "recreate-vms-first", "remove-old-ca", "recreate-vms-second", "director-creds-cleanup"}
+
+// Names of the maintenance procedures, which are also the flags of maintain that run them
+const (
+	renewNatsCert       = "renew-nats-cert"
+	renewDirectorCerts  = "renew-director-certs"
+	rotateCredentials   = "rotate-credentials"
+	recreateWorkers     = "recreate-workers"
+	snapshotDB          = "snapshot-db"
+	restoreDB           = "restore-db"
+	rotateSSHKey        = "rotate-ssh-key"
+	rotateEncryptionKey = "rotate-encryption-key"
+	cleanupDirector     = "cleanup"
+)
 
-	var stageIndex int
-	if m.StageIsSet {
-		stageIndex = m.Stage
-	} else {
-		stageIndex, err = client.determineStage(maintenance)
-		if err != nil {
-			return err
-		}
-	}
+func init() {
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        renewNatsCert,
+			Description: "Rotate the NATS certificate on the director, recreating every VM twice",
+			Options:     []string{maintain.OptionStage},
+		},
+		resumable:    true,
+		waitForLocks: true,
+		steps:        (*Client).renewNatsCertSteps,
+	})
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        renewDirectorCerts,
+			Description: "Renew the director TLS certificate, default CA and mbus certificate",
+			Options:     []string{maintain.OptionStage},
+		},
+		resumable:    true,
+		waitForLocks: true,
+		steps:        (*Client).renewDirectorCertsSteps,
+	})
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        rotateCredentials,
+			Description: "Rotate the director, RDS, Concourse, Grafana and CredHub admin credentials",
+			Options:     []string{maintain.OptionCredentials},
+		},
+		resumable:    true,
+		waitForLocks: true,
+		steps:        (*Client).rotateCredentialsSteps,
+	})
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        recreateWorkers,
+			Description: "Recreate workers one at a time, landing each one and waiting for its containers to drain first",
+			Options:     []string{maintain.OptionDrainTimeout},
+		},
+		waitForLocks: true,
+		steps:        (*Client).recreateWorkersSteps,
+	})
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        snapshotDB,
+			Description: "Snapshot the RDS or Cloud SQL database, recording the snapshot ID in config.json",
+		},
+		steps: (*Client).snapshotDBSteps,
+	})
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        restoreDB,
+			Description: "Restore the RDS or Cloud SQL database from the snapshot with the given ID",
+			Argument:    "the ID of a snapshot",
+		},
+		resumable: true,
+		steps:     (*Client).restoreDBSteps,
+	})
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        rotateSSHKey,
+			Description: "Replace the SSH key pair of the director, recreating the director with the new one",
+		},
+		resumable:    true,
+		waitForLocks: true,
+		steps:        (*Client).rotateSSHKeySteps,
+	})
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        rotateEncryptionKey,
+			Description: "Rotate the key Concourse encrypts its database with, re-encrypting the data with the new key",
+			Options:     []string{maintain.OptionStage},
+		},
+		resumable:    true,
+		waitForLocks: true,
+		steps:        (*Client).rotateEncryptionKeySteps,
+	})
+	registerProcedure(procedure{
+		Procedure: maintain.Procedure{
+			Name:        cleanupDirector,
+			Description: "Delete the unused releases and stemcells and the orphaned disks kept by the director",
+			Options:     []string{maintain.OptionAll, maintain.OptionOrphanedDiskAge},
+		},
+		waitForLocks: true,
+		steps:        (*Client).cleanupDirectorSteps,
+	})
+}
 
-	tasks := []tasks{
-		{"Adding new CA", resource.AddNewCa, client.createEnv},
-		{"Recreating VMs for the first time", "first", client.recreate},
-		{"Removing old CA", resource.RemoveOldCa, client.createEnv},
-		{"Recreating VMs for the second time", "second", client.recreate},
-		{"Cleaning up director-creds.yml", "", client.cleanup},
+// Maintain runs the maintenance procedure chosen by the args, or reports on those already run
+func (client *Client) Maintain(m maintain.Args) error {
+	if m.StatusIsSet {
+		return client.maintenanceStatus()
 	}
 
-	if stageIndex >= len(tasks) {
-		return fmt.Errorf("Invalid stage index")
+	p, ok := procedureNamed(m.Procedure())
+	if !ok {
+		return nil
 	}
+	return client.runProcedure(p, m)
+}
 
-	for i := stageIndex; i < len(tasks); i++ {
-		fmt.Printf("current action: %s\n", tasks[i].description)
-		err1 := tasks[i].action(tasks[i].description, tasks[i].operation)
-		if err1 != nil {
-			return err1
-		}
-		err1 = client.updateStage(i, maintenance)
-		if err1 != nil {
-			return err1
-		}
-	}
-	err = client.updateStage(-1, maintenance)
-	return err
+func (client *Client) renewNatsCertSteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	return []step{
+		{natsCertRenewalSteps[0], "Adding new CA", events.PhaseAddNewCA, func() error { return client.createEnv(resource.AddNewCa) }, nil},
+		{natsCertRenewalSteps[1], "Recreating VMs for the first time", events.PhaseRecreateVMs, client.recreate, nil},
+		{natsCertRenewalSteps[2], "Removing old CA", events.PhaseRemoveOldCA, func() error { return client.createEnv(resource.RemoveOldCa) }, nil},
+		{natsCertRenewalSteps[3], "Recreating VMs for the second time", events.PhaseRecreateVMs, client.recreate, nil},
+		{natsCertRenewalSteps[4], "Cleaning up director-creds.yml", events.PhaseDirectorCleanup, client.cleanup, nil},
+	}, nil, nil
 }
 
 // constructBoshClient creates a boshClient for use in this package
@@ -144,7 +214,7 @@ func (client *Client) checkIfLocked() (bool, error) {
 func (client *Client) waitForBOSHLocks(waitTime time.Duration) error {
 	start := time.Now().UTC()
 	for {
-		fmt.Println("Waiting for BOSH lock to become available")
+		fmt.Fprintln(client.stdout, "Waiting for BOSH lock to become available")
 		locked, err := client.checkIfLocked()
 		if err != nil {
 			return err
@@ -153,14 +223,14 @@ func (client *Client) waitForBOSHLocks(waitTime time.Duration) error {
 			return nil
 		}
 		if time.Since(start) > waitTime {
-			return fmt.Errorf("BOSH lock failed to become available after %ds", waitTime/1000)
+			return fmt.Errorf("BOSH lock failed to become available after %s", waitTime)
 		}
 	}
 }
 
-// retrieveStage will retrieve the maintenance object from the config bucket
-// if the object is not found it will create one with statusIndex of -1
-func (client *Client) retrieveStage() (*Maintenance, error) {
+// retrieveMaintenance will retrieve the maintenance object from the config bucket,
+// returning an empty one if it isn't found
+func (client *Client) retrieveMaintenance() (*Maintenance, error) {
 	var maintenance Maintenance
 	fileExists, err := client.configClient.HasAsset(maintenanceFilename)
 	if err != nil {
@@ -175,29 +245,32 @@ func (client *Client) retrieveStage() (*Maintenance, error) {
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		maintenance = Maintenance{StatusIndex: -1}
 	}
+	if maintenance.Procedures == nil {
+		maintenance.Procedures = map[string]*ProcedureRun{}
+	}
+	if maintenance.StatusIndex != nil && *maintenance.StatusIndex >= 0 {
+		maintenance.Procedures[renewNatsCert] = legacyRun(natsCertRenewalSteps, *maintenance.StatusIndex)
+	}
+	maintenance.StatusIndex = nil
 	return &maintenance, nil
 }
 
-// determineStage returns the index of the next operation to be run
-func (client *Client) determineStage(maintenance *Maintenance) (int, error) {
-	return maintenance.StatusIndex + 1, nil
-}
-
-// updateStage stores the specified index in the maintenance object in the config bucket
-func (client *Client) updateStage(index int, maintenance *Maintenance) error {
-	maintenance.StatusIndex = index
-	maintenanceBytes, err := json.Marshal(maintenance)
-	if err != nil {
-		return err
+// legacyRun returns an interrupted run in which the steps up to and including lastCompleted succeeded
+func legacyRun(stepIDs []string, lastCompleted int) *ProcedureRun {
+	run := &ProcedureRun{Status: ProcedureFailed}
+	for i, id := range stepIDs {
+		status := StepPending
+		if i <= lastCompleted {
+			status = StepSucceeded
+		}
+		run.Steps = append(run.Steps, &StepRun{ID: id, Status: status})
 	}
-	return client.configClient.StoreAsset(maintenanceFilename, maintenanceBytes)
+	return run
 }
 
 // createEnv runs bosh create-env
-func (client *Client) createEnv(description, operation string) error {
+func (client *Client) createEnv(operation string) error {
 	boshClientPointer, err := client.constructBoshClient()
 	if err != nil {
 		return err
@@ -205,6 +278,11 @@ func (client *Client) createEnv(description, operation string) error {
 	boshClient := *boshClientPointer
 	defer boshClient.Cleanup()
 
+	return client.runCreateEnv(boshClient, operation)
+}
+
+// runCreateEnv runs bosh create-env with the stored director state and creds, storing the updated ones
+func (client *Client) runCreateEnv(boshClient bosh.IClient, operation string) error {
 	boshStateBytes, err := loadDirectorState(client.configClient)
 	if err != nil {
 		return err
@@ -230,7 +308,7 @@ func (client *Client) createEnv(description, operation string) error {
 }
 
 // recreate runs bosh recreate
-func (client *Client) recreate(description, operation string) error {
+func (client *Client) recreate() error {
 	boshClientPointer, err := client.constructBoshClient()
 	if err != nil {
 		return err
@@ -246,7 +324,7 @@ func (client *Client) recreate(description, operation string) error {
 }
 
 // cleanup cleans up the director-creds.yml file
-func (client *Client) cleanup(description, operation string) error {
+func (client *Client) cleanup() error {
 	directorCredsBytes, err := loadDirectorCreds(client.configClient)
 	if err != nil {
 		return err
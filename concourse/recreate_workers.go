@@ -0,0 +1,156 @@
+package concourse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/events"
+	"github.com/EngineerBetter/control-tower/fly"
+)
+
+const (
+	workerRejoinTimeout = 15 * time.Minute
+	workerPollInterval  = 10 * time.Second
+)
+
+// recreateWorkersSteps plans the recreation of the worker VMs one at a time. Each worker is landed and
+// left to finish its builds first, and the next one is only started once it has rejoined.
+func (client *Client) recreateWorkersSteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flyClient, err := client.flyClientFactory(client.provider, fly.Credentials{
+		Target:   conf.GetDeployment(),
+		API:      fmt.Sprintf("https://%s", conf.GetDomain()),
+		Username: conf.GetConcourseUsername(),
+		Password: conf.GetConcoursePassword(),
+	},
+		client.stdout,
+		client.stderr,
+		client.versionFile,
+	)
+	if err != nil {
+		boshClient.Cleanup()
+		return nil, nil, err
+	}
+	cleanup := func() {
+		flyClient.Cleanup()
+		boshClient.Cleanup()
+	}
+
+	instances, err := boshClient.Instances()
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	workerInstances := workerInstancesOf(instances)
+	if len(workerInstances) == 0 {
+		cleanup()
+		return nil, nil, fmt.Errorf("no worker instances found in the concourse deployment")
+	}
+
+	var steps []step
+	for i, instance := range workerInstances {
+		instance := instance
+		var workerName string
+		steps = append(steps,
+			step{
+				id:          fmt.Sprintf("%s:%s", events.PhaseLandWorker, instance.Name),
+				description: fmt.Sprintf("Landing worker %s (%d of %d)", instance.Name, i+1, len(workerInstances)),
+				phase:       events.PhaseLandWorker,
+				run: func() error {
+					var err error
+					if workerName, err = findWorkerName(flyClient, instance.Name); err != nil {
+						return err
+					}
+					return client.landWorker(flyClient, workerName, m.DrainTimeout)
+				},
+			},
+			step{
+				id:          fmt.Sprintf("%s:%s", events.PhaseRecreateWorker, instance.Name),
+				description: fmt.Sprintf("Recreating worker %s (%d of %d)", instance.Name, i+1, len(workerInstances)),
+				phase:       events.PhaseRecreateWorker,
+				run: func() error {
+					if err := boshClient.RecreateInstance(instance.Name); err != nil {
+						return err
+					}
+					fmt.Fprintf(client.stdout, "Waiting for worker %s to rejoin\n", workerName)
+					return client.waitForWorker(flyClient, workerName, workerRejoinTimeout, "running", func(worker fly.Worker) bool {
+						return worker.State == "running"
+					})
+				},
+			},
+		)
+	}
+
+	return steps, cleanup, nil
+}
+
+// landWorker lands a worker and waits until it has no running containers
+func (client *Client) landWorker(flyClient fly.IClient, name string, drainTimeout time.Duration) error {
+	fmt.Fprintf(client.stdout, "Landing worker %s\n", name)
+	if err := flyClient.LandWorker(name); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(client.stdout, "Waiting up to %s for worker %s to drain\n", drainTimeout, name)
+	return client.waitForWorker(flyClient, name, drainTimeout, "drained", func(worker fly.Worker) bool {
+		return worker.State == "landed" || worker.ActiveContainers == 0
+	})
+}
+
+// waitForWorker polls the workers registered with concourse until the named one is ready
+func (client *Client) waitForWorker(flyClient fly.IClient, name string, timeout time.Duration, description string, ready func(fly.Worker) bool) error {
+	start := time.Now().UTC()
+	for {
+		workers, err := flyClient.Workers()
+		if err != nil {
+			return err
+		}
+		for _, worker := range workers {
+			if worker.Name == name && ready(worker) {
+				return nil
+			}
+		}
+		if time.Since(start) > timeout {
+			return fmt.Errorf("worker %s was not %s after %s", name, description, timeout)
+		}
+		time.Sleep(workerPollInterval)
+	}
+}
+
+// findWorkerName returns the name concourse knows the worker on a BOSH instance by, which includes the instance ID
+func findWorkerName(flyClient fly.IClient, instance string) (string, error) {
+	workers, err := flyClient.Workers()
+	if err != nil {
+		return "", err
+	}
+
+	instanceID := instance[strings.Index(instance, "/")+1:]
+	for _, worker := range workers {
+		if strings.Contains(worker.Name, instanceID) {
+			return worker.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no concourse worker found for instance %s", instance)
+}
+
+func workerInstancesOf(instances []bosh.Instance) []bosh.Instance {
+	var workers []bosh.Instance
+	for _, instance := range instances {
+		if strings.HasPrefix(instance.Name, "worker/") {
+			workers = append(workers, instance)
+		}
+	}
+	return workers
+}
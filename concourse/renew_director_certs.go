@@ -0,0 +1,138 @@
+package concourse
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/events"
+	"github.com/EngineerBetter/control-tower/resource"
+)
+
+// renewedDirectorVars are the director vars store entries replaced by their _2 counterparts once renewed
+var renewedDirectorVars = []string{"default_ca", "mbus_bootstrap_ssl"}
+
+// directorCertRenewalSteps are the IDs of the steps of a director certificate renewal, in the order they run
+var directorCertRenewalSteps = []string{events.PhaseDirectorCertGeneration, events.PhaseAddNewDirectorCA, events.PhaseDirectorCleanup}
+
+// The renewed certificates are kept in the values of the run until they have been saved to the config
+const (
+	renewedDirectorCACert = "director_ca_cert"
+	renewedDirectorCert   = "director_cert"
+	renewedDirectorKey    = "director_key"
+)
+
+func (client *Client) renewDirectorCertsSteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	return []step{
+		{directorCertRenewalSteps[0], "Generating new director certificates", events.PhaseDirectorCertGeneration, func() error {
+			return client.generateRenewedDirectorCerts(run)
+		}, nil},
+		{directorCertRenewalSteps[1], "Adding new default CA and renewed certificates", events.PhaseAddNewDirectorCA, func() error {
+			return client.createEnvWithRenewedDirectorCerts(run, resource.AddNewDirectorCa)
+		}, nil},
+		{directorCertRenewalSteps[2], "Cleaning up director-creds.yml", events.PhaseDirectorCleanup, client.cleanupDirectorCreds, nil},
+	}, nil, nil
+}
+
+// generateRenewedDirectorCerts generates a new director CA and certificate, keeping them in the run
+func (client *Client) generateRenewedDirectorCerts(run *ProcedureRun) error {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+
+	dc, err := client.generateDirectorCerts(client.acmeClientConstructor, DirectorCerts{}, conf.GetDeployment(), tfOutputs, conf.GetPublicCIDR())
+	if err != nil {
+		return err
+	}
+	if dc.DirectorCACert == "" {
+		return fmt.Errorf("failed to generate director certificates for public CIDR [%s]", conf.GetPublicCIDR())
+	}
+
+	run.Values[renewedDirectorCACert] = dc.DirectorCACert
+	run.Values[renewedDirectorCert] = dc.DirectorCert
+	run.Values[renewedDirectorKey] = dc.DirectorKey
+	return nil
+}
+
+// createEnvWithRenewedDirectorCerts runs bosh create-env with the renewed director certificate and
+// an mbus certificate from a new default CA, then saves the renewed director certificate to the config
+func (client *Client) createEnvWithRenewedDirectorCerts(run *ProcedureRun, operation string) error {
+	if run.Values[renewedDirectorCACert] == "" {
+		return fmt.Errorf("no renewed director certificates found, run --renew-director-certs from stage 0")
+	}
+
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+	conf.DirectorCACert = run.Values[renewedDirectorCACert]
+	conf.DirectorCert = run.Values[renewedDirectorCert]
+	conf.DirectorKey = run.Values[renewedDirectorKey]
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	if err = client.runCreateEnv(boshClient, operation); err != nil {
+		return err
+	}
+	return client.configClient.Update(conf)
+}
+
+// cleanupDirectorCreds moves the renewed default CA and mbus certificate to their original keys in director-creds.yml
+func (client *Client) cleanupDirectorCreds() error {
+	directorCredsBytes, err := loadDirectorCreds(client.configClient)
+	if err != nil {
+		return err
+	}
+
+	var vars yaml.MapSlice
+	if err = yaml.Unmarshal(directorCredsBytes, &vars); err != nil {
+		return err
+	}
+
+	renewed := map[string]interface{}{}
+	var kept yaml.MapSlice
+	for _, item := range vars {
+		key := fmt.Sprint(item.Key)
+		if isRenewedDirectorVar(key) {
+			renewed[key] = item.Value
+			continue
+		}
+		kept = append(kept, item)
+	}
+	for _, name := range renewedDirectorVars {
+		if _, ok := renewed[name+"_2"]; !ok {
+			return fmt.Errorf("%s_2 not found in %s", name, bosh.CredsFilename)
+		}
+	}
+	for i, item := range kept {
+		if value, ok := renewed[fmt.Sprint(item.Key)+"_2"]; ok {
+			kept[i].Value = value
+		}
+	}
+
+	correctedCreds, err := yaml.Marshal(kept)
+	if err != nil {
+		return err
+	}
+	err = client.configClient.StoreAsset("director-creds-backup.yml", directorCredsBytes)
+	if err != nil {
+		return err
+	}
+	return client.configClient.StoreAsset(bosh.CredsFilename, correctedCreds)
+}
+
+func isRenewedDirectorVar(key string) bool {
+	for _, name := range renewedDirectorVars {
+		if key == name+"_2" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+package concourse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/events"
+	"github.com/EngineerBetter/control-tower/terraform"
+	"github.com/EngineerBetter/control-tower/util/yaml"
+)
+
+const rotatedPasswordLength = 20
+
+type credentialRotationStage struct {
+	name        string
+	credentials []string
+	description string
+	phase       string
+	rotate      func(newValue string) error
+	// compensate undoes what a failed rotate left behind, when it can be undone
+	compensate func() error
+}
+
+// credentialRotationStages returns the stages of a rotation in the order they run.
+// Concourse and Grafana share the atc_password var so are rotated together.
+// The stages which write the new value to director-creds.yml before redeploying put the
+// old one back if the redeploy fails, as config.json still has the old one.
+func (client *Client) credentialRotationStages() []credentialRotationStage {
+	return []credentialRotationStage{
+		{"director", []string{"director"}, "Rotating director admin password", events.PhaseRotateDirectorPassword, client.rotateDirectorPassword, nil},
+		{"rds", []string{"rds"}, "Rotating RDS master password", events.PhaseRotateRDSPassword, client.rotateRDSPassword, nil},
+		{"concourse", []string{"concourse", "grafana"}, "Rotating Concourse and Grafana admin password", events.PhaseRotateConcoursePassword, client.rotateConcoursePassword, client.restoreDirectorCredsValue("atc_password", func(conf config.Config) string {
+			return conf.ConcoursePassword
+		})},
+		{"credhub", []string{"credhub"}, "Rotating CredHub admin client secret", events.PhaseRotateCredhubSecret, client.rotateCredhubSecret, client.restoreDirectorCredsValue("credhub_admin_client_secret", func(conf config.Config) string {
+			return conf.CredhubAdminClientSecret
+		})},
+	}
+}
+
+// rotatedCredentials is the value of a run which holds the comma separated credentials being rotated.
+// The new value of each credential is kept under the ID of its step until the step succeeds.
+const rotatedCredentials = "credentials"
+
+func (client *Client) rotateCredentialsSteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	selected := strings.Join(m.SelectedCredentials(), ",")
+	switch rotating := run.Values[rotatedCredentials]; {
+	case rotating == "":
+		run.Values[rotatedCredentials] = selected
+	case m.CredentialsIsSet && rotating != selected:
+		return nil, nil, fmt.Errorf("a rotation of [%s] is already in progress, run --rotate-credentials without --credentials to finish it", strings.ReplaceAll(rotating, ",", ", "))
+	default:
+		fmt.Fprintf(client.stdout, "Continuing the rotation of [%s]\n", strings.ReplaceAll(rotating, ",", ", "))
+	}
+	credentials := strings.Split(run.Values[rotatedCredentials], ",")
+
+	var steps []step
+	for _, stage := range client.credentialRotationStages() {
+		rotated := selectedOf(stage.credentials, credentials)
+		if len(rotated) == 0 {
+			continue
+		}
+
+		stage := stage
+		steps = append(steps, step{id: stage.phase, description: stage.description, phase: stage.phase, run: func() error {
+			newValue, ok := run.Values[stage.phase]
+			if !ok {
+				newValue = client.passwordGenerator(rotatedPasswordLength)
+				run.Values[stage.phase] = newValue
+				if err := client.storeMaintenance(maintenance); err != nil {
+					return err
+				}
+			}
+
+			if err := stage.rotate(newValue); err != nil {
+				return err
+			}
+
+			delete(run.Values, stage.phase)
+			if maintenance.CredentialsRotatedAt == nil {
+				maintenance.CredentialsRotatedAt = map[string]time.Time{}
+			}
+			for _, credential := range rotated {
+				maintenance.CredentialsRotatedAt[credential] = time.Now().UTC()
+			}
+			return nil
+		}, compensate: stage.compensate})
+	}
+	return steps, nil, nil
+}
+
+// rotateDirectorPassword runs bosh create-env with a new admin password
+func (client *Client) rotateDirectorPassword(newValue string) error {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+	conf.DirectorPassword = newValue
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	if err = client.runCreateEnv(boshClient, ""); err != nil {
+		return err
+	}
+	return client.configClient.Update(conf)
+}
+
+// rotateRDSPassword changes the master password of the database with terraform then
+// redeploys the director and Concourse, which both connect to it as the master user
+func (client *Client) rotateRDSPassword(newValue string) error {
+	conf, err := client.configClient.Load()
+	if err != nil {
+		return err
+	}
+	conf.RDSPassword = newValue
+
+	tfInputVars := client.tfInputVarsFactory.NewInputVars(conf)
+	if err = client.tfCLI.Apply(tfInputVars); err != nil {
+		return err
+	}
+	// The database no longer accepts the old password, so the new one is saved before redeploying
+	if err = client.configClient.Update(conf); err != nil {
+		return err
+	}
+
+	tfOutputs, err := client.tfCLI.BuildOutput(tfInputVars)
+	if err != nil {
+		return err
+	}
+	return client.redeployWithCredentials(conf, tfOutputs)
+}
+
+// rotateConcoursePassword replaces atc_password, which is the admin password of both Concourse and Grafana
+func (client *Client) rotateConcoursePassword(newValue string) error {
+	if err := client.setDirectorCredsValue("atc_password", newValue); err != nil {
+		return err
+	}
+
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+	conf.ConcoursePassword = newValue
+	conf.GrafanaPassword = newValue
+	return client.redeployWithCredentials(conf, tfOutputs)
+}
+
+// rotateCredhubSecret replaces the secret of the credhub_admin UAA client
+func (client *Client) rotateCredhubSecret(newValue string) error {
+	if err := client.setDirectorCredsValue("credhub_admin_client_secret", newValue); err != nil {
+		return err
+	}
+
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+	conf.CredhubAdminClientSecret = newValue
+	return client.redeployWithCredentials(conf, tfOutputs)
+}
+
+// redeployWithCredentials deploys the director and Concourse using conf and the stored
+// director-creds.yml, then saves the credentials they ended up with to the config
+func (client *Client) redeployWithCredentials(conf config.Config, tfOutputs terraform.Outputs) error {
+	bp, err := client.deployBosh(conf, tfOutputs, false)
+	if err != nil {
+		return err
+	}
+
+	conf.CredhubPassword = bp.CredhubPassword
+	conf.CredhubAdminClientSecret = bp.CredhubAdminClientSecret
+	conf.ConcoursePassword = bp.ConcoursePassword
+	conf.GrafanaPassword = bp.GrafanaPassword
+	return client.configClient.Update(conf)
+}
+
+func (client *Client) loadConfigAndOutputs() (config.Config, terraform.Outputs, error) {
+	conf, err := client.configClient.Load()
+	if err != nil {
+		return conf, nil, err
+	}
+
+	tfOutputs, err := client.tfCLI.BuildOutput(client.tfInputVarsFactory.NewInputVars(conf))
+	return conf, tfOutputs, err
+}
+
+// setDirectorCredsValue replaces the value of a variable in director-creds.yml
+func (client *Client) setDirectorCredsValue(name, value string) error {
+	directorCredsBytes, err := loadDirectorCreds(client.configClient)
+	if err != nil {
+		return err
+	}
+
+	ops := fmt.Sprintf("- type: replace\n  path: /%s?\n  value: ((value))\n", name)
+	updatedCreds, err := yaml.Interpolate(string(directorCredsBytes), ops, map[string]interface{}{
+		"value": value,
+	})
+	if err != nil {
+		return err
+	}
+	return client.configClient.StoreAsset(bosh.CredsFilename, []byte(updatedCreds))
+}
+
+// restoreDirectorCredsValue returns a func which sets a variable in director-creds.yml back to the value recorded in the config
+func (client *Client) restoreDirectorCredsValue(name string, recorded func(config.Config) string) func() error {
+	return func() error {
+		conf, err := client.configClient.Load()
+		if err != nil {
+			return err
+		}
+		return client.setDirectorCredsValue(name, recorded(conf))
+	}
+}
+
+// storeMaintenance stores the maintenance object in the config bucket
+func (client *Client) storeMaintenance(maintenance *Maintenance) error {
+	maintenanceBytes, err := json.Marshal(maintenance)
+	if err != nil {
+		return err
+	}
+	return client.configClient.StoreAsset(maintenanceFilename, maintenanceBytes)
+}
+
+// selectedOf returns the members of credentials which are in selection
+func selectedOf(credentials, selection []string) []string {
+	var selected []string
+	for _, credential := range credentials {
+		for _, s := range selection {
+			if credential == s {
+				selected = append(selected, credential)
+			}
+		}
+	}
+	return selected
+}
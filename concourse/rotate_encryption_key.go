@@ -0,0 +1,115 @@
+package concourse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/events"
+	"github.com/EngineerBetter/control-tower/fly"
+)
+
+const (
+	encryptionKeyLength = 32
+	reencryptionTimeout = 15 * time.Minute
+	reencryptionPoll    = 10 * time.Second
+)
+
+// encryptionKeyRotationSteps are the IDs of the steps of an encryption key rotation, in the order they run
+var encryptionKeyRotationSteps = []string{events.PhaseEncryptionKeyGeneration, events.PhaseAddNewEncryptionKey, events.PhaseWaitForReencryption, events.PhaseRemoveOldEncryptionKey}
+
+// rotateEncryptionKeySteps plans the rotation of the key Concourse encrypts credentials and other data in its
+// database with. Concourse is deployed with both the new key and the old one, so that it re-encrypts the data
+// with the new key as it starts, and then the old key is removed.
+func (client *Client) rotateEncryptionKeySteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	return []step{
+		{encryptionKeyRotationSteps[0], "Generating a new encryption key", events.PhaseEncryptionKeyGeneration, client.generateEncryptionKey, nil},
+		{encryptionKeyRotationSteps[1], "Deploying Concourse with the new and old encryption keys", events.PhaseAddNewEncryptionKey, client.deployWithEncryptionKeys, nil},
+		{encryptionKeyRotationSteps[2], "Waiting for Concourse to re-encrypt its data with the new key", events.PhaseWaitForReencryption, client.waitForReencryption, nil},
+		{encryptionKeyRotationSteps[3], "Deploying Concourse without the old encryption key", events.PhaseRemoveOldEncryptionKey, client.removeOldEncryptionKey, nil},
+	}, nil, nil
+}
+
+// generateEncryptionKey saves a new encryption key to the config, keeping the current one as the old key.
+// The keys are saved straight away so that any deploy during the rotation gives Concourse both of them.
+func (client *Client) generateEncryptionKey() error {
+	conf, err := client.configClient.Load()
+	if err != nil {
+		return err
+	}
+
+	// Generating another key now would lose the one the data is still encrypted with
+	if conf.OldEncryptionKey != "" {
+		fmt.Fprintln(client.stdout, "Continuing with the encryption key generated by an earlier rotation")
+		return nil
+	}
+
+	conf.OldEncryptionKey = conf.EncryptionKey
+	conf.EncryptionKey = client.passwordGenerator(encryptionKeyLength)
+	return client.configClient.Update(conf)
+}
+
+// deployWithEncryptionKeys deploys Concourse with the encryption keys in the config
+func (client *Client) deployWithEncryptionKeys() error {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+	if conf.OldEncryptionKey == "" {
+		return fmt.Errorf("no old encryption key found, run --rotate-encryption-key from stage 0")
+	}
+	return client.deployConcourseOnly(conf, tfOutputs)
+}
+
+// waitForReencryption waits until the Concourse API can be logged in to. Concourse re-encrypts
+// its data before it starts serving the API, so it is then no longer encrypted with the old key.
+func (client *Client) waitForReencryption() error {
+	conf, err := client.configClient.Load()
+	if err != nil {
+		return err
+	}
+
+	flyClient, err := client.flyClientFactory(client.provider, fly.Credentials{
+		Target:   conf.GetDeployment(),
+		API:      fmt.Sprintf("https://%s", conf.GetDomain()),
+		Username: conf.GetConcourseUsername(),
+		Password: conf.GetConcoursePassword(),
+	},
+		client.stdout,
+		client.stderr,
+		client.versionFile,
+	)
+	if err != nil {
+		return err
+	}
+	defer flyClient.Cleanup()
+
+	start := time.Now().UTC()
+	for {
+		connected, err := flyClient.CanConnect()
+		if err != nil {
+			return err
+		}
+		if connected {
+			return nil
+		}
+		if time.Since(start) > reencryptionTimeout {
+			return fmt.Errorf("concourse was not reachable after %s", reencryptionTimeout)
+		}
+		time.Sleep(reencryptionPoll)
+	}
+}
+
+// removeOldEncryptionKey removes the old encryption key from the config and deploys Concourse without it
+func (client *Client) removeOldEncryptionKey() error {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+	conf.OldEncryptionKey = ""
+
+	if err = client.deployConcourseOnly(conf, tfOutputs); err != nil {
+		return err
+	}
+	return client.configClient.Update(conf)
+}
@@ -0,0 +1,91 @@
+package concourse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/events"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+// The new key pair is kept in the values of the run until the director has been recreated with it
+const (
+	rotatedPrivateKey = "private_key"
+	rotatedPublicKey  = "public_key"
+)
+
+func (client *Client) rotateSSHKeySteps(m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+	steps := []step{
+		{id: events.PhaseSSHKeyGeneration, description: "Generating a new SSH key pair", phase: events.PhaseSSHKeyGeneration, run: func() error {
+			return client.generateSSHKey(run)
+		}},
+	}
+	// The GCP jumpbox user is part of the director manifest, but on AWS the public key is registered as a key pair by terraform
+	if client.provider.IAAS() == iaas.AWS {
+		steps = append(steps, step{id: events.PhaseSSHKeyRegistration, description: "Registering the new SSH public key", phase: events.PhaseSSHKeyRegistration, run: func() error {
+			return client.registerSSHKey(run)
+		}})
+	}
+	steps = append(steps, step{id: events.PhaseBOSHCreateEnv, description: "Recreating the director with the new SSH key", phase: events.PhaseBOSHCreateEnv, run: func() error {
+		return client.createEnvWithSSHKey(run)
+	}})
+	return steps, nil, nil
+}
+
+// generateSSHKey generates a new key pair, keeping it in the run
+func (client *Client) generateSSHKey(run *ProcedureRun) error {
+	privateKey, publicKey, _, err := client.sshGenerator()
+	if err != nil {
+		return err
+	}
+	run.Values[rotatedPrivateKey] = strings.TrimSpace(string(privateKey))
+	run.Values[rotatedPublicKey] = strings.TrimSpace(string(publicKey))
+	return nil
+}
+
+// registerSSHKey replaces the director key pair with terraform. The new key is saved to the config straight
+// away so that a deploy run before the director is recreated doesn't put the old key pair back.
+func (client *Client) registerSSHKey(run *ProcedureRun) error {
+	if run.Values[rotatedPublicKey] == "" {
+		return fmt.Errorf("no new SSH key found, run --rotate-ssh-key again to start a new rotation")
+	}
+
+	conf, err := client.configClient.Load()
+	if err != nil {
+		return err
+	}
+	conf.PrivateKey = run.Values[rotatedPrivateKey]
+	conf.PublicKey = run.Values[rotatedPublicKey]
+
+	if err = client.tfCLI.Apply(client.tfInputVarsFactory.NewInputVars(conf)); err != nil {
+		return err
+	}
+	return client.configClient.Update(conf)
+}
+
+// createEnvWithSSHKey runs bosh create-env with the new key pair, which recreates the director VM
+// as its manifest has changed, then saves the key pair to the config
+func (client *Client) createEnvWithSSHKey(run *ProcedureRun) error {
+	if run.Values[rotatedPrivateKey] == "" {
+		return fmt.Errorf("no new SSH key found, run --rotate-ssh-key again to start a new rotation")
+	}
+
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+	conf.PrivateKey = run.Values[rotatedPrivateKey]
+	conf.PublicKey = run.Values[rotatedPublicKey]
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	if err = client.runCreateEnv(boshClient, ""); err != nil {
+		return err
+	}
+	return client.configClient.Update(conf)
+}
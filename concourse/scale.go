@@ -0,0 +1,58 @@
+package concourse
+
+import (
+	"fmt"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/commands/scale"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/terraform"
+)
+
+// Scale changes the number or size of the workers in config.json and applies it by deploying only
+// the Concourse manifest, skipping the terraform, certificate and director steps of a full deploy
+func (client *Client) Scale(s scale.Args) error {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+
+	if s.WorkerCountIsSet {
+		conf.ConcourseWorkerCount = s.WorkerCount
+	}
+	if s.WorkerSizeIsSet {
+		conf.ConcourseWorkerSize = s.WorkerSize
+	}
+
+	if err = client.deployConcourseOnly(conf, tfOutputs); err != nil {
+		return err
+	}
+
+	if err = client.configClient.Update(conf); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(client.stdout, "Scaled %s to %d %s workers\n", conf.GetDeployment(), conf.GetConcourseWorkerCount(), conf.GetConcourseWorkerSize())
+	return err
+}
+
+// deployConcourseOnly deploys the Concourse manifest for conf, storing the updated director-creds.yml
+func (client *Client) deployConcourseOnly(conf config.Config, tfOutputs terraform.Outputs) error {
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	boshCredsBytes, err := loadDirectorCreds(client.configClient)
+	if err != nil {
+		return err
+	}
+
+	boshCredsBytes, err = boshClient.DeployConcourse(boshCredsBytes)
+	err1 := client.configClient.StoreAsset(bosh.CredsFilename, boshCredsBytes)
+	if err == nil {
+		err = err1
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package concourse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+)
+
+// SSH opens an interactive shell on a VM of the deployment, tunnelling through the director. instance is
+// either an instance group such as `web` or a single instance such as `worker/<id>`. When it is empty, or
+// names a group of more than one instance, the user picks one from a list and answers on stdin, or
+// if stdin is nil is asked to give a single instance instead.
+func (client *Client) SSH(instance string, stdin io.Reader) error {
+	conf, tfOutputs, err := client.loadConfigAndOutputs()
+	if err != nil {
+		return err
+	}
+
+	boshClient, err := client.buildBoshClient(conf, tfOutputs)
+	if err != nil {
+		return err
+	}
+	defer boshClient.Cleanup()
+
+	instances, err := boshClient.Instances()
+	if err != nil {
+		return err
+	}
+
+	matches := matchInstances(instances, instance)
+	switch {
+	case len(matches) == 0 && instance == "":
+		return fmt.Errorf("there are no instances in the deployment")
+	case len(matches) == 0:
+		return fmt.Errorf("no instance [%s] in the deployment, the instances are: %s", instance, strings.Join(instanceNames(instances), ", "))
+	case len(matches) == 1:
+		return boshClient.SSH(matches[0].Name)
+	case stdin == nil:
+		return fmt.Errorf("choose a single instance from: %s", strings.Join(instanceNames(matches), ", "))
+	}
+
+	chosen, err := pickInstance(stdin, client.stdout, matches)
+	if err != nil {
+		return err
+	}
+	return boshClient.SSH(chosen.Name)
+}
+
+// matchInstances returns every instance if name is empty, otherwise the instance called name
+// or the instances in the group called name
+func matchInstances(instances []bosh.Instance, name string) []bosh.Instance {
+	if name == "" {
+		return instances
+	}
+	var matches []bosh.Instance
+	for _, instance := range instances {
+		group := strings.SplitN(instance.Name, "/", 2)[0]
+		if instance.Name == name || group == name {
+			matches = append(matches, instance)
+		}
+	}
+	return matches
+}
+
+func instanceNames(instances []bosh.Instance) []string {
+	var names []string
+	for _, instance := range instances {
+		names = append(names, instance.Name)
+	}
+	return names
+}
+
+// pickInstance lists the instances by number and returns the one whose number is read from stdin
+func pickInstance(stdin io.Reader, stdout io.Writer, instances []bosh.Instance) (bosh.Instance, error) {
+	tw := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', 0)
+	for i, instance := range instances {
+		fmt.Fprintf(tw, "%d)\t%s\t%s\t%s\n", i+1, instance.Name, instance.IP, instance.State)
+	}
+	if err := tw.Flush(); err != nil {
+		return bosh.Instance{}, err
+	}
+	if _, err := fmt.Fprintf(stdout, "Choose an instance [1-%d]: ", len(instances)); err != nil {
+		return bosh.Instance{}, err
+	}
+
+	var response string
+	if _, err := fmt.Fscan(stdin, &response); err != nil {
+		return bosh.Instance{}, err
+	}
+	var choice int
+	if _, err := fmt.Sscan(response, &choice); err != nil || choice < 1 || choice > len(instances) {
+		return bosh.Instance{}, fmt.Errorf("Input not recognized: `%s`", response)
+	}
+	return instances[choice-1], nil
+}
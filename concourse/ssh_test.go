@@ -0,0 +1,105 @@
+package concourse
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/bosh/boshfakes"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/terraform/terraformfakes"
+)
+
+var sshInstances = []bosh.Instance{
+	{Name: "web/abc", IP: "10.0.0.1", State: "running"},
+	{Name: "worker/def", IP: "10.0.0.2", State: "running"},
+	{Name: "worker/ghi", IP: "10.0.0.3", State: "failing"},
+}
+
+func sshClient(boshClient *boshfakes.FakeIClient, stdout io.Writer) *Client {
+	boshClient.InstancesReturns(sshInstances, nil)
+	client := newDeploymentClient(config.Config{Deployment: "control-tower-foo"}, &terraformfakes.FakeOutputs{}, boshClient)
+	client.stdout = stdout
+	return client
+}
+
+func TestSSH(t *testing.T) {
+	tests := []struct {
+		name       string
+		instance   string
+		stdin      string
+		wantSSH    string
+		wantPicker bool
+	}{
+		{name: "a single instance", instance: "worker/ghi", wantSSH: "worker/ghi"},
+		{name: "a group of one instance", instance: "web", wantSSH: "web/abc"},
+		{name: "a group of several instances", instance: "worker", stdin: "2\n", wantSSH: "worker/ghi", wantPicker: true},
+		{name: "no instance", stdin: "1\n", wantSSH: "web/abc", wantPicker: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boshClient := &boshfakes.FakeIClient{}
+			var stdout bytes.Buffer
+
+			if err := sshClient(boshClient, &stdout).SSH(tt.instance, strings.NewReader(tt.stdin)); err != nil {
+				t.Fatal(err)
+			}
+			if boshClient.SSHCallCount() != 1 || boshClient.SSHArgsForCall(0) != tt.wantSSH {
+				t.Fatalf("SSH() opened a shell on %v, want %s", boshClient.Invocations()["SSH"], tt.wantSSH)
+			}
+			if strings.Contains(stdout.String(), "Choose an instance") != tt.wantPicker {
+				t.Errorf("SSH() wrote %q, want picker %t", stdout.String(), tt.wantPicker)
+			}
+			if boshClient.CleanupCallCount() != 1 {
+				t.Errorf("SSH() did not clean up the BOSH client")
+			}
+		})
+	}
+}
+
+func TestSSH_UnknownInstance(t *testing.T) {
+	boshClient := &boshfakes.FakeIClient{}
+
+	err := sshClient(boshClient, io.Discard).SSH("db", strings.NewReader(""))
+	want := "no instance [db] in the deployment, the instances are: web/abc, worker/def, worker/ghi"
+	if err == nil || err.Error() != want {
+		t.Errorf("SSH() error = %v, want %s", err, want)
+	}
+	if boshClient.SSHCallCount() != 0 {
+		t.Errorf("SSH() opened a shell on an unknown instance")
+	}
+}
+
+func TestSSH_NonInteractive(t *testing.T) {
+	boshClient := &boshfakes.FakeIClient{}
+
+	err := sshClient(boshClient, io.Discard).SSH("worker", nil)
+	want := "choose a single instance from: worker/def, worker/ghi"
+	if err == nil || err.Error() != want {
+		t.Errorf("SSH() error = %v, want %s", err, want)
+	}
+}
+
+func TestPickInstance(t *testing.T) {
+	var stdout bytes.Buffer
+	chosen, err := pickInstance(strings.NewReader("3\n"), &stdout, sshInstances)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chosen.Name != "worker/ghi" {
+		t.Errorf("pickInstance() = %s, want worker/ghi", chosen.Name)
+	}
+	want := "1)  web/abc     10.0.0.1  running\n2)  worker/def  10.0.0.2  running\n3)  worker/ghi  10.0.0.3  failing\nChoose an instance [1-3]: "
+	if stdout.String() != want {
+		t.Errorf("pickInstance() wrote %q, want %q", stdout.String(), want)
+	}
+
+	for _, response := range []string{"0", "4", "worker"} {
+		_, err := pickInstance(strings.NewReader(response+"\n"), io.Discard, sshInstances)
+		if err == nil || err.Error() != "Input not recognized: `"+response+"`" {
+			t.Errorf("pickInstance() with %q error = %v, want input not recognized", response, err)
+		}
+	}
+}
@@ -15,9 +15,9 @@ type TFInputVarsFactory interface {
 	NewInputVars(conf config.ConfigView) terraform.InputVars
 }
 
-func NewTFInputVarsFactory(provider iaas.Provider) (TFInputVarsFactory, error) {
+func NewTFInputVarsFactory(provider iaas.Provider, stateStore config.StateStore) (TFInputVarsFactory, error) {
 	if provider.IAAS() == iaas.AWS {
-		return &AWSInputVarsFactory{}, nil
+		return &AWSInputVarsFactory{stateStore: stateStore}, nil
 	} else if provider.IAAS() == iaas.GCP {
 		credentialsPath, err := provider.Attr("credentials_path")
 		if err != nil {
@@ -34,16 +34,20 @@ func NewTFInputVarsFactory(provider iaas.Provider) (TFInputVarsFactory, error) {
 			project:         project,
 			region:          provider.Region(),
 			zone:            provider.Zone("", ""),
+			stateStore:      stateStore,
 		}, nil
 	}
 
 	return nil, fmt.Errorf("IAAS not supported [%s]", provider.IAAS())
 }
 
-type AWSInputVarsFactory struct{}
+type AWSInputVarsFactory struct {
+	stateStore config.StateStore
+}
 
 func (f *AWSInputVarsFactory) NewInputVars(c config.ConfigView) terraform.InputVars {
 	metricsEnabled := !c.MetricsIsDisabled()
+	backend := nonDefaultStateBackend(f.stateStore, c, config.StateStoreS3)
 	return &terraform.AWSInputVars{
 		NetworkCIDR:            c.GetNetworkCIDR(),
 		PublicCIDR:             c.GetPublicCIDR(),
@@ -67,6 +71,9 @@ func (f *AWSInputVarsFactory) NewInputVars(c config.ConfigView) terraform.InputV
 		RDS2CIDR:               c.GetRDS2CIDR(),
 		Region:                 c.GetRegion(),
 		SourceAccessIP:         c.GetSourceAccessIP(),
+		StateBackend:           backend.Type,
+		StateDir:               backend.Dir,
+		StateRegion:            backend.Region,
 		TFStatePath:            c.GetTFStatePath(),
 	}
 }
@@ -76,10 +83,12 @@ type GCPInputVarsFactory struct {
 	project         string
 	region          string
 	zone            string
+	stateStore      config.StateStore
 }
 
 func (f *GCPInputVarsFactory) NewInputVars(c config.ConfigView) terraform.InputVars {
 	metricsEnabled := !c.MetricsIsDisabled()
+	backend := nonDefaultStateBackend(f.stateStore, c, config.StateStoreGCS)
 	return &terraform.GCPInputVars{
 		AllowIPs:           c.GetAllowIPs(),
 		ConfigBucket:       c.GetConfigBucket(),
@@ -96,9 +105,25 @@ func (f *GCPInputVarsFactory) NewInputVars(c config.ConfigView) terraform.InputV
 		Namespace:          c.GetNamespace(),
 		Project:            f.project,
 		Region:             f.region,
+		StateBackend:       backend.Type,
+		StateDir:           backend.Dir,
+		StateRegion:        backend.Region,
 		Tags:               "",
 		Zone:               f.zone,
 		PublicCIDR:         c.GetPublicCIDR(),
 		PrivateCIDR:        c.GetPrivateCIDR(),
 	}
 }
+
+// nonDefaultStateBackend returns the terraform backend of the state store, or an empty
+// backend when it is the bucket storage of the deployment's own IAAS and region
+func nonDefaultStateBackend(stateStore config.StateStore, c config.ConfigView, defaultType string) config.StateBackend {
+	if stateStore == nil {
+		return config.StateBackend{}
+	}
+	backend := stateStore.Backend(c.GetConfigBucket())
+	if backend.Type == defaultType && (backend.Region == "" || backend.Region == c.GetRegion()) {
+		return config.StateBackend{}
+	}
+	return backend
+}
@@ -0,0 +1,311 @@
+package concourse
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/events"
+)
+
+// Statuses of a procedure run
+const (
+	ProcedureRunning   = "running"
+	ProcedureSucceeded = "succeeded"
+	ProcedureFailed    = "failed"
+)
+
+// Statuses of a step of a procedure run
+const (
+	StepPending     = "pending"
+	StepRunning     = "running"
+	StepSucceeded   = "succeeded"
+	StepSkipped     = "skipped"
+	StepFailed      = "failed"
+	StepCompensated = "compensated"
+)
+
+// ProcedureRun records the progress of a maintenance procedure so that an interrupted one can be continued
+type ProcedureRun struct {
+	Status     string     `json:"status"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Steps      []*StepRun `json:"steps"`
+	// Values are kept between attempts at a run, e.g. so that a retried step uses the same generated password.
+	// They are removed once the run succeeds as they can include secrets.
+	Values map[string]string `json:"values,omitempty"`
+
+	current *StepRun
+}
+
+// StepRun records the state of one step of a procedure run
+type StepRun struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Log        []LogEntry `json:"log,omitempty"`
+}
+
+// LogEntry is a timestamped message logged by a step
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// step is one operation of a procedure. If it fails, compensate is run, when set, to undo what it partly did.
+type step struct {
+	id          string
+	description string
+	phase       string
+	run         func() error
+	compensate  func() error
+}
+
+// procedure is a maintenance operation made up of steps, which is run by maintain --<name>
+type procedure struct {
+	maintain.Procedure
+	// resumable procedures continue an unfinished run from the first step which didn't succeed, rather than starting again
+	resumable bool
+	// waitForLocks waits for the director to finish any tasks before the steps are planned
+	waitForLocks bool
+	// steps plans the steps of a run. Any cleanup returned is called once the steps have run.
+	steps func(client *Client, m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error)
+}
+
+var procedures []procedure
+
+// registerProcedure adds a procedure to those that maintain can run, registering its flag with the maintain args
+func registerProcedure(p procedure) {
+	maintain.Register(p.Procedure)
+	procedures = append(procedures, p)
+}
+
+func procedureNamed(name string) (procedure, bool) {
+	for _, p := range procedures {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return procedure{}, false
+}
+
+// runProcedure runs the steps of a procedure in order, recording their progress in maintenance.json
+func (client *Client) runProcedure(p procedure, m maintain.Args) error {
+	if p.waitForLocks {
+		if err := client.waitForBOSHLocks(10 * time.Minute); err != nil {
+			return err
+		}
+	}
+
+	maintenance, err := client.retrieveMaintenance()
+	if err != nil {
+		return err
+	}
+
+	run := maintenance.Procedures[p.Name]
+	if run == nil || run.Status == ProcedureSucceeded || !p.resumable {
+		run = &ProcedureRun{StartedAt: time.Now().UTC()}
+		maintenance.Procedures[p.Name] = run
+	}
+	if run.Values == nil {
+		run.Values = map[string]string{}
+	}
+	run.Status = ProcedureRunning
+	run.FinishedAt = nil
+
+	steps, cleanup, err := p.steps(client, m, maintenance, run)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+
+	start := 0
+	if p.Offers(maintain.OptionStage) && m.StageIsSet {
+		if m.Stage < 0 || m.Stage >= len(steps) {
+			return fmt.Errorf("Invalid stage index")
+		}
+		start = m.Stage
+	}
+	run.planSteps(steps, start)
+	if err = client.storeMaintenance(maintenance); err != nil {
+		return err
+	}
+
+	for i, s := range steps {
+		state := run.step(s.id)
+		if i < start || state.Status == StepSucceeded {
+			continue
+		}
+
+		err = client.runStep(run, state, s, func() error {
+			return client.storeMaintenance(maintenance)
+		})
+		if err != nil {
+			run.finish(ProcedureFailed)
+			if err1 := client.storeMaintenance(maintenance); err1 != nil {
+				fmt.Fprintf(client.stdout, "failed to record the failure of %s: %s\n", s.id, err1)
+			}
+			return err
+		}
+	}
+
+	run.finish(ProcedureSucceeded)
+	run.Values = nil
+	return client.storeMaintenance(maintenance)
+}
+
+// runStep runs a step, and its compensation if it fails, saving its state as it goes
+func (client *Client) runStep(run *ProcedureRun, state *StepRun, s step, save func() error) error {
+	run.current = state
+	defer func() { run.current = nil }()
+
+	now := time.Now().UTC()
+	state.Status = StepRunning
+	state.StartedAt = &now
+	state.FinishedAt = nil
+	state.Error = ""
+	client.logf(run, "current action: %s", s.description)
+	if err := save(); err != nil {
+		return err
+	}
+
+	err := client.recorder.Record(s.phase, s.run)
+	if err == nil {
+		state.Status = StepSucceeded
+		state.FinishedAt = timeNow()
+		return save()
+	}
+
+	state.Status = StepFailed
+	state.Error = err.Error()
+	client.logf(run, "%s failed: %s", s.id, err)
+	if s.compensate != nil {
+		client.logf(run, "compensating for the failure of %s", s.id)
+		err1 := client.recorder.Record(events.PhaseCompensate, s.compensate)
+		if err1 == nil {
+			state.Status = StepCompensated
+		} else {
+			client.logf(run, "compensating for the failure of %s failed: %s", s.id, err1)
+		}
+	}
+	state.FinishedAt = timeNow()
+	return err
+}
+
+// logf prints a message, and keeps it in the log of the step being run
+func (client *Client) logf(run *ProcedureRun, format string, a ...interface{}) {
+	message := fmt.Sprintf(format, a...)
+	fmt.Fprintln(client.stdout, message)
+	if run.current != nil {
+		run.current.Log = append(run.current.Log, LogEntry{Time: time.Now().UTC(), Message: message})
+	}
+}
+
+// planSteps lines up the recorded steps with those planned, keeping the state of steps which were already run.
+// Steps before start are skipped unless they already succeeded, and those from start on are run again.
+func (run *ProcedureRun) planSteps(steps []step, start int) {
+	var planned []*StepRun
+	for i, s := range steps {
+		state := run.step(s.id)
+		if state == nil {
+			state = &StepRun{ID: s.id, Status: StepPending}
+		}
+		switch {
+		case i < start && state.Status != StepSucceeded:
+			state.Status = StepSkipped
+		case i >= start && start > 0:
+			state.Status = StepPending
+		}
+		planned = append(planned, state)
+	}
+	run.Steps = planned
+}
+
+func (run *ProcedureRun) step(id string) *StepRun {
+	for _, state := range run.Steps {
+		if state.ID == id {
+			return state
+		}
+	}
+	return nil
+}
+
+func (run *ProcedureRun) finish(status string) {
+	run.Status = status
+	run.FinishedAt = timeNow()
+}
+
+func timeNow() *time.Time {
+	now := time.Now().UTC()
+	return &now
+}
+
+// maintenanceStatus prints the state of the last run of each procedure
+func (client *Client) maintenanceStatus() error {
+	maintenance, err := client.retrieveMaintenance()
+	if err != nil {
+		return err
+	}
+	return writeMaintenanceStatus(client.stdout, maintenance)
+}
+
+func writeMaintenanceStatus(w io.Writer, maintenance *Maintenance) error {
+	if len(maintenance.Procedures) == 0 && len(maintenance.CredentialsRotatedAt) == 0 {
+		_, err := fmt.Fprintln(w, "No maintenance procedures have been run")
+		return err
+	}
+
+	var names []string
+	for name := range maintenance.Procedures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for _, name := range names {
+		run := maintenance.Procedures[name]
+		fmt.Fprintf(tw, "%s\t%s\tstarted %s\t%s\n", name, run.Status, formatTime(&run.StartedAt), finishedAt(run.FinishedAt))
+		for _, state := range run.Steps {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", state.ID, state.Status, formatTime(state.StartedAt), finishedAt(state.FinishedAt))
+			if state.Error != "" {
+				fmt.Fprintf(tw, "    error: %s\t\t\t\n", state.Error)
+			}
+		}
+	}
+
+	if len(maintenance.CredentialsRotatedAt) > 0 {
+		var credentials []string
+		for credential := range maintenance.CredentialsRotatedAt {
+			credentials = append(credentials, credential)
+		}
+		sort.Strings(credentials)
+
+		fmt.Fprintln(tw, "\nCredential\tLast rotated\t\t")
+		for _, credential := range credentials {
+			rotatedAt := maintenance.CredentialsRotatedAt[credential]
+			fmt.Fprintf(tw, "%s\t%s\t\t\n", credential, formatTime(&rotatedAt))
+		}
+	}
+	return tw.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func finishedAt(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return "finished " + formatTime(t)
+}
@@ -0,0 +1,255 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/EngineerBetter/control-tower/bosh"
+	"github.com/EngineerBetter/control-tower/commands/maintain"
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/config/configfakes"
+	"github.com/EngineerBetter/control-tower/events"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/terraform"
+	"github.com/EngineerBetter/control-tower/terraform/terraformfakes"
+)
+
+type nullInputVarsFactory struct{}
+
+func (nullInputVarsFactory) NewInputVars(config.ConfigView) terraform.InputVars {
+	return &terraform.NullInputVars{}
+}
+
+func newWorkflowClient(storedAssets map[string][]byte) *Client {
+	configClient := &configfakes.FakeIClient{}
+	configClient.HasAssetStub = func(name string) (bool, error) {
+		_, ok := storedAssets[name]
+		return ok, nil
+	}
+	configClient.LoadAssetStub = func(name string) ([]byte, error) {
+		return storedAssets[name], nil
+	}
+	configClient.StoreAssetStub = func(name string, contents []byte) error {
+		storedAssets[name] = contents
+		return nil
+	}
+	return &Client{
+		configClient: configClient,
+		stdout:       &bytes.Buffer{},
+		recorder:     events.NopRecorder{},
+	}
+}
+
+// newDeploymentClient returns a client of a deployment with the stored config conf and the terraform outputs
+// tfOutputs, whose director is reached through boshClient. Tests set any other fields they exercise.
+func newDeploymentClient(conf config.Config, tfOutputs terraform.Outputs, boshClient bosh.IClient) *Client {
+	configClient := &configfakes.FakeIClient{}
+	configClient.LoadReturns(conf, nil)
+	tfCLI := &terraformfakes.FakeCLIInterface{}
+	tfCLI.BuildOutputReturns(tfOutputs, nil)
+
+	return &Client{
+		configClient:       configClient,
+		tfCLI:              tfCLI,
+		tfInputVarsFactory: nullInputVarsFactory{},
+		boshClientFactory: func(config.ConfigView, terraform.Outputs, io.Writer, io.Writer, iaas.Provider, []byte, events.Recorder) (bosh.IClient, error) {
+			return boshClient, nil
+		},
+		stdout:   &bytes.Buffer{},
+		stderr:   io.Discard,
+		recorder: events.NopRecorder{},
+	}
+}
+
+func storedRun(t *testing.T, storedAssets map[string][]byte, name string) *ProcedureRun {
+	var maintenance Maintenance
+	if err := json.Unmarshal(storedAssets[maintenanceFilename], &maintenance); err != nil {
+		t.Fatalf("failed to parse %s: %v", maintenanceFilename, err)
+	}
+	return maintenance.Procedures[name]
+}
+
+func stepStatuses(run *ProcedureRun) map[string]string {
+	statuses := map[string]string{}
+	for _, state := range run.Steps {
+		statuses[state.ID] = state.Status
+	}
+	return statuses
+}
+
+// testProcedure returns a procedure of three steps, where the step called failing returns an error
+func testProcedure(ran *[]string, failing string, resumable bool) procedure {
+	action := func(id string) func() error {
+		return func() error {
+			*ran = append(*ran, id)
+			if id == failing {
+				return errors.New("step failed")
+			}
+			return nil
+		}
+	}
+	return procedure{
+		Procedure: maintain.Procedure{Name: "test", Options: []string{maintain.OptionStage}},
+		resumable: resumable,
+		steps: func(client *Client, m maintain.Args, maintenance *Maintenance, run *ProcedureRun) ([]step, func(), error) {
+			return []step{
+				{id: "first", description: "First", run: action("first")},
+				{id: "second", description: "Second", run: action("second"), compensate: func() error {
+					*ran = append(*ran, "compensating second")
+					return nil
+				}},
+				{id: "third", description: "Third", run: action("third")},
+			}, nil, nil
+		},
+	}
+}
+
+func TestClient_runProcedure(t *testing.T) {
+	tests := []struct {
+		name         string
+		failing      string
+		resumable    bool
+		args         maintain.Args
+		previousRun  *ProcedureRun
+		wantErr      bool
+		wantRan      []string
+		wantStatus   string
+		wantStatuses map[string]string
+	}{
+		{
+			name:         "runs every step in order",
+			wantRan:      []string{"first", "second", "third"},
+			wantStatus:   ProcedureSucceeded,
+			wantStatuses: map[string]string{"first": StepSucceeded, "second": StepSucceeded, "third": StepSucceeded},
+		},
+		{
+			name:         "stops at a failed step, running its compensation",
+			failing:      "second",
+			wantErr:      true,
+			wantRan:      []string{"first", "second", "compensating second"},
+			wantStatus:   ProcedureFailed,
+			wantStatuses: map[string]string{"first": StepSucceeded, "second": StepCompensated, "third": StepPending},
+		},
+		{
+			name:      "resumes from the step which failed",
+			resumable: true,
+			previousRun: &ProcedureRun{Status: ProcedureFailed, Steps: []*StepRun{
+				{ID: "first", Status: StepSucceeded},
+				{ID: "second", Status: StepFailed},
+				{ID: "third", Status: StepPending},
+			}},
+			wantRan:      []string{"second", "third"},
+			wantStatus:   ProcedureSucceeded,
+			wantStatuses: map[string]string{"first": StepSucceeded, "second": StepSucceeded, "third": StepSucceeded},
+		},
+		{
+			name: "starts again when the procedure isn't resumable",
+			previousRun: &ProcedureRun{Status: ProcedureFailed, Steps: []*StepRun{
+				{ID: "first", Status: StepSucceeded},
+				{ID: "second", Status: StepFailed},
+			}},
+			wantRan:      []string{"first", "second", "third"},
+			wantStatus:   ProcedureSucceeded,
+			wantStatuses: map[string]string{"first": StepSucceeded, "second": StepSucceeded, "third": StepSucceeded},
+		},
+		{
+			name:         "starts from the given stage",
+			args:         maintain.Args{Stage: 2, StageIsSet: true},
+			wantRan:      []string{"third"},
+			wantStatus:   ProcedureSucceeded,
+			wantStatuses: map[string]string{"first": StepSkipped, "second": StepSkipped, "third": StepSucceeded},
+		},
+		{
+			name:    "rejects a stage past the last step",
+			args:    maintain.Args{Stage: 3, StageIsSet: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storedAssets := map[string][]byte{}
+			if tt.previousRun != nil {
+				maintenanceBytes, err := json.Marshal(Maintenance{Procedures: map[string]*ProcedureRun{"test": tt.previousRun}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				storedAssets[maintenanceFilename] = maintenanceBytes
+			}
+
+			var ran []string
+			err := newWorkflowClient(storedAssets).runProcedure(testProcedure(&ran, tt.failing, tt.resumable), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runProcedure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(ran, tt.wantRan) {
+				t.Errorf("runProcedure() ran %v, want %v", ran, tt.wantRan)
+			}
+			if tt.wantStatuses == nil {
+				return
+			}
+
+			run := storedRun(t, storedAssets, "test")
+			if run.Status != tt.wantStatus {
+				t.Errorf("run status = %v, want %v", run.Status, tt.wantStatus)
+			}
+			if got := stepStatuses(run); !reflect.DeepEqual(got, tt.wantStatuses) {
+				t.Errorf("step statuses = %v, want %v", got, tt.wantStatuses)
+			}
+		})
+	}
+}
+
+func TestClient_runProcedure_recordsFailures(t *testing.T) {
+	storedAssets := map[string][]byte{}
+	var ran []string
+	err := newWorkflowClient(storedAssets).runProcedure(testProcedure(&ran, "first", false), maintain.Args{})
+	if err == nil {
+		t.Fatal("runProcedure() expected an error")
+	}
+
+	state := storedRun(t, storedAssets, "test").Steps[0]
+	if state.Error != "step failed" {
+		t.Errorf("step error = %q, want %q", state.Error, "step failed")
+	}
+	if state.StartedAt == nil || state.FinishedAt == nil {
+		t.Errorf("step timestamps not recorded: started %v, finished %v", state.StartedAt, state.FinishedAt)
+	}
+	var messages []string
+	for _, entry := range state.Log {
+		messages = append(messages, entry.Message)
+	}
+	wantMessages := []string{"current action: First", "first failed: step failed"}
+	if !reflect.DeepEqual(messages, wantMessages) {
+		t.Errorf("step log = %v, want %v", messages, wantMessages)
+	}
+}
+
+func TestClient_retrieveMaintenance_migratesLegacyProgress(t *testing.T) {
+	storedAssets := map[string][]byte{
+		maintenanceFilename: []byte(`{"status_index": 1}`),
+	}
+
+	maintenance, err := newWorkflowClient(storedAssets).retrieveMaintenance()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := maintenance.Procedures[renewNatsCert]
+	want := map[string]string{
+		"add-new-ca":             StepSucceeded,
+		"recreate-vms-first":     StepSucceeded,
+		"remove-old-ca":          StepPending,
+		"recreate-vms-second":    StepPending,
+		"director-creds-cleanup": StepPending,
+	}
+	if got := stepStatuses(run); !reflect.DeepEqual(got, want) {
+		t.Errorf("migrated step statuses = %v, want %v", got, want)
+	}
+	if maintenance.StatusIndex != nil {
+		t.Errorf("legacy status index not cleared")
+	}
+}
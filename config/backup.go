@@ -0,0 +1,330 @@
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const backupManifestFilename = "manifest.json"
+
+// backupMagic identifies a control-tower backup file and its format version
+var backupMagic = []byte("CTBACKUP1")
+
+const (
+	backupSaltSize = 16
+	backupKeySize  = 32
+	backupScryptN  = 32768
+	backupScryptR  = 8
+	backupScryptP  = 1
+)
+
+// BackupAssets are the config bucket assets which together make up the state of a deployment.
+// Only the terraform state file written by the backend of the deployment is present.
+var BackupAssets = []string{
+	configFilePath,
+	"director-state.json",
+	"director-creds.yml",
+	terraformStateFileName,
+	gcsStateFileName,
+	"maintenance.json",
+}
+
+// requiredBackupAssets returns the assets without which a backup can't be restored
+func requiredBackupAssets(backend StateBackend) []string {
+	stateFile := terraformStateFileName
+	if backend.Type == StateStoreGCS {
+		stateFile = gcsStateFileName
+	}
+	return []string{configFilePath, "director-state.json", "director-creds.yml", stateFile}
+}
+
+// BackupManifest describes the contents of a backup
+type BackupManifest struct {
+	Project   string            `json:"project"`
+	Namespace string            `json:"namespace"`
+	CreatedAt time.Time         `json:"created_at"`
+	Checksums map[string]string `json:"checksums"`
+}
+
+// Backup reads every state asset present in the config bucket and returns them
+// as a gzipped tarball encrypted with a key derived from passphrase
+func Backup(client IClient, project, namespace, passphrase string) ([]byte, BackupManifest, error) {
+	manifest := BackupManifest{
+		Project:   project,
+		Namespace: namespace,
+		CreatedAt: time.Now().UTC(),
+		Checksums: map[string]string{},
+	}
+
+	exists, err := client.ConfigExists()
+	if err != nil {
+		return nil, manifest, fmt.Errorf("error determining if config exists: [%v]", err)
+	}
+	if !exists {
+		return nil, manifest, errors.New("no config found for this deployment, nothing to back up")
+	}
+
+	required := requiredBackupAssets(client.StateBackend())
+	assets := map[string][]byte{}
+	for _, name := range BackupAssets {
+		hasAsset, err := client.HasAsset(name)
+		if err != nil {
+			return nil, manifest, fmt.Errorf("error checking for asset [%s]: [%v]", name, err)
+		}
+		if !hasAsset {
+			if contains(required, name) {
+				return nil, manifest, fmt.Errorf("deployment is missing [%s], refusing to create a backup which can't be restored", name)
+			}
+			continue
+		}
+		contents, err := client.LoadAsset(name)
+		if err != nil {
+			return nil, manifest, fmt.Errorf("error loading asset [%s]: [%v]", name, err)
+		}
+		assets[name] = contents
+		manifest.Checksums[name] = checksum(contents)
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, manifest, err
+	}
+
+	var archive bytes.Buffer
+	gw := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gw)
+	if err = writeTarEntry(tw, backupManifestFilename, manifestBytes); err != nil {
+		return nil, manifest, err
+	}
+	for _, name := range BackupAssets {
+		contents, ok := assets[name]
+		if !ok {
+			continue
+		}
+		if err = writeTarEntry(tw, name, contents); err != nil {
+			return nil, manifest, err
+		}
+	}
+	if err = tw.Close(); err != nil {
+		return nil, manifest, err
+	}
+	if err = gw.Close(); err != nil {
+		return nil, manifest, err
+	}
+
+	encrypted, err := encryptBackup(archive.Bytes(), passphrase)
+	if err != nil {
+		return nil, manifest, err
+	}
+
+	return encrypted, manifest, nil
+}
+
+// Restore decrypts a backup created by Backup, verifies the checksum of every asset
+// against the backup manifest and then writes the assets back to the config bucket.
+// Backups can only be restored to a deployment with the same project name.
+func Restore(client IClient, backup []byte, project, passphrase string) (BackupManifest, error) {
+	archive, err := decryptBackup(backup, passphrase)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+
+	manifest, assets, err := readBackupArchive(archive)
+	if err != nil {
+		return manifest, err
+	}
+
+	if manifest.Project != project {
+		return manifest, fmt.Errorf("backup is of deployment [%s], refusing to restore it to [%s]", manifest.Project, project)
+	}
+
+	if _, ok := assets[configFilePath]; !ok {
+		return manifest, fmt.Errorf("backup does not contain [%s]", configFilePath)
+	}
+
+	if err = client.EnsureBucketExists(); err != nil {
+		return manifest, fmt.Errorf("error ensuring config bucket exists: [%v]", err)
+	}
+
+	// config.json is written last so that an interrupted restore is not mistaken for a complete deployment
+	for _, name := range BackupAssets {
+		contents, ok := assets[name]
+		if !ok || name == configFilePath {
+			continue
+		}
+		if err = client.StoreAsset(name, contents); err != nil {
+			return manifest, fmt.Errorf("error storing asset [%s]: [%v]", name, err)
+		}
+	}
+	if err = client.StoreAsset(configFilePath, assets[configFilePath]); err != nil {
+		return manifest, fmt.Errorf("error storing asset [%s]: [%v]", configFilePath, err)
+	}
+
+	return manifest, nil
+}
+
+func readBackupArchive(archive []byte) (BackupManifest, map[string][]byte, error) {
+	var manifest BackupManifest
+	var manifestFound bool
+
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return manifest, nil, fmt.Errorf("backup is not a valid archive: [%v]", err)
+	}
+	tr := tar.NewReader(gr)
+
+	assets := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return manifest, nil, fmt.Errorf("backup is not a valid archive: [%v]", err)
+		}
+		contents, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return manifest, nil, err
+		}
+		if header.Name == backupManifestFilename {
+			if err = json.Unmarshal(contents, &manifest); err != nil {
+				return manifest, nil, fmt.Errorf("backup manifest is invalid: [%v]", err)
+			}
+			manifestFound = true
+			continue
+		}
+		if !isBackupAsset(header.Name) {
+			return manifest, nil, fmt.Errorf("backup contains unexpected file [%s]", header.Name)
+		}
+		assets[header.Name] = contents
+	}
+
+	if !manifestFound {
+		return manifest, nil, errors.New("backup does not contain a manifest")
+	}
+
+	for name, sum := range manifest.Checksums {
+		contents, ok := assets[name]
+		if !ok {
+			return manifest, nil, fmt.Errorf("backup is missing [%s] listed in its manifest", name)
+		}
+		if checksum(contents) != sum {
+			return manifest, nil, fmt.Errorf("checksum mismatch for [%s]", name)
+		}
+	}
+	for name := range assets {
+		if _, ok := manifest.Checksums[name]; !ok {
+			return manifest, nil, fmt.Errorf("backup contains [%s] which is not listed in its manifest", name)
+		}
+	}
+
+	return manifest, assets, nil
+}
+
+func encryptBackup(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, backupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte{}, backupMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plaintext, backupMagic), nil
+}
+
+func decryptBackup(data []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(data, backupMagic) {
+		return nil, errors.New("file is not a control-tower backup")
+	}
+	data = data[len(backupMagic):]
+	if len(data) < backupSaltSize {
+		return nil, errors.New("backup is truncated")
+	}
+	salt, data := data[:backupSaltSize], data[backupSaltSize:]
+
+	gcm, err := backupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("backup is truncated")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, backupMagic)
+	if err != nil {
+		return nil, errors.New("failed to decrypt backup, the passphrase is wrong or the file is corrupt")
+	}
+	return plaintext, nil
+}
+
+func backupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errors.New("a passphrase is required")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, backupScryptN, backupScryptR, backupScryptP, backupKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func writeTarEntry(tw *tar.Writer, name string, contents []byte) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0600,
+		Size: int64(len(contents)),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(contents)
+	return err
+}
+
+func isBackupAsset(name string) bool {
+	return contains(BackupAssets, name)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func checksum(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
+}
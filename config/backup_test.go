@@ -0,0 +1,137 @@
+package config_test
+
+import (
+	"errors"
+
+	. "github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/config/configfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backup and Restore", func() {
+	var source *configfakes.FakeIClient
+	var target *configfakes.FakeIClient
+	var sourceAssets map[string][]byte
+	var restoredAssets map[string][]byte
+	var storeOrder []string
+
+	BeforeEach(func() {
+		sourceAssets = map[string][]byte{
+			"config.json":         []byte(`{"project":"happymeal"}`),
+			"director-state.json": []byte(`{"state":"yes"}`),
+			"director-creds.yml":  []byte("creds: secret"),
+			"terraform.tfstate":   []byte(`{"version":4}`),
+		}
+		source = &configfakes.FakeIClient{}
+		source.ConfigExistsReturns(true, nil)
+		source.StateBackendReturns(StateBackend{Type: StateStoreS3, Region: "eu-west-1"})
+		source.HasAssetStub = func(name string) (bool, error) {
+			_, ok := sourceAssets[name]
+			return ok, nil
+		}
+		source.LoadAssetStub = func(name string) ([]byte, error) {
+			return sourceAssets[name], nil
+		}
+
+		restoredAssets = map[string][]byte{}
+		storeOrder = nil
+		target = &configfakes.FakeIClient{}
+		target.StoreAssetStub = func(name string, contents []byte) error {
+			restoredAssets[name] = contents
+			storeOrder = append(storeOrder, name)
+			return nil
+		}
+	})
+
+	It("round trips every asset present in the config bucket", func() {
+		backup, manifest, err := Backup(source, "happymeal", "eu-west-1", "hunter2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manifest.Checksums).To(HaveLen(4))
+		Expect(manifest.Checksums).ToNot(HaveKey("maintenance.json"))
+		Expect(string(backup)).ToNot(ContainSubstring("creds: secret"))
+
+		restoredManifest, err := Restore(target, backup, "happymeal", "hunter2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(restoredManifest.Project).To(Equal("happymeal"))
+		Expect(restoredManifest.Namespace).To(Equal("eu-west-1"))
+		Expect(restoredAssets).To(Equal(sourceAssets))
+		Expect(target.EnsureBucketExistsCallCount()).To(Equal(1))
+		Expect(storeOrder[len(storeOrder)-1]).To(Equal("config.json"))
+	})
+
+	It("backs up the terraform state of the gcs backend", func() {
+		source.StateBackendReturns(StateBackend{Type: StateStoreGCS, Region: "europe-west1"})
+		sourceAssets["default.tfstate"] = sourceAssets["terraform.tfstate"]
+		delete(sourceAssets, "terraform.tfstate")
+
+		backup, manifest, err := Backup(source, "happymeal", "europe-west1", "hunter2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manifest.Checksums).To(HaveKey("default.tfstate"))
+
+		_, err = Restore(target, backup, "happymeal", "hunter2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(restoredAssets).To(Equal(sourceAssets))
+	})
+
+	It("refuses to back up a deployment which is missing its terraform state", func() {
+		source.StateBackendReturns(StateBackend{Type: StateStoreGCS, Region: "europe-west1"})
+
+		_, _, err := Backup(source, "happymeal", "europe-west1", "hunter2")
+		Expect(err).To(MatchError("deployment is missing [default.tfstate], refusing to create a backup which can't be restored"))
+	})
+
+	It("refuses to back up a deployment without a config", func() {
+		source.ConfigExistsReturns(false, nil)
+
+		_, _, err := Backup(source, "happymeal", "eu-west-1", "hunter2")
+		Expect(err).To(MatchError("no config found for this deployment, nothing to back up"))
+	})
+
+	It("returns an error when an asset cannot be loaded", func() {
+		source.LoadAssetStub = func(name string) ([]byte, error) {
+			return nil, errors.New("access denied")
+		}
+
+		_, _, err := Backup(source, "happymeal", "eu-west-1", "hunter2")
+		Expect(err).To(MatchError(ContainSubstring("access denied")))
+	})
+
+	It("does not restore anything when the passphrase is wrong", func() {
+		backup, _, err := Backup(source, "happymeal", "eu-west-1", "hunter2")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = Restore(target, backup, "happymeal", "wrong")
+		Expect(err).To(MatchError("failed to decrypt backup, the passphrase is wrong or the file is corrupt"))
+		Expect(target.StoreAssetCallCount()).To(Equal(0))
+	})
+
+	It("does not restore anything when the backup has been tampered with", func() {
+		backup, _, err := Backup(source, "happymeal", "eu-west-1", "hunter2")
+		Expect(err).ToNot(HaveOccurred())
+		backup[len(backup)-1] ^= 0xff
+
+		_, err = Restore(target, backup, "happymeal", "hunter2")
+		Expect(err).To(HaveOccurred())
+		Expect(target.StoreAssetCallCount()).To(Equal(0))
+	})
+
+	It("refuses to restore a backup to a differently named deployment", func() {
+		backup, _, err := Backup(source, "happymeal", "eu-west-1", "hunter2")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = Restore(target, backup, "other", "hunter2")
+		Expect(err).To(MatchError("backup is of deployment [happymeal], refusing to restore it to [other]"))
+		Expect(target.StoreAssetCallCount()).To(Equal(0))
+	})
+
+	It("rejects files which are not backups", func() {
+		_, err := Restore(target, []byte("not a backup"), "happymeal", "hunter2")
+		Expect(err).To(MatchError("file is not a control-tower backup"))
+	})
+
+	It("requires a passphrase", func() {
+		_, _, err := Backup(source, "happymeal", "eu-west-1", "")
+		Expect(err).To(MatchError("a passphrase is required"))
+	})
+})
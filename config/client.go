@@ -10,9 +10,12 @@ import (
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 const terraformStateFileName = "terraform.tfstate"
+
+// gcsStateFileName is where the gcs terraform backend keeps state, as it is configured without a prefix
+const gcsStateFileName = "default.tfstate"
 const configFilePath = "config.json"
 
-//counterfeiter: generate . IClient
+// counterfeiter: generate . IClient
 type IClient interface {
 	Load() (Config, error)
 	DeleteAll(config ConfigView) error
@@ -21,8 +24,10 @@ type IClient interface {
 	HasAsset(filename string) (bool, error)
 	ConfigExists() (bool, error)
 	LoadAsset(filename string) ([]byte, error)
+	DeleteAsset(filename string) error
 	NewConfig() Config
 	EnsureBucketExists() error
+	StateBackend() StateBackend
 }
 
 // Client is a client for loading the config file  from S3
@@ -33,26 +38,32 @@ type Client struct {
 	BucketName   string
 	BucketExists bool
 	BucketError  error
+	// Store holds the state of the deployment, defaulting to the buckets of Iaas when nil
+	Store StateStore
 }
 
-// New instantiates a new client
+// New instantiates a new client which keeps state in the buckets of the IAAS provider
 func New(iaas iaas.Provider, project, namespace string) *Client {
+	return NewWithStateStore(iaas, nil, project, namespace)
+}
+
+// NewWithStateStore instantiates a new client which keeps state in the given state store
+func NewWithStateStore(iaas iaas.Provider, store StateStore, project, namespace string) *Client {
 	namespace = determineNamespace(namespace, iaas.Region())
-	bucketName, exists, err := determineBucketName(iaas, namespace, project)
-
-	return &Client{
-		iaas,
-		project,
-		namespace,
-		bucketName,
-		exists,
-		err,
+	client := &Client{
+		Iaas:      iaas,
+		Project:   project,
+		Namespace: namespace,
+		Store:     store,
 	}
+	client.BucketName, client.BucketExists, client.BucketError = determineBucketName(client.stateStore(), iaas.Region(), namespace, project)
+
+	return client
 }
 
 // StoreAsset stores an associated configuration file
 func (client *Client) StoreAsset(filename string, contents []byte) error {
-	return client.Iaas.WriteFile(client.configBucket(),
+	return client.stateStore().WriteFile(client.configBucket(),
 		filename,
 		contents,
 	)
@@ -60,7 +71,7 @@ func (client *Client) StoreAsset(filename string, contents []byte) error {
 
 // LoadAsset loads an associated configuration file
 func (client *Client) LoadAsset(filename string) ([]byte, error) {
-	return client.Iaas.LoadFile(
+	return client.stateStore().LoadFile(
 		client.configBucket(),
 		filename,
 	)
@@ -68,7 +79,15 @@ func (client *Client) LoadAsset(filename string) ([]byte, error) {
 
 // HasAsset returns true if an associated configuration file exists
 func (client *Client) HasAsset(filename string) (bool, error) {
-	return client.Iaas.HasFile(
+	return client.stateStore().HasFile(
+		client.configBucket(),
+		filename,
+	)
+}
+
+// DeleteAsset deletes an associated configuration file
+func (client *Client) DeleteAsset(filename string) error {
+	return client.stateStore().DeleteFile(
 		client.configBucket(),
 		filename,
 	)
@@ -86,21 +105,22 @@ func (client *Client) Update(config Config) error {
 		return err
 	}
 
-	return client.Iaas.WriteFile(client.configBucket(), configFilePath, bytes)
+	return client.stateStore().WriteFile(client.configBucket(), configFilePath, bytes)
 }
 
 // DeleteAll deletes the entire configuration bucket
 func (client *Client) DeleteAll(config ConfigView) error {
-	return client.Iaas.DeleteVersionedBucket(config.GetConfigBucket())
+	return client.stateStore().DeleteBucket(config.GetConfigBucket())
 }
 
-// Load loads an existing config file from S3
+// Load loads an existing config file from S3, migrating it to the current schema in memory.
+// The migrated config is only saved by the commands which go on to change the deployment, when they Update it.
 func (client *Client) Load() (Config, error) {
 	if client.BucketError != nil {
 		return Config{}, client.BucketError
 	}
 
-	configBytes, err := client.Iaas.LoadFile(
+	configBytes, err := client.stateStore().LoadFile(
 		client.configBucket(),
 		configFilePath,
 	)
@@ -113,19 +133,23 @@ func (client *Client) Load() (Config, error) {
 		return Config{}, err
 	}
 
-	conf = populateMandatoryFieldsAddedSinceLastSave(conf)
+	conf, _, err = Migrate(conf)
+	if err != nil {
+		return Config{}, err
+	}
 
 	return conf, nil
 }
 
 func (client *Client) NewConfig() Config {
 	return Config{
-		ConfigBucket: client.configBucket(),
-		Deployment:   deployment(client.Project),
-		Namespace:    client.Namespace,
-		Project:      client.Project,
-		Region:       client.Iaas.Region(),
-		TFStatePath:  terraformStateFileName,
+		ConfigBucket:  client.configBucket(),
+		Deployment:    deployment(client.Project),
+		Namespace:     client.Namespace,
+		Project:       client.Project,
+		Region:        client.Iaas.Region(),
+		SchemaVersion: CurrentSchemaVersion(),
+		TFStatePath:   terraformStateFileName,
 	}
 }
 
@@ -134,14 +158,14 @@ func (client *Client) EnsureBucketExists() error {
 		return fmt.Errorf("client failed to configure properly: [%v]", client.BucketError)
 	}
 
-	exists, err := client.Iaas.BucketExists(client.BucketName)
+	exists, err := client.stateStore().BucketExists(client.BucketName)
 
 	if err != nil {
 		return fmt.Errorf("error determining if bucket [%v] exists: [%v]", client.BucketName, err)
 	}
 
 	if !exists {
-		err = client.Iaas.CreateBucket(client.BucketName)
+		err = client.stateStore().CreateBucket(client.BucketName)
 
 		if err != nil {
 			return fmt.Errorf("error creating config bucket [%v]: [%v]", client.BucketName, err)
@@ -151,10 +175,22 @@ func (client *Client) EnsureBucketExists() error {
 	return nil
 }
 
+// StateBackend returns the terraform backend which keeps state alongside the config
+func (client *Client) StateBackend() StateBackend {
+	return client.stateStore().Backend(client.configBucket())
+}
+
 func (client *Client) configBucket() string {
 	return client.BucketName
 }
 
+func (client *Client) stateStore() StateStore {
+	if client.Store == nil {
+		return NewBucketStateStore(client.Iaas)
+	}
+	return client.Store
+}
+
 func deployment(project string) string {
 	return fmt.Sprintf("control-tower-%s", project)
 }
@@ -163,14 +199,14 @@ func createBucketName(deployment, extension string) string {
 	return fmt.Sprintf("%s-%s-config", deployment, extension)
 }
 
-func determineBucketName(iaas iaas.Provider, namespace, project string) (string, bool, error) {
-	regionBucketName := createBucketName(deployment(project), iaas.Region())
+func determineBucketName(store StateStore, region, namespace, project string) (string, bool, error) {
+	regionBucketName := createBucketName(deployment(project), region)
 	namespaceBucketName := createBucketName(deployment(project), namespace)
 
-	foundRegionNamedBucket, err := iaas.BucketExists(regionBucketName)
+	foundRegionNamedBucket, err := store.BucketExists(regionBucketName)
 	var foundNamespacedBucket bool
 	if err != nil {
-		foundNamespacedBucket, err = iaas.BucketExists(namespaceBucketName)
+		foundNamespacedBucket, err = store.BucketExists(namespaceBucketName)
 		if err != nil {
 			return "", false, fmt.Errorf("error looking for possible config buckets [%v] or [%v]: [%v]", regionBucketName, namespaceBucketName, err)
 		}
@@ -194,12 +230,3 @@ func determineNamespace(namespace, region string) string {
 	}
 	return namespace
 }
-
-// Allow new mandatory fields to be populated based on old fields that are now deprecated
-func populateMandatoryFieldsAddedSinceLastSave(oldConf Config) Config {
-	if oldConf.VMProvisioningType == "" {
-		oldConf.VMProvisioningType = ConvertSpotBoolToVMProvisioningType(oldConf.Spot)
-	}
-
-	return oldConf
-}
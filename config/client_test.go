@@ -277,6 +277,11 @@ func TestClient_Load(t *testing.T) {
 			want: Config{
 				Spot:               true,
 				VMProvisioningType: SPOT,
+				PersistentDisk:     "default",
+				SchemaVersion:      CurrentSchemaVersion(),
+				TFStatePath:        "terraform.tfstate",
+				WorkerType:         "m4",
+				MetricsBackend:     MetricsBackendInfluxDB,
 			},
 			wantErr: false,
 		},
@@ -343,6 +348,11 @@ func TestClient_HasConfig(t *testing.T) {
 			},
 			want: Config{
 				VMProvisioningType: ON_DEMAND,
+				PersistentDisk:     "default",
+				SchemaVersion:      CurrentSchemaVersion(),
+				TFStatePath:        "terraform.tfstate",
+				WorkerType:         "m4",
+				MetricsBackend:     MetricsBackendInfluxDB,
 			},
 			wantErr: false,
 		},
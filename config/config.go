@@ -3,6 +3,13 @@ package config
 const SPOT = "spot"
 const ON_DEMAND = "on-demand"
 
+// Metrics backends which can be deployed colocated on the web VM
+const (
+	MetricsBackendPrometheus = "prometheus"
+	MetricsBackendInfluxDB   = "influxdb"
+	MetricsBackendNone       = "none"
+)
+
 func ConvertSpotBoolToVMProvisioningType(spot bool) string {
 	if spot {
 		return SPOT
@@ -17,69 +24,76 @@ type Config struct {
 	AllowIPsUnformatted      string `json:"allow_ips_unformatted"`
 	AvailabilityZone         string `json:"availability_zone"`
 	BitbucketClientID        string `json:"bitbucket_client_id"`
-	BitbucketClientSecret    string `json:"bitbucket_client_secret"`
+	BitbucketClientSecret    string `json:"bitbucket_client_secret" secret:"true"`
 	ConcourseCACert          string `json:"concourse_ca_cert"`
 	ConcourseCert            string `json:"concourse_cert"`
-	ConcourseKey             string `json:"concourse_key"`
-	ConcoursePassword        string `json:"concourse_password"`
+	ConcourseKey             string `json:"concourse_key" secret:"true"`
+	ConcoursePassword        string `json:"concourse_password" secret:"true"`
 	ConcourseUsername        string `json:"concourse_username"`
 	ConcourseWebSize         string `json:"concourse_web_size"`
 	ConcourseWorkerCount     int    `json:"concourse_worker_count"`
 	ConcourseWorkerSize      string `json:"concourse_worker_size"`
 	ConfigBucket             string `json:"config_bucket"`
-	CredhubAdminClientSecret string `json:"credhub_admin_client_secret"`
+	CredhubAdminClientSecret string `json:"credhub_admin_client_secret" secret:"true"`
 	CredhubCACert            string `json:"credhub_ca_cert"`
-	CredhubPassword          string `json:"credhub_password"`
+	CredhubPassword          string `json:"credhub_password" secret:"true"`
 	CredhubURL               string `json:"credhub_url"`
 	CredhubUsername          string `json:"credhub_username"`
+	DBSnapshotID             string `json:"db_snapshot_id,omitempty"`
 	Deployment               string `json:"deployment"`
 	DirectorCACert           string `json:"director_ca_cert"`
 	DirectorCert             string `json:"director_cert"`
-	DirectorHMUserPassword   string `json:"director_hm_user_password"`
-	DirectorKey              string `json:"director_key"`
-	DirectorMbusPassword     string `json:"director_mbus_password"`
-	DirectorNATSPassword     string `json:"director_nats_password"`
-	DirectorPassword         string `json:"director_password"`
+	DirectorHMUserPassword   string `json:"director_hm_user_password" secret:"true"`
+	DirectorKey              string `json:"director_key" secret:"true"`
+	DirectorMbusPassword     string `json:"director_mbus_password" secret:"true"`
+	DirectorNATSPassword     string `json:"director_nats_password" secret:"true"`
+	DirectorPassword         string `json:"director_password" secret:"true"`
 	DirectorPublicIP         string `json:"director_public_ip"`
-	DirectorRegistryPassword string `json:"director_registry_password"`
+	DirectorRegistryPassword string `json:"director_registry_password" secret:"true"`
 	DirectorUsername         string `json:"director_username"`
 	Domain                   string `json:"domain"`
 	EnableGlobalResources    bool   `json:"enable_global_resources"`
 	EnablePipelineInstances  bool   `json:"enable_pipeline_instances"`
 	InfluxDbRetention        string `json:"influx_db_retention_period"`
-	EncryptionKey            string `json:"encryption_key"`
+	EncryptionKey            string `json:"encryption_key" secret:"true"`
 	GithubClientID           string `json:"github_client_id"`
-	GithubClientSecret       string `json:"github_client_secret"`
+	GithubClientSecret       string `json:"github_client_secret" secret:"true"`
 	GithubHost               string `json:"github_host"`
 	GithubCaCert             string `json:"github_ca_cert"`
-	GrafanaPassword          string `json:"grafana_password"`
+	GrafanaPassword          string `json:"grafana_password" secret:"true"`
 	HostedZoneID             string `json:"hosted_zone_id"`
 	HostedZoneRecordPrefix   string `json:"hosted_zone_record_prefix"`
 	IAAS                     string `json:"iaas"`
 	MainGithubUsers          string `json:"main_github_users"`
 	MainGithubTeams          string `json:"main_github_teams"`
 	MainGithubOrgs           string `json:"main_github_orgs"`
+	MetricsBackend           string `json:"metrics_backend"`
 	MicrosoftClientID        string `json:"microsoft_client_id"`
-	MicrosoftClientSecret    string `json:"microsoft_client_secret"`
+	MicrosoftClientSecret    string `json:"microsoft_client_secret" secret:"true"`
 	MicrosoftTenant          string `json:"microsoft_tenant"`
 	Namespace                string `json:"namespace"`
 	NetworkCIDR              string `json:"network_cidr"`
 	NoMetrics                bool   `json:"no_metrics"`
+	OldEncryptionKey         string `json:"old_encryption_key,omitempty" secret:"true"`
 	PersistentDisk           string `json:"persistent_disk"`
 	PrivateCIDR              string `json:"private_cidr"`
-	PrivateKey               string `json:"private_key"`
+	PrivateKey               string `json:"private_key" secret:"true"`
 	Project                  string `json:"project"`
+	PrometheusRetention      string `json:"prometheus_retention_period"`
 	PublicCIDR               string `json:"public_cidr"`
 	PublicKey                string `json:"public_key"`
 	RDS1CIDR                 string `json:"rds1_cidr"`
 	RDS2CIDR                 string `json:"rds2_cidr"`
 	RDSDefaultDatabaseName   string `json:"rds_default_database_name"`
 	RDSInstanceClass         string `json:"rds_instance_class"`
-	RDSPassword              string `json:"rds_password"`
+	RDSPassword              string `json:"rds_password" secret:"true"`
 	RDSUsername              string `json:"rds_username"`
 	RDSDiskEncryption        bool   `json:"rds_disk_encryption"`
 	Region                   string `json:"region"`
+	SchemaVersion            int    `json:"schema_version"`
 	SourceAccessIP           string `json:"source_access_ip"`
+	StateStore               string `json:"state_store"`
+	StateStoreLocation       string `json:"state_store_location"`
 	//Spot is deprecated, exists only as we need to migrate old configs to VMProvisioningType
 	Spot               bool     `json:"spot"`
 	Tags               []string `json:"tags"`
@@ -109,6 +123,7 @@ type ConfigView interface {
 	GetCredhubPassword() string
 	GetCredhubURL() string
 	GetCredhubUsername() string
+	GetDBSnapshotID() string
 	GetDeployment() string
 	GetDirectorCACert() string
 	GetDirectorCert() string
@@ -136,15 +151,18 @@ type ConfigView interface {
 	GetMainGithubUsers() string
 	GetMainGithubTeams() string
 	GetMainGithubOrgs() string
+	GetMetricsBackend() string
 	GetMicrosoftClientID() string
 	GetMicrosoftClientSecret() string
 	GetMicrosoftTenant() string
 	GetNamespace() string
 	GetNetworkCIDR() string
+	GetOldEncryptionKey() string
 	GetPersistentDiskSize() string
 	GetPrivateCIDR() string
 	GetPrivateKey() string
 	GetProject() string
+	GetPrometheusRetention() string
 	GetPublicCIDR() string
 	GetPublicKey() string
 	GetRDS1CIDR() string
@@ -156,6 +174,8 @@ type ConfigView interface {
 	GetRDSDiskEncryption() bool
 	GetRegion() string
 	GetSourceAccessIP() string
+	GetStateStore() string
+	GetStateStoreLocation() string
 	GetTags() []string
 	GetTFStatePath() string
 	GetVersion() string
@@ -249,6 +269,10 @@ func (c Config) GetCredhubUsername() string {
 	return c.CredhubUsername
 }
 
+func (c Config) GetDBSnapshotID() string {
+	return c.DBSnapshotID
+}
+
 func (c Config) GetDeployment() string {
 	return c.Deployment
 }
@@ -357,6 +381,10 @@ func (c Config) GetMainGithubOrgs() string {
 	return c.MainGithubOrgs
 }
 
+func (c Config) GetMetricsBackend() string {
+	return c.MetricsBackend
+}
+
 func (c Config) GetMicrosoftClientID() string {
 	return c.MicrosoftClientID
 }
@@ -377,6 +405,10 @@ func (c Config) GetNetworkCIDR() string {
 	return c.NetworkCIDR
 }
 
+func (c Config) GetOldEncryptionKey() string {
+	return c.OldEncryptionKey
+}
+
 func (c Config) GetPrivateCIDR() string {
 	return c.PrivateCIDR
 }
@@ -389,6 +421,10 @@ func (c Config) GetProject() string {
 	return c.Project
 }
 
+func (c Config) GetPrometheusRetention() string {
+	return c.PrometheusRetention
+}
+
 func (c Config) GetPublicCIDR() string {
 	return c.PublicCIDR
 }
@@ -433,6 +469,14 @@ func (c Config) GetSourceAccessIP() string {
 	return c.SourceAccessIP
 }
 
+func (c Config) GetStateStore() string {
+	return c.StateStore
+}
+
+func (c Config) GetStateStoreLocation() string {
+	return c.StateStoreLocation
+}
+
 func (c Config) GetTags() []string {
 	return c.Tags
 }
@@ -474,5 +518,5 @@ func (c Config) IsSpot() bool {
 }
 
 func (c Config) MetricsIsDisabled() bool {
-	return c.NoMetrics
+	return c.MetricsBackend == MetricsBackendNone
 }
@@ -31,6 +31,17 @@ type FakeIClient struct {
 	deleteAllReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteAssetStub        func(string) error
+	deleteAssetMutex       sync.RWMutex
+	deleteAssetArgsForCall []struct {
+		arg1 string
+	}
+	deleteAssetReturns struct {
+		result1 error
+	}
+	deleteAssetReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EnsureBucketExistsStub        func() error
 	ensureBucketExistsMutex       sync.RWMutex
 	ensureBucketExistsArgsForCall []struct {
@@ -89,6 +100,16 @@ type FakeIClient struct {
 	newConfigReturnsOnCall map[int]struct {
 		result1 config.Config
 	}
+	StateBackendStub        func() config.StateBackend
+	stateBackendMutex       sync.RWMutex
+	stateBackendArgsForCall []struct {
+	}
+	stateBackendReturns struct {
+		result1 config.StateBackend
+	}
+	stateBackendReturnsOnCall map[int]struct {
+		result1 config.StateBackend
+	}
 	StoreAssetStub        func(string, []byte) error
 	storeAssetMutex       sync.RWMutex
 	storeAssetArgsForCall []struct {
@@ -121,15 +142,16 @@ func (fake *FakeIClient) ConfigExists() (bool, error) {
 	ret, specificReturn := fake.configExistsReturnsOnCall[len(fake.configExistsArgsForCall)]
 	fake.configExistsArgsForCall = append(fake.configExistsArgsForCall, struct {
 	}{})
+	stub := fake.ConfigExistsStub
+	fakeReturns := fake.configExistsReturns
 	fake.recordInvocation("ConfigExists", []interface{}{})
 	fake.configExistsMutex.Unlock()
-	if fake.ConfigExistsStub != nil {
-		return fake.ConfigExistsStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.configExistsReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -177,15 +199,16 @@ func (fake *FakeIClient) DeleteAll(arg1 config.ConfigView) error {
 	fake.deleteAllArgsForCall = append(fake.deleteAllArgsForCall, struct {
 		arg1 config.ConfigView
 	}{arg1})
+	stub := fake.DeleteAllStub
+	fakeReturns := fake.deleteAllReturns
 	fake.recordInvocation("DeleteAll", []interface{}{arg1})
 	fake.deleteAllMutex.Unlock()
-	if fake.DeleteAllStub != nil {
-		return fake.DeleteAllStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.deleteAllReturns
 	return fakeReturns.result1
 }
 
@@ -231,20 +254,82 @@ func (fake *FakeIClient) DeleteAllReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIClient) DeleteAsset(arg1 string) error {
+	fake.deleteAssetMutex.Lock()
+	ret, specificReturn := fake.deleteAssetReturnsOnCall[len(fake.deleteAssetArgsForCall)]
+	fake.deleteAssetArgsForCall = append(fake.deleteAssetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteAssetStub
+	fakeReturns := fake.deleteAssetReturns
+	fake.recordInvocation("DeleteAsset", []interface{}{arg1})
+	fake.deleteAssetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) DeleteAssetCallCount() int {
+	fake.deleteAssetMutex.RLock()
+	defer fake.deleteAssetMutex.RUnlock()
+	return len(fake.deleteAssetArgsForCall)
+}
+
+func (fake *FakeIClient) DeleteAssetCalls(stub func(string) error) {
+	fake.deleteAssetMutex.Lock()
+	defer fake.deleteAssetMutex.Unlock()
+	fake.DeleteAssetStub = stub
+}
+
+func (fake *FakeIClient) DeleteAssetArgsForCall(i int) string {
+	fake.deleteAssetMutex.RLock()
+	defer fake.deleteAssetMutex.RUnlock()
+	argsForCall := fake.deleteAssetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) DeleteAssetReturns(result1 error) {
+	fake.deleteAssetMutex.Lock()
+	defer fake.deleteAssetMutex.Unlock()
+	fake.DeleteAssetStub = nil
+	fake.deleteAssetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) DeleteAssetReturnsOnCall(i int, result1 error) {
+	fake.deleteAssetMutex.Lock()
+	defer fake.deleteAssetMutex.Unlock()
+	fake.DeleteAssetStub = nil
+	if fake.deleteAssetReturnsOnCall == nil {
+		fake.deleteAssetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAssetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIClient) EnsureBucketExists() error {
 	fake.ensureBucketExistsMutex.Lock()
 	ret, specificReturn := fake.ensureBucketExistsReturnsOnCall[len(fake.ensureBucketExistsArgsForCall)]
 	fake.ensureBucketExistsArgsForCall = append(fake.ensureBucketExistsArgsForCall, struct {
 	}{})
+	stub := fake.EnsureBucketExistsStub
+	fakeReturns := fake.ensureBucketExistsReturns
 	fake.recordInvocation("EnsureBucketExists", []interface{}{})
 	fake.ensureBucketExistsMutex.Unlock()
-	if fake.EnsureBucketExistsStub != nil {
-		return fake.EnsureBucketExistsStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.ensureBucketExistsReturns
 	return fakeReturns.result1
 }
 
@@ -289,15 +374,16 @@ func (fake *FakeIClient) HasAsset(arg1 string) (bool, error) {
 	fake.hasAssetArgsForCall = append(fake.hasAssetArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.HasAssetStub
+	fakeReturns := fake.hasAssetReturns
 	fake.recordInvocation("HasAsset", []interface{}{arg1})
 	fake.hasAssetMutex.Unlock()
-	if fake.HasAssetStub != nil {
-		return fake.HasAssetStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.hasAssetReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -351,15 +437,16 @@ func (fake *FakeIClient) Load() (config.Config, error) {
 	ret, specificReturn := fake.loadReturnsOnCall[len(fake.loadArgsForCall)]
 	fake.loadArgsForCall = append(fake.loadArgsForCall, struct {
 	}{})
+	stub := fake.LoadStub
+	fakeReturns := fake.loadReturns
 	fake.recordInvocation("Load", []interface{}{})
 	fake.loadMutex.Unlock()
-	if fake.LoadStub != nil {
-		return fake.LoadStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.loadReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -407,15 +494,16 @@ func (fake *FakeIClient) LoadAsset(arg1 string) ([]byte, error) {
 	fake.loadAssetArgsForCall = append(fake.loadAssetArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.LoadAssetStub
+	fakeReturns := fake.loadAssetReturns
 	fake.recordInvocation("LoadAsset", []interface{}{arg1})
 	fake.loadAssetMutex.Unlock()
-	if fake.LoadAssetStub != nil {
-		return fake.LoadAssetStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.loadAssetReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -469,15 +557,16 @@ func (fake *FakeIClient) NewConfig() config.Config {
 	ret, specificReturn := fake.newConfigReturnsOnCall[len(fake.newConfigArgsForCall)]
 	fake.newConfigArgsForCall = append(fake.newConfigArgsForCall, struct {
 	}{})
+	stub := fake.NewConfigStub
+	fakeReturns := fake.newConfigReturns
 	fake.recordInvocation("NewConfig", []interface{}{})
 	fake.newConfigMutex.Unlock()
-	if fake.NewConfigStub != nil {
-		return fake.NewConfigStub()
+	if stub != nil {
+		return stub()
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.newConfigReturns
 	return fakeReturns.result1
 }
 
@@ -516,6 +605,59 @@ func (fake *FakeIClient) NewConfigReturnsOnCall(i int, result1 config.Config) {
 	}{result1}
 }
 
+func (fake *FakeIClient) StateBackend() config.StateBackend {
+	fake.stateBackendMutex.Lock()
+	ret, specificReturn := fake.stateBackendReturnsOnCall[len(fake.stateBackendArgsForCall)]
+	fake.stateBackendArgsForCall = append(fake.stateBackendArgsForCall, struct {
+	}{})
+	stub := fake.StateBackendStub
+	fakeReturns := fake.stateBackendReturns
+	fake.recordInvocation("StateBackend", []interface{}{})
+	fake.stateBackendMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) StateBackendCallCount() int {
+	fake.stateBackendMutex.RLock()
+	defer fake.stateBackendMutex.RUnlock()
+	return len(fake.stateBackendArgsForCall)
+}
+
+func (fake *FakeIClient) StateBackendCalls(stub func() config.StateBackend) {
+	fake.stateBackendMutex.Lock()
+	defer fake.stateBackendMutex.Unlock()
+	fake.StateBackendStub = stub
+}
+
+func (fake *FakeIClient) StateBackendReturns(result1 config.StateBackend) {
+	fake.stateBackendMutex.Lock()
+	defer fake.stateBackendMutex.Unlock()
+	fake.StateBackendStub = nil
+	fake.stateBackendReturns = struct {
+		result1 config.StateBackend
+	}{result1}
+}
+
+func (fake *FakeIClient) StateBackendReturnsOnCall(i int, result1 config.StateBackend) {
+	fake.stateBackendMutex.Lock()
+	defer fake.stateBackendMutex.Unlock()
+	fake.StateBackendStub = nil
+	if fake.stateBackendReturnsOnCall == nil {
+		fake.stateBackendReturnsOnCall = make(map[int]struct {
+			result1 config.StateBackend
+		})
+	}
+	fake.stateBackendReturnsOnCall[i] = struct {
+		result1 config.StateBackend
+	}{result1}
+}
+
 func (fake *FakeIClient) StoreAsset(arg1 string, arg2 []byte) error {
 	var arg2Copy []byte
 	if arg2 != nil {
@@ -528,15 +670,16 @@ func (fake *FakeIClient) StoreAsset(arg1 string, arg2 []byte) error {
 		arg1 string
 		arg2 []byte
 	}{arg1, arg2Copy})
+	stub := fake.StoreAssetStub
+	fakeReturns := fake.storeAssetReturns
 	fake.recordInvocation("StoreAsset", []interface{}{arg1, arg2Copy})
 	fake.storeAssetMutex.Unlock()
-	if fake.StoreAssetStub != nil {
-		return fake.StoreAssetStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.storeAssetReturns
 	return fakeReturns.result1
 }
 
@@ -588,15 +731,16 @@ func (fake *FakeIClient) Update(arg1 config.Config) error {
 	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
 		arg1 config.Config
 	}{arg1})
+	stub := fake.UpdateStub
+	fakeReturns := fake.updateReturns
 	fake.recordInvocation("Update", []interface{}{arg1})
 	fake.updateMutex.Unlock()
-	if fake.UpdateStub != nil {
-		return fake.UpdateStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.updateReturns
 	return fakeReturns.result1
 }
 
@@ -649,6 +793,8 @@ func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	defer fake.configExistsMutex.RUnlock()
 	fake.deleteAllMutex.RLock()
 	defer fake.deleteAllMutex.RUnlock()
+	fake.deleteAssetMutex.RLock()
+	defer fake.deleteAssetMutex.RUnlock()
 	fake.ensureBucketExistsMutex.RLock()
 	defer fake.ensureBucketExistsMutex.RUnlock()
 	fake.hasAssetMutex.RLock()
@@ -659,6 +805,8 @@ func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	defer fake.loadAssetMutex.RUnlock()
 	fake.newConfigMutex.RLock()
 	defer fake.newConfigMutex.RUnlock()
+	fake.stateBackendMutex.RLock()
+	defer fake.stateBackendMutex.RUnlock()
 	fake.storeAssetMutex.RLock()
 	defer fake.storeAssetMutex.RUnlock()
 	fake.updateMutex.RLock()
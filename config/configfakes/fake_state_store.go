@@ -0,0 +1,818 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package configfakes
+
+import (
+	"sync"
+
+	"github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+type FakeStateStore struct {
+	BackendStub        func(string) config.StateBackend
+	backendMutex       sync.RWMutex
+	backendArgsForCall []struct {
+		arg1 string
+	}
+	backendReturns struct {
+		result1 config.StateBackend
+	}
+	backendReturnsOnCall map[int]struct {
+		result1 config.StateBackend
+	}
+	BucketExistsStub        func(string) (bool, error)
+	bucketExistsMutex       sync.RWMutex
+	bucketExistsArgsForCall []struct {
+		arg1 string
+	}
+	bucketExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	bucketExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	CreateBucketStub        func(string) error
+	createBucketMutex       sync.RWMutex
+	createBucketArgsForCall []struct {
+		arg1 string
+	}
+	createBucketReturns struct {
+		result1 error
+	}
+	createBucketReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteBucketStub        func(string) error
+	deleteBucketMutex       sync.RWMutex
+	deleteBucketArgsForCall []struct {
+		arg1 string
+	}
+	deleteBucketReturns struct {
+		result1 error
+	}
+	deleteBucketReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteFileStub        func(string, string) error
+	deleteFileMutex       sync.RWMutex
+	deleteFileArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteFileReturns struct {
+		result1 error
+	}
+	deleteFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ForRegionStub        func(string) (config.StateStore, error)
+	forRegionMutex       sync.RWMutex
+	forRegionArgsForCall []struct {
+		arg1 string
+	}
+	forRegionReturns struct {
+		result1 config.StateStore
+		result2 error
+	}
+	forRegionReturnsOnCall map[int]struct {
+		result1 config.StateStore
+		result2 error
+	}
+	HasFileStub        func(string, string) (bool, error)
+	hasFileMutex       sync.RWMutex
+	hasFileArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	hasFileReturns struct {
+		result1 bool
+		result2 error
+	}
+	hasFileReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ListBucketsStub        func(string) ([]iaas.Bucket, error)
+	listBucketsMutex       sync.RWMutex
+	listBucketsArgsForCall []struct {
+		arg1 string
+	}
+	listBucketsReturns struct {
+		result1 []iaas.Bucket
+		result2 error
+	}
+	listBucketsReturnsOnCall map[int]struct {
+		result1 []iaas.Bucket
+		result2 error
+	}
+	LoadFileStub        func(string, string) ([]byte, error)
+	loadFileMutex       sync.RWMutex
+	loadFileArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	loadFileReturns struct {
+		result1 []byte
+		result2 error
+	}
+	loadFileReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	WriteFileStub        func(string, string, []byte) error
+	writeFileMutex       sync.RWMutex
+	writeFileArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}
+	writeFileReturns struct {
+		result1 error
+	}
+	writeFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStateStore) Backend(arg1 string) config.StateBackend {
+	fake.backendMutex.Lock()
+	ret, specificReturn := fake.backendReturnsOnCall[len(fake.backendArgsForCall)]
+	fake.backendArgsForCall = append(fake.backendArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BackendStub
+	fakeReturns := fake.backendReturns
+	fake.recordInvocation("Backend", []interface{}{arg1})
+	fake.backendMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStateStore) BackendCallCount() int {
+	fake.backendMutex.RLock()
+	defer fake.backendMutex.RUnlock()
+	return len(fake.backendArgsForCall)
+}
+
+func (fake *FakeStateStore) BackendCalls(stub func(string) config.StateBackend) {
+	fake.backendMutex.Lock()
+	defer fake.backendMutex.Unlock()
+	fake.BackendStub = stub
+}
+
+func (fake *FakeStateStore) BackendArgsForCall(i int) string {
+	fake.backendMutex.RLock()
+	defer fake.backendMutex.RUnlock()
+	argsForCall := fake.backendArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStateStore) BackendReturns(result1 config.StateBackend) {
+	fake.backendMutex.Lock()
+	defer fake.backendMutex.Unlock()
+	fake.BackendStub = nil
+	fake.backendReturns = struct {
+		result1 config.StateBackend
+	}{result1}
+}
+
+func (fake *FakeStateStore) BackendReturnsOnCall(i int, result1 config.StateBackend) {
+	fake.backendMutex.Lock()
+	defer fake.backendMutex.Unlock()
+	fake.BackendStub = nil
+	if fake.backendReturnsOnCall == nil {
+		fake.backendReturnsOnCall = make(map[int]struct {
+			result1 config.StateBackend
+		})
+	}
+	fake.backendReturnsOnCall[i] = struct {
+		result1 config.StateBackend
+	}{result1}
+}
+
+func (fake *FakeStateStore) BucketExists(arg1 string) (bool, error) {
+	fake.bucketExistsMutex.Lock()
+	ret, specificReturn := fake.bucketExistsReturnsOnCall[len(fake.bucketExistsArgsForCall)]
+	fake.bucketExistsArgsForCall = append(fake.bucketExistsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BucketExistsStub
+	fakeReturns := fake.bucketExistsReturns
+	fake.recordInvocation("BucketExists", []interface{}{arg1})
+	fake.bucketExistsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStateStore) BucketExistsCallCount() int {
+	fake.bucketExistsMutex.RLock()
+	defer fake.bucketExistsMutex.RUnlock()
+	return len(fake.bucketExistsArgsForCall)
+}
+
+func (fake *FakeStateStore) BucketExistsCalls(stub func(string) (bool, error)) {
+	fake.bucketExistsMutex.Lock()
+	defer fake.bucketExistsMutex.Unlock()
+	fake.BucketExistsStub = stub
+}
+
+func (fake *FakeStateStore) BucketExistsArgsForCall(i int) string {
+	fake.bucketExistsMutex.RLock()
+	defer fake.bucketExistsMutex.RUnlock()
+	argsForCall := fake.bucketExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStateStore) BucketExistsReturns(result1 bool, result2 error) {
+	fake.bucketExistsMutex.Lock()
+	defer fake.bucketExistsMutex.Unlock()
+	fake.BucketExistsStub = nil
+	fake.bucketExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) BucketExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.bucketExistsMutex.Lock()
+	defer fake.bucketExistsMutex.Unlock()
+	fake.BucketExistsStub = nil
+	if fake.bucketExistsReturnsOnCall == nil {
+		fake.bucketExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.bucketExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) CreateBucket(arg1 string) error {
+	fake.createBucketMutex.Lock()
+	ret, specificReturn := fake.createBucketReturnsOnCall[len(fake.createBucketArgsForCall)]
+	fake.createBucketArgsForCall = append(fake.createBucketArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CreateBucketStub
+	fakeReturns := fake.createBucketReturns
+	fake.recordInvocation("CreateBucket", []interface{}{arg1})
+	fake.createBucketMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStateStore) CreateBucketCallCount() int {
+	fake.createBucketMutex.RLock()
+	defer fake.createBucketMutex.RUnlock()
+	return len(fake.createBucketArgsForCall)
+}
+
+func (fake *FakeStateStore) CreateBucketCalls(stub func(string) error) {
+	fake.createBucketMutex.Lock()
+	defer fake.createBucketMutex.Unlock()
+	fake.CreateBucketStub = stub
+}
+
+func (fake *FakeStateStore) CreateBucketArgsForCall(i int) string {
+	fake.createBucketMutex.RLock()
+	defer fake.createBucketMutex.RUnlock()
+	argsForCall := fake.createBucketArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStateStore) CreateBucketReturns(result1 error) {
+	fake.createBucketMutex.Lock()
+	defer fake.createBucketMutex.Unlock()
+	fake.CreateBucketStub = nil
+	fake.createBucketReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStateStore) CreateBucketReturnsOnCall(i int, result1 error) {
+	fake.createBucketMutex.Lock()
+	defer fake.createBucketMutex.Unlock()
+	fake.CreateBucketStub = nil
+	if fake.createBucketReturnsOnCall == nil {
+		fake.createBucketReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createBucketReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStateStore) DeleteBucket(arg1 string) error {
+	fake.deleteBucketMutex.Lock()
+	ret, specificReturn := fake.deleteBucketReturnsOnCall[len(fake.deleteBucketArgsForCall)]
+	fake.deleteBucketArgsForCall = append(fake.deleteBucketArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteBucketStub
+	fakeReturns := fake.deleteBucketReturns
+	fake.recordInvocation("DeleteBucket", []interface{}{arg1})
+	fake.deleteBucketMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStateStore) DeleteBucketCallCount() int {
+	fake.deleteBucketMutex.RLock()
+	defer fake.deleteBucketMutex.RUnlock()
+	return len(fake.deleteBucketArgsForCall)
+}
+
+func (fake *FakeStateStore) DeleteBucketCalls(stub func(string) error) {
+	fake.deleteBucketMutex.Lock()
+	defer fake.deleteBucketMutex.Unlock()
+	fake.DeleteBucketStub = stub
+}
+
+func (fake *FakeStateStore) DeleteBucketArgsForCall(i int) string {
+	fake.deleteBucketMutex.RLock()
+	defer fake.deleteBucketMutex.RUnlock()
+	argsForCall := fake.deleteBucketArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStateStore) DeleteBucketReturns(result1 error) {
+	fake.deleteBucketMutex.Lock()
+	defer fake.deleteBucketMutex.Unlock()
+	fake.DeleteBucketStub = nil
+	fake.deleteBucketReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStateStore) DeleteBucketReturnsOnCall(i int, result1 error) {
+	fake.deleteBucketMutex.Lock()
+	defer fake.deleteBucketMutex.Unlock()
+	fake.DeleteBucketStub = nil
+	if fake.deleteBucketReturnsOnCall == nil {
+		fake.deleteBucketReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteBucketReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStateStore) DeleteFile(arg1 string, arg2 string) error {
+	fake.deleteFileMutex.Lock()
+	ret, specificReturn := fake.deleteFileReturnsOnCall[len(fake.deleteFileArgsForCall)]
+	fake.deleteFileArgsForCall = append(fake.deleteFileArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteFileStub
+	fakeReturns := fake.deleteFileReturns
+	fake.recordInvocation("DeleteFile", []interface{}{arg1, arg2})
+	fake.deleteFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStateStore) DeleteFileCallCount() int {
+	fake.deleteFileMutex.RLock()
+	defer fake.deleteFileMutex.RUnlock()
+	return len(fake.deleteFileArgsForCall)
+}
+
+func (fake *FakeStateStore) DeleteFileCalls(stub func(string, string) error) {
+	fake.deleteFileMutex.Lock()
+	defer fake.deleteFileMutex.Unlock()
+	fake.DeleteFileStub = stub
+}
+
+func (fake *FakeStateStore) DeleteFileArgsForCall(i int) (string, string) {
+	fake.deleteFileMutex.RLock()
+	defer fake.deleteFileMutex.RUnlock()
+	argsForCall := fake.deleteFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStateStore) DeleteFileReturns(result1 error) {
+	fake.deleteFileMutex.Lock()
+	defer fake.deleteFileMutex.Unlock()
+	fake.DeleteFileStub = nil
+	fake.deleteFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStateStore) DeleteFileReturnsOnCall(i int, result1 error) {
+	fake.deleteFileMutex.Lock()
+	defer fake.deleteFileMutex.Unlock()
+	fake.DeleteFileStub = nil
+	if fake.deleteFileReturnsOnCall == nil {
+		fake.deleteFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStateStore) ForRegion(arg1 string) (config.StateStore, error) {
+	fake.forRegionMutex.Lock()
+	ret, specificReturn := fake.forRegionReturnsOnCall[len(fake.forRegionArgsForCall)]
+	fake.forRegionArgsForCall = append(fake.forRegionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ForRegionStub
+	fakeReturns := fake.forRegionReturns
+	fake.recordInvocation("ForRegion", []interface{}{arg1})
+	fake.forRegionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStateStore) ForRegionCallCount() int {
+	fake.forRegionMutex.RLock()
+	defer fake.forRegionMutex.RUnlock()
+	return len(fake.forRegionArgsForCall)
+}
+
+func (fake *FakeStateStore) ForRegionCalls(stub func(string) (config.StateStore, error)) {
+	fake.forRegionMutex.Lock()
+	defer fake.forRegionMutex.Unlock()
+	fake.ForRegionStub = stub
+}
+
+func (fake *FakeStateStore) ForRegionArgsForCall(i int) string {
+	fake.forRegionMutex.RLock()
+	defer fake.forRegionMutex.RUnlock()
+	argsForCall := fake.forRegionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStateStore) ForRegionReturns(result1 config.StateStore, result2 error) {
+	fake.forRegionMutex.Lock()
+	defer fake.forRegionMutex.Unlock()
+	fake.ForRegionStub = nil
+	fake.forRegionReturns = struct {
+		result1 config.StateStore
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) ForRegionReturnsOnCall(i int, result1 config.StateStore, result2 error) {
+	fake.forRegionMutex.Lock()
+	defer fake.forRegionMutex.Unlock()
+	fake.ForRegionStub = nil
+	if fake.forRegionReturnsOnCall == nil {
+		fake.forRegionReturnsOnCall = make(map[int]struct {
+			result1 config.StateStore
+			result2 error
+		})
+	}
+	fake.forRegionReturnsOnCall[i] = struct {
+		result1 config.StateStore
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) HasFile(arg1 string, arg2 string) (bool, error) {
+	fake.hasFileMutex.Lock()
+	ret, specificReturn := fake.hasFileReturnsOnCall[len(fake.hasFileArgsForCall)]
+	fake.hasFileArgsForCall = append(fake.hasFileArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.HasFileStub
+	fakeReturns := fake.hasFileReturns
+	fake.recordInvocation("HasFile", []interface{}{arg1, arg2})
+	fake.hasFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStateStore) HasFileCallCount() int {
+	fake.hasFileMutex.RLock()
+	defer fake.hasFileMutex.RUnlock()
+	return len(fake.hasFileArgsForCall)
+}
+
+func (fake *FakeStateStore) HasFileCalls(stub func(string, string) (bool, error)) {
+	fake.hasFileMutex.Lock()
+	defer fake.hasFileMutex.Unlock()
+	fake.HasFileStub = stub
+}
+
+func (fake *FakeStateStore) HasFileArgsForCall(i int) (string, string) {
+	fake.hasFileMutex.RLock()
+	defer fake.hasFileMutex.RUnlock()
+	argsForCall := fake.hasFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStateStore) HasFileReturns(result1 bool, result2 error) {
+	fake.hasFileMutex.Lock()
+	defer fake.hasFileMutex.Unlock()
+	fake.HasFileStub = nil
+	fake.hasFileReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) HasFileReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.hasFileMutex.Lock()
+	defer fake.hasFileMutex.Unlock()
+	fake.HasFileStub = nil
+	if fake.hasFileReturnsOnCall == nil {
+		fake.hasFileReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.hasFileReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) ListBuckets(arg1 string) ([]iaas.Bucket, error) {
+	fake.listBucketsMutex.Lock()
+	ret, specificReturn := fake.listBucketsReturnsOnCall[len(fake.listBucketsArgsForCall)]
+	fake.listBucketsArgsForCall = append(fake.listBucketsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListBucketsStub
+	fakeReturns := fake.listBucketsReturns
+	fake.recordInvocation("ListBuckets", []interface{}{arg1})
+	fake.listBucketsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStateStore) ListBucketsCallCount() int {
+	fake.listBucketsMutex.RLock()
+	defer fake.listBucketsMutex.RUnlock()
+	return len(fake.listBucketsArgsForCall)
+}
+
+func (fake *FakeStateStore) ListBucketsCalls(stub func(string) ([]iaas.Bucket, error)) {
+	fake.listBucketsMutex.Lock()
+	defer fake.listBucketsMutex.Unlock()
+	fake.ListBucketsStub = stub
+}
+
+func (fake *FakeStateStore) ListBucketsArgsForCall(i int) string {
+	fake.listBucketsMutex.RLock()
+	defer fake.listBucketsMutex.RUnlock()
+	argsForCall := fake.listBucketsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStateStore) ListBucketsReturns(result1 []iaas.Bucket, result2 error) {
+	fake.listBucketsMutex.Lock()
+	defer fake.listBucketsMutex.Unlock()
+	fake.ListBucketsStub = nil
+	fake.listBucketsReturns = struct {
+		result1 []iaas.Bucket
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) ListBucketsReturnsOnCall(i int, result1 []iaas.Bucket, result2 error) {
+	fake.listBucketsMutex.Lock()
+	defer fake.listBucketsMutex.Unlock()
+	fake.ListBucketsStub = nil
+	if fake.listBucketsReturnsOnCall == nil {
+		fake.listBucketsReturnsOnCall = make(map[int]struct {
+			result1 []iaas.Bucket
+			result2 error
+		})
+	}
+	fake.listBucketsReturnsOnCall[i] = struct {
+		result1 []iaas.Bucket
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) LoadFile(arg1 string, arg2 string) ([]byte, error) {
+	fake.loadFileMutex.Lock()
+	ret, specificReturn := fake.loadFileReturnsOnCall[len(fake.loadFileArgsForCall)]
+	fake.loadFileArgsForCall = append(fake.loadFileArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.LoadFileStub
+	fakeReturns := fake.loadFileReturns
+	fake.recordInvocation("LoadFile", []interface{}{arg1, arg2})
+	fake.loadFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStateStore) LoadFileCallCount() int {
+	fake.loadFileMutex.RLock()
+	defer fake.loadFileMutex.RUnlock()
+	return len(fake.loadFileArgsForCall)
+}
+
+func (fake *FakeStateStore) LoadFileCalls(stub func(string, string) ([]byte, error)) {
+	fake.loadFileMutex.Lock()
+	defer fake.loadFileMutex.Unlock()
+	fake.LoadFileStub = stub
+}
+
+func (fake *FakeStateStore) LoadFileArgsForCall(i int) (string, string) {
+	fake.loadFileMutex.RLock()
+	defer fake.loadFileMutex.RUnlock()
+	argsForCall := fake.loadFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStateStore) LoadFileReturns(result1 []byte, result2 error) {
+	fake.loadFileMutex.Lock()
+	defer fake.loadFileMutex.Unlock()
+	fake.LoadFileStub = nil
+	fake.loadFileReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) LoadFileReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.loadFileMutex.Lock()
+	defer fake.loadFileMutex.Unlock()
+	fake.LoadFileStub = nil
+	if fake.loadFileReturnsOnCall == nil {
+		fake.loadFileReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.loadFileReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStateStore) WriteFile(arg1 string, arg2 string, arg3 []byte) error {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.writeFileMutex.Lock()
+	ret, specificReturn := fake.writeFileReturnsOnCall[len(fake.writeFileArgsForCall)]
+	fake.writeFileArgsForCall = append(fake.writeFileArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+	}{arg1, arg2, arg3Copy})
+	stub := fake.WriteFileStub
+	fakeReturns := fake.writeFileReturns
+	fake.recordInvocation("WriteFile", []interface{}{arg1, arg2, arg3Copy})
+	fake.writeFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStateStore) WriteFileCallCount() int {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	return len(fake.writeFileArgsForCall)
+}
+
+func (fake *FakeStateStore) WriteFileCalls(stub func(string, string, []byte) error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = stub
+}
+
+func (fake *FakeStateStore) WriteFileArgsForCall(i int) (string, string, []byte) {
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	argsForCall := fake.writeFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStateStore) WriteFileReturns(result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	fake.writeFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStateStore) WriteFileReturnsOnCall(i int, result1 error) {
+	fake.writeFileMutex.Lock()
+	defer fake.writeFileMutex.Unlock()
+	fake.WriteFileStub = nil
+	if fake.writeFileReturnsOnCall == nil {
+		fake.writeFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStateStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.backendMutex.RLock()
+	defer fake.backendMutex.RUnlock()
+	fake.bucketExistsMutex.RLock()
+	defer fake.bucketExistsMutex.RUnlock()
+	fake.createBucketMutex.RLock()
+	defer fake.createBucketMutex.RUnlock()
+	fake.deleteBucketMutex.RLock()
+	defer fake.deleteBucketMutex.RUnlock()
+	fake.deleteFileMutex.RLock()
+	defer fake.deleteFileMutex.RUnlock()
+	fake.forRegionMutex.RLock()
+	defer fake.forRegionMutex.RUnlock()
+	fake.hasFileMutex.RLock()
+	defer fake.hasFileMutex.RUnlock()
+	fake.listBucketsMutex.RLock()
+	defer fake.listBucketsMutex.RUnlock()
+	fake.loadFileMutex.RLock()
+	defer fake.loadFileMutex.RUnlock()
+	fake.writeFileMutex.RLock()
+	defer fake.writeFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStateStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ config.StateStore = new(FakeStateStore)
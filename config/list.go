@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const bucketPrefix = "control-tower-"
+const bucketSuffix = "-config"
+
+// List finds every control-tower config bucket in the state store and loads the config from each.
+// Buckets that live outside of the store's region are read through a store for that region.
+func List(store StateStore) ([]Config, error) {
+	buckets, err := store.ListBuckets(bucketPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := []Config{}
+	for _, bucket := range buckets {
+		if !isConfigBucket(bucket.Name) {
+			continue
+		}
+
+		bucketStore, err := store.ForRegion(bucket.Region)
+		if err != nil {
+			return nil, fmt.Errorf("error creating state store for region [%v]: [%v]", bucket.Region, err)
+		}
+
+		exists, err := bucketStore.HasFile(bucket.Name, configFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("error checking for config in bucket [%v]: [%v]", bucket.Name, err)
+		}
+		if !exists {
+			continue
+		}
+
+		configBytes, err := bucketStore.LoadFile(bucket.Name, configFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading config from bucket [%v]: [%v]", bucket.Name, err)
+		}
+
+		conf := Config{}
+		if err := json.Unmarshal(configBytes, &conf); err != nil {
+			return nil, fmt.Errorf("error parsing config from bucket [%v]: [%v]", bucket.Name, err)
+		}
+
+		// configs from newer versions of control-tower cannot be migrated, so are listed as stored
+		if migrated, _, err := Migrate(conf); err == nil {
+			conf = migrated
+		}
+		configs = append(configs, conf)
+	}
+
+	sort.SliceStable(configs, func(i, j int) bool {
+		if configs[i].Namespace != configs[j].Namespace {
+			return configs[i].Namespace < configs[j].Namespace
+		}
+		return configs[i].Project < configs[j].Project
+	})
+
+	return configs, nil
+}
+
+func isConfigBucket(name string) bool {
+	return strings.HasPrefix(name, bucketPrefix) && strings.HasSuffix(name, bucketSuffix)
+}
@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"errors"
+
+	. "github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/config/configfakes"
+	"github.com/EngineerBetter/control-tower/iaas"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("List", func() {
+	var store *configfakes.FakeStateStore
+	var otherRegionStore *configfakes.FakeStateStore
+
+	BeforeEach(func() {
+		store = &configfakes.FakeStateStore{}
+		store.ForRegionStub = func(region string) (StateStore, error) {
+			if region == "us-east-1" {
+				return otherRegionStore, nil
+			}
+			return store, nil
+		}
+		store.HasFileReturns(true, nil)
+		store.LoadFileStub = func(bucket, path string) ([]byte, error) {
+			switch bucket {
+			case "control-tower-zeta-eu-west-1-config":
+				return []byte(`{"project":"zeta","namespace":"eu-west-1","region":"eu-west-1","spot":true}`), nil
+			case "control-tower-alpha-prod-config":
+				return []byte(`{"project":"alpha","namespace":"prod","region":"eu-west-1"}`), nil
+			}
+			return nil, errors.New("unexpected bucket " + bucket)
+		}
+
+		otherRegionStore = &configfakes.FakeStateStore{}
+		otherRegionStore.HasFileReturns(true, nil)
+		otherRegionStore.LoadFileReturns([]byte(`{"project":"beta","namespace":"us-east-1","region":"us-east-1"}`), nil)
+	})
+
+	It("loads the config from every control-tower config bucket", func() {
+		store.ListBucketsReturns([]iaas.Bucket{
+			{Name: "control-tower-zeta-eu-west-1-config", Region: "eu-west-1"},
+			{Name: "control-tower-alpha-prod-config", Region: "eu-west-1"},
+			{Name: "control-tower-unrelated-bucket", Region: "eu-west-1"},
+		}, nil)
+
+		configs, err := List(store)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.ListBucketsArgsForCall(0)).To(Equal("control-tower-"))
+		Expect(configs).To(HaveLen(2))
+		Expect(configs[0].Project).To(Equal("zeta"))
+		Expect(configs[0].VMProvisioningType).To(Equal(SPOT))
+		Expect(configs[1].Project).To(Equal("alpha"))
+	})
+
+	It("reads buckets in other regions through a store for that region", func() {
+		store.ListBucketsReturns([]iaas.Bucket{
+			{Name: "control-tower-beta-us-east-1-config", Region: "us-east-1"},
+		}, nil)
+
+		configs, err := List(store)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(store.ForRegionArgsForCall(0)).To(Equal("us-east-1"))
+		Expect(configs).To(HaveLen(1))
+		Expect(configs[0].Region).To(Equal("us-east-1"))
+		Expect(store.LoadFileCallCount()).To(Equal(0))
+	})
+
+	It("lists the configs in a local state store", func() {
+		local := NewLocalStateStore(GinkgoT().TempDir())
+		Expect(local.WriteFile("control-tower-alpha-prod-config", "config.json", []byte(`{"project":"alpha","namespace":"prod"}`))).To(Succeed())
+		Expect(local.CreateBucket("control-tower-empty-config")).To(Succeed())
+
+		configs, err := List(local)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configs).To(HaveLen(1))
+		Expect(configs[0].Project).To(Equal("alpha"))
+	})
+
+	It("skips buckets without a config file", func() {
+		store.ListBucketsReturns([]iaas.Bucket{
+			{Name: "control-tower-alpha-prod-config", Region: "eu-west-1"},
+		}, nil)
+		store.HasFileReturns(false, nil)
+
+		configs, err := List(store)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configs).To(BeEmpty())
+	})
+
+	It("returns an error when the buckets cannot be listed", func() {
+		store.ListBucketsReturns(nil, errors.New("access denied"))
+
+		_, err := List(store)
+		Expect(err).To(MatchError("access denied"))
+	})
+
+	It("returns a useful error when a config cannot be parsed", func() {
+		store.ListBucketsReturns([]iaas.Bucket{
+			{Name: "control-tower-alpha-prod-config", Region: "eu-west-1"},
+		}, nil)
+		store.LoadFileReturns([]byte(`not json`), nil)
+		store.LoadFileStub = nil
+
+		_, err := List(store)
+		Expect(err).To(MatchError(ContainSubstring("error parsing config from bucket [control-tower-alpha-prod-config]")))
+	})
+})
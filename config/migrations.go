@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Migration upgrades a config from one schema version to the next
+type Migration struct {
+	Description string
+	Migrate     func(Config) (Config, error)
+}
+
+// Migrations are applied in order, Migrations[n] taking a config from
+// schema version n to n+1. Append new migrations here and never reorder them.
+var Migrations = []Migration{
+	{
+		Description: "derive vm_provisioning_type from the deprecated spot field",
+		Migrate:     migrateSpotToVMProvisioningType,
+	},
+	{
+		Description: "default fields added since the initial schema",
+		Migrate:     migrateDefaultAddedFields,
+	},
+	{
+		Description: "derive metrics_backend from the deprecated no_metrics field",
+		Migrate:     migrateNoMetricsToMetricsBackend,
+	},
+}
+
+// CurrentSchemaVersion is the schema version of configs written by this version of control-tower
+func CurrentSchemaVersion() int {
+	return len(Migrations)
+}
+
+// Migrate applies every migration needed to bring the config up to the current schema
+// version, and reports whether any were applied. It refuses configs written by a newer
+// version of control-tower.
+func Migrate(conf Config) (Config, bool, error) {
+	if conf.SchemaVersion > CurrentSchemaVersion() {
+		return conf, false, fmt.Errorf("config has schema version %d but this version of control-tower only supports up to %d, please upgrade control-tower", conf.SchemaVersion, CurrentSchemaVersion())
+	}
+	if conf.SchemaVersion < 0 {
+		return conf, false, fmt.Errorf("config has invalid schema version %d", conf.SchemaVersion)
+	}
+
+	migrated := false
+	for version := conf.SchemaVersion; version < CurrentSchemaVersion(); version++ {
+		var err error
+		conf, err = Migrations[version].Migrate(conf)
+		if err != nil {
+			return conf, migrated, fmt.Errorf("error migrating config from schema version %d to %d (%s): [%v]", version, version+1, Migrations[version].Description, err)
+		}
+		conf.SchemaVersion = version + 1
+		migrated = true
+	}
+
+	return conf, migrated, nil
+}
+
+func migrateSpotToVMProvisioningType(conf Config) (Config, error) {
+	if conf.VMProvisioningType == "" {
+		conf.VMProvisioningType = ConvertSpotBoolToVMProvisioningType(conf.Spot)
+	}
+	return conf, nil
+}
+
+func migrateDefaultAddedFields(conf Config) (Config, error) {
+	if conf.PersistentDisk == "" {
+		conf.PersistentDisk = "default"
+	}
+	if conf.WorkerType == "" {
+		conf.WorkerType = "m4"
+	}
+	if conf.TFStatePath == "" {
+		conf.TFStatePath = terraformStateFileName
+	}
+	return conf, nil
+}
+
+// migrateNoMetricsToMetricsBackend keeps the metrics stack of configs from before the backend could be
+// chosen, which deployed InfluxDB unless no_metrics was set
+func migrateNoMetricsToMetricsBackend(conf Config) (Config, error) {
+	if conf.MetricsBackend == "" {
+		conf.MetricsBackend = MetricsBackendInfluxDB
+		if conf.NoMetrics {
+			conf.MetricsBackend = MetricsBackendNone
+		}
+	}
+	return conf, nil
+}
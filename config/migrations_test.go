@@ -0,0 +1,128 @@
+package config_test
+
+import (
+	"fmt"
+
+	. "github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas/iaasfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Migrations", func() {
+	migrate := func(from int, conf Config) Config {
+		migrated, err := Migrations[from].Migrate(conf)
+		Expect(err).ToNot(HaveOccurred())
+		return migrated
+	}
+
+	Describe("0 to 1", func() {
+		It("converts spot to vm_provisioning_type", func() {
+			Expect(migrate(0, Config{Spot: true}).VMProvisioningType).To(Equal(SPOT))
+			Expect(migrate(0, Config{Spot: false}).VMProvisioningType).To(Equal(ON_DEMAND))
+		})
+
+		It("keeps an existing vm_provisioning_type", func() {
+			Expect(migrate(0, Config{Spot: true, VMProvisioningType: ON_DEMAND}).VMProvisioningType).To(Equal(ON_DEMAND))
+		})
+	})
+
+	Describe("1 to 2", func() {
+		It("defaults fields which were added later", func() {
+			conf := migrate(1, Config{})
+			Expect(conf.PersistentDisk).To(Equal("default"))
+			Expect(conf.WorkerType).To(Equal("m4"))
+			Expect(conf.TFStatePath).To(Equal("terraform.tfstate"))
+		})
+
+		It("keeps values which are already set", func() {
+			conf := migrate(1, Config{PersistentDisk: "large", WorkerType: "m5", TFStatePath: "custom.tfstate"})
+			Expect(conf.PersistentDisk).To(Equal("large"))
+			Expect(conf.WorkerType).To(Equal("m5"))
+			Expect(conf.TFStatePath).To(Equal("custom.tfstate"))
+		})
+	})
+
+	Describe("2 to 3", func() {
+		It("derives metrics_backend from no_metrics", func() {
+			Expect(migrate(2, Config{NoMetrics: false}).MetricsBackend).To(Equal(MetricsBackendInfluxDB))
+			Expect(migrate(2, Config{NoMetrics: true}).MetricsBackend).To(Equal(MetricsBackendNone))
+		})
+
+		It("keeps an existing metrics_backend", func() {
+			Expect(migrate(2, Config{MetricsBackend: MetricsBackendPrometheus}).MetricsBackend).To(Equal(MetricsBackendPrometheus))
+		})
+	})
+
+	Describe("Migrate", func() {
+		It("brings an unversioned config up to the current schema version", func() {
+			conf, migrated, err := Migrate(Config{Spot: true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(migrated).To(BeTrue())
+			Expect(conf.SchemaVersion).To(Equal(CurrentSchemaVersion()))
+			Expect(conf.VMProvisioningType).To(Equal(SPOT))
+			Expect(conf.PersistentDisk).To(Equal("default"))
+		})
+
+		It("only runs migrations newer than the config", func() {
+			conf, migrated, err := Migrate(Config{SchemaVersion: 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(migrated).To(BeTrue())
+			Expect(conf.VMProvisioningType).To(BeEmpty())
+			Expect(conf.WorkerType).To(Equal("m4"))
+		})
+
+		It("does nothing to a current config", func() {
+			current := Config{SchemaVersion: CurrentSchemaVersion()}
+			conf, migrated, err := Migrate(current)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(migrated).To(BeFalse())
+			Expect(conf).To(Equal(current))
+		})
+
+		It("refuses a config from a newer version of control-tower", func() {
+			_, _, err := Migrate(Config{SchemaVersion: CurrentSchemaVersion() + 1})
+			Expect(err).To(MatchError(ContainSubstring("only supports up to")))
+		})
+	})
+
+	Describe("Client.Load", func() {
+		var provider *iaasfakes.FakeProvider
+		var stored []byte
+
+		BeforeEach(func() {
+			provider = &iaasfakes.FakeProvider{}
+			provider.RegionReturns("eu-west-1")
+			provider.LoadFileStub = func(bucket, path string) ([]byte, error) {
+				return stored, nil
+			}
+		})
+
+		It("migrates a config in memory without writing it back", func() {
+			stored = []byte(`{"project":"happymeal","spot":true}`)
+
+			conf, err := New(provider, "happymeal", "").Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conf.SchemaVersion).To(Equal(CurrentSchemaVersion()))
+			Expect(conf.VMProvisioningType).To(Equal(SPOT))
+			Expect(provider.WriteFileCallCount()).To(Equal(0))
+		})
+
+		It("does not write back a current config", func() {
+			stored = []byte(fmt.Sprintf(`{"project":"happymeal","schema_version":%d}`, CurrentSchemaVersion()))
+
+			_, err := New(provider, "happymeal", "").Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(provider.WriteFileCallCount()).To(Equal(0))
+		})
+
+		It("refuses to load a config from a newer schema", func() {
+			stored = []byte(`{"project":"happymeal","schema_version":999}`)
+
+			_, err := New(provider, "happymeal", "").Load()
+			Expect(err).To(MatchError(ContainSubstring("config has schema version 999")))
+			Expect(provider.WriteFileCallCount()).To(Equal(0))
+		})
+
+	})
+})
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"reflect"
+)
+
+// RedactedValue replaces the value of secret fields in redacted configs
+const RedactedValue = "REDACTED"
+
+// Redact returns a copy of the config with every non-empty field tagged `secret:"true"`
+// replaced by RedactedValue, so that it can be shared safely
+func Redact(c Config) Config {
+	v := reflect.ValueOf(&c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("secret") != "true" {
+			continue
+		}
+		field := v.Field(i)
+		if field.Kind() == reflect.String && field.String() != "" {
+			field.SetString(RedactedValue)
+		}
+	}
+	return c
+}
@@ -0,0 +1,270 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/iaas"
+)
+
+// Supported state store types
+const (
+	StateStoreS3    = "s3"
+	StateStoreGCS   = "gcs"
+	StateStoreLocal = "local"
+)
+
+// StateBackend describes where terraform should keep its state so that it lives
+// alongside the rest of the state of a deployment
+type StateBackend struct {
+	Type   string
+	Region string
+	Dir    string
+}
+
+//counterfeiter:generate . StateStore
+// StateStore persists the state of deployments as files grouped into buckets
+type StateStore interface {
+	BucketExists(bucket string) (bool, error)
+	CreateBucket(bucket string) error
+	DeleteBucket(bucket string) error
+	ListBuckets(prefix string) ([]iaas.Bucket, error)
+	ForRegion(region string) (StateStore, error)
+	HasFile(bucket, path string) (bool, error)
+	LoadFile(bucket, path string) ([]byte, error)
+	WriteFile(bucket, path string, contents []byte) error
+	DeleteFile(bucket, path string) error
+	Backend(bucket string) StateBackend
+}
+
+// NewStateStore returns the state store of the given type. An empty type selects the
+// bucket storage of provider. location is the region for s3 and gcs stores, and the
+// root directory for local stores.
+func NewStateStore(storeType, location string, provider iaas.Provider) (StateStore, error) {
+	switch strings.ToLower(storeType) {
+	case "":
+		return NewBucketStateStore(provider), nil
+	case StateStoreS3:
+		return bucketStateStoreFor(iaas.AWS, location, provider)
+	case StateStoreGCS:
+		return bucketStateStoreFor(iaas.GCP, location, provider)
+	case StateStoreLocal:
+		if location == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("error finding home directory for local state store: [%v]", err)
+			}
+			location = filepath.Join(home, ".control-tower", "state")
+		}
+		return NewLocalStateStore(location), nil
+	}
+	return nil, fmt.Errorf("state store [%s] not supported, can be %s, %s or %s", storeType, StateStoreS3, StateStoreGCS, StateStoreLocal)
+}
+
+func bucketStateStoreFor(iaasName iaas.Name, region string, provider iaas.Provider) (StateStore, error) {
+	if provider.IAAS() == iaasName && (region == "" || region == provider.Region()) {
+		return NewBucketStateStore(provider), nil
+	}
+	storeProvider, err := iaas.New(iaasName, region)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s provider for state store: [%v]", iaasName, err)
+	}
+	return NewBucketStateStore(storeProvider), nil
+}
+
+// BucketStateStore keeps state in the S3 or GCS buckets of an IAAS provider
+type BucketStateStore struct {
+	provider iaas.Provider
+}
+
+// NewBucketStateStore returns a state store backed by the buckets of provider
+func NewBucketStateStore(provider iaas.Provider) *BucketStateStore {
+	return &BucketStateStore{provider}
+}
+
+// BucketExists returns true if the named bucket exists
+func (s *BucketStateStore) BucketExists(bucket string) (bool, error) {
+	return s.provider.BucketExists(bucket)
+}
+
+// CreateBucket creates the named bucket
+func (s *BucketStateStore) CreateBucket(bucket string) error {
+	return s.provider.CreateBucket(bucket)
+}
+
+// DeleteBucket deletes the named bucket and every version of its contents
+func (s *BucketStateStore) DeleteBucket(bucket string) error {
+	return s.provider.DeleteVersionedBucket(bucket)
+}
+
+// ListBuckets lists the buckets whose names start with prefix
+func (s *BucketStateStore) ListBuckets(prefix string) ([]iaas.Bucket, error) {
+	return s.provider.ListBuckets(prefix)
+}
+
+// ForRegion returns a store able to read the buckets in region. Only region bound
+// providers need a new store, all others return the store itself.
+func (s *BucketStateStore) ForRegion(region string) (StateStore, error) {
+	regionBound, _ := s.provider.Choose(iaas.Choice{
+		AWS: true,
+		GCP: false,
+	}).(bool)
+	if !regionBound || region == "" || region == s.provider.Region() {
+		return s, nil
+	}
+	provider, err := iaas.New(s.provider.IAAS(), region)
+	if err != nil {
+		return nil, err
+	}
+	return NewBucketStateStore(provider), nil
+}
+
+// HasFile returns true if the file exists in the bucket
+func (s *BucketStateStore) HasFile(bucket, path string) (bool, error) {
+	return s.provider.HasFile(bucket, path)
+}
+
+// LoadFile loads a file from the bucket
+func (s *BucketStateStore) LoadFile(bucket, path string) ([]byte, error) {
+	return s.provider.LoadFile(bucket, path)
+}
+
+// WriteFile writes a file to the bucket
+func (s *BucketStateStore) WriteFile(bucket, path string, contents []byte) error {
+	return s.provider.WriteFile(bucket, path, contents)
+}
+
+// DeleteFile deletes a file from the bucket
+func (s *BucketStateStore) DeleteFile(bucket, path string) error {
+	return s.provider.DeleteFile(bucket, path)
+}
+
+// Backend returns the terraform backend matching the bucket storage of the provider
+func (s *BucketStateStore) Backend(bucket string) StateBackend {
+	backendType, _ := s.provider.Choose(iaas.Choice{
+		AWS: StateStoreS3,
+		GCP: StateStoreGCS,
+	}).(string)
+	return StateBackend{
+		Type:   backendType,
+		Region: s.provider.Region(),
+	}
+}
+
+// LocalStateStore keeps state on the local filesystem, with a directory per bucket
+type LocalStateStore struct {
+	root string
+}
+
+// NewLocalStateStore returns a state store rooted at the given directory
+func NewLocalStateStore(root string) *LocalStateStore {
+	return &LocalStateStore{root}
+}
+
+// BucketExists returns true if the directory for the bucket exists
+func (s *LocalStateStore) BucketExists(bucket string) (bool, error) {
+	info, err := os.Stat(s.bucketDir(bucket))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// CreateBucket creates the directory for the bucket
+func (s *LocalStateStore) CreateBucket(bucket string) error {
+	return os.MkdirAll(s.bucketDir(bucket), 0700)
+}
+
+// DeleteBucket deletes the directory for the bucket and everything in it
+func (s *LocalStateStore) DeleteBucket(bucket string) error {
+	return os.RemoveAll(s.bucketDir(bucket))
+}
+
+// ListBuckets lists the bucket directories whose names start with prefix
+func (s *LocalStateStore) ListBuckets(prefix string) ([]iaas.Bucket, error) {
+	entries, err := ioutil.ReadDir(s.root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	buckets := []iaas.Bucket{}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) {
+			buckets = append(buckets, iaas.Bucket{Name: entry.Name()})
+		}
+	}
+	return buckets, nil
+}
+
+// ForRegion returns the store itself, as local buckets are not region bound
+func (s *LocalStateStore) ForRegion(region string) (StateStore, error) {
+	return s, nil
+}
+
+// HasFile returns true if the file exists in the bucket directory
+func (s *LocalStateStore) HasFile(bucket, path string) (bool, error) {
+	_, err := os.Stat(s.filePath(bucket, path))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// LoadFile loads a file from the bucket directory
+func (s *LocalStateStore) LoadFile(bucket, path string) ([]byte, error) {
+	return ioutil.ReadFile(s.filePath(bucket, path))
+}
+
+// WriteFile atomically writes a file to the bucket directory
+func (s *LocalStateStore) WriteFile(bucket, path string, contents []byte) error {
+	target := s.filePath(bucket, path)
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(target), filepath.Base(target)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// DeleteFile deletes a file from the bucket directory, if it exists
+func (s *LocalStateStore) DeleteFile(bucket, path string) error {
+	err := os.Remove(s.filePath(bucket, path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Backend returns a local terraform backend keeping state in the bucket directory
+func (s *LocalStateStore) Backend(bucket string) StateBackend {
+	return StateBackend{
+		Type: StateStoreLocal,
+		Dir:  s.bucketDir(bucket),
+	}
+}
+
+func (s *LocalStateStore) bucketDir(bucket string) string {
+	return filepath.Join(s.root, filepath.Base(bucket))
+}
+
+func (s *LocalStateStore) filePath(bucket, path string) string {
+	return filepath.Join(s.bucketDir(bucket), filepath.Clean("/" + path))
+}
@@ -0,0 +1,160 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/EngineerBetter/control-tower/config"
+	"github.com/EngineerBetter/control-tower/iaas"
+	"github.com/EngineerBetter/control-tower/iaas/iaasfakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateStore", func() {
+	Describe("NewStateStore", func() {
+		var provider *iaasfakes.FakeProvider
+
+		BeforeEach(func() {
+			provider = &iaasfakes.FakeProvider{}
+			provider.IAASReturns(iaas.AWS)
+			provider.RegionReturns("eu-west-1")
+		})
+
+		It("defaults to the buckets of the provider", func() {
+			store, err := NewStateStore("", "", provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(store).To(BeAssignableToTypeOf(&BucketStateStore{}))
+
+			_, err = store.HasFile("bucket", "config.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(provider.HasFileCallCount()).To(Equal(1))
+		})
+
+		It("reuses the provider for an s3 store in the same region", func() {
+			store, err := NewStateStore("s3", "eu-west-1", provider)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = store.BucketExists("bucket")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(provider.BucketExistsCallCount()).To(Equal(1))
+		})
+
+		It("returns a local store", func() {
+			store, err := NewStateStore("local", "/tmp/state", provider)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(store.Backend("bucket")).To(Equal(StateBackend{Type: "local", Dir: "/tmp/state/bucket"}))
+		})
+
+		It("rejects unknown store types", func() {
+			_, err := NewStateStore("ftp", "", provider)
+			Expect(err).To(MatchError("state store [ftp] not supported, can be s3, gcs or local"))
+		})
+	})
+
+	Describe("BucketStateStore", func() {
+		It("deletes every version in the bucket", func() {
+			provider := &iaasfakes.FakeProvider{}
+			store := NewBucketStateStore(provider)
+
+			Expect(store.DeleteBucket("bucket")).To(Succeed())
+			Expect(provider.DeleteVersionedBucketArgsForCall(0)).To(Equal("bucket"))
+		})
+
+		It("describes the terraform backend of the provider", func() {
+			provider := &iaasfakes.FakeProvider{}
+			provider.RegionReturns("europe-west1")
+			provider.ChooseStub = func(c iaas.Choice) interface{} {
+				return c.GCP
+			}
+
+			Expect(NewBucketStateStore(provider).Backend("bucket")).To(Equal(StateBackend{Type: "gcs", Region: "europe-west1"}))
+		})
+
+		It("reads buckets in any region when the provider is not region bound", func() {
+			provider := &iaasfakes.FakeProvider{}
+			provider.RegionReturns("europe-west1")
+			provider.ChooseStub = func(c iaas.Choice) interface{} {
+				return c.GCP
+			}
+			store := NewBucketStateStore(provider)
+
+			regionStore, err := store.ForRegion("us-east1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(regionStore).To(BeIdenticalTo(store))
+		})
+	})
+
+	Describe("LocalStateStore", func() {
+		var root string
+		var store *LocalStateStore
+
+		BeforeEach(func() {
+			var err error
+			root, err = ioutil.TempDir("", "state-store")
+			Expect(err).ToNot(HaveOccurred())
+			store = NewLocalStateStore(root)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(root)
+		})
+
+		It("creates, checks and deletes buckets", func() {
+			exists, err := store.BucketExists("bucket")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+
+			Expect(store.CreateBucket("bucket")).To(Succeed())
+			exists, err = store.BucketExists("bucket")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			Expect(store.DeleteBucket("bucket")).To(Succeed())
+			exists, err = store.BucketExists("bucket")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("writes and loads files", func() {
+			hasFile, err := store.HasFile("bucket", "config.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hasFile).To(BeFalse())
+
+			Expect(store.WriteFile("bucket", "config.json", []byte("{}"))).To(Succeed())
+			hasFile, err = store.HasFile("bucket", "config.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hasFile).To(BeTrue())
+
+			contents, err := store.LoadFile("bucket", "config.json")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(Equal([]byte("{}")))
+
+			info, err := os.Stat(filepath.Join(root, "bucket", "config.json"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+
+		It("keeps files inside the bucket directory", func() {
+			Expect(store.WriteFile("bucket", "../../escaped", []byte("x"))).To(Succeed())
+			_, err := os.Stat(filepath.Join(root, "bucket", "escaped"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("can back a config client", func() {
+			provider := &iaasfakes.FakeProvider{}
+			provider.RegionReturns("eu-west-1")
+			client := NewWithStateStore(provider, store, "test", "")
+
+			Expect(client.EnsureBucketExists()).To(Succeed())
+			Expect(client.Update(Config{Project: "test"})).To(Succeed())
+
+			conf, err := client.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conf.Project).To(Equal("test"))
+			Expect(provider.WriteFileCallCount()).To(Equal(0))
+			Expect(filepath.Join(root, "control-tower-test-eu-west-1-config", "config.json")).To(BeAnExistingFile())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package cost_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCost(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cost Suite")
+}
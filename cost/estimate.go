@@ -0,0 +1,260 @@
+package cost
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+// Components of a deployment which are priced separately
+const (
+	ComponentDirector        = "BOSH director"
+	ComponentWeb             = "Web"
+	ComponentWorkers         = "Workers"
+	ComponentDatabase        = "Database"
+	ComponentDatabaseStorage = "Database storage"
+	ComponentNATGateway      = "NAT gateway"
+	ComponentPublicIPs       = "Public IPs"
+	ComponentDisks           = "Disks"
+)
+
+// persistentDiskSizes maps the --persistent-disk sizes of the web VM to GB, as in the cloud configs
+var persistentDiskSizes = map[string]int{
+	"small":   20,
+	"default": 50,
+	"medium":  100,
+	"large":   200,
+}
+
+// layout is what each IAAS runs for a deployment, as in its cloud config, director manifest and terraform
+type layout struct {
+	directorInstance string
+	directorDisks    map[string]int
+	vmDiskType       string
+	webDiskGB        int
+	workerDiskGB     int
+	databaseGB       int
+	publicIPs        int
+	webInstances     map[string]string
+	workerInstance   func(size, workerType string) string
+}
+
+var layouts = map[string]layout{
+	"AWS": {
+		directorInstance: "t3.small",
+		directorDisks:    map[string]int{"gp2": 25 + 20},
+		vmDiskType:       "gp2",
+		webDiskGB:        20,
+		workerDiskGB:     200,
+		databaseGB:       10,
+		publicIPs:        3,
+		webInstances: map[string]string{
+			"small":   "t3.small",
+			"medium":  "t3.medium",
+			"large":   "t3.large",
+			"xlarge":  "t3.xlarge",
+			"2xlarge": "t3.2xlarge",
+		},
+		workerInstance: func(size, workerType string) string {
+			if size == "medium" {
+				return "t3.medium"
+			}
+			if workerType == "" {
+				workerType = "m4"
+			}
+			return workerType + "." + size
+		},
+	},
+	"GCP": {
+		directorInstance: "n1-standard-1",
+		directorDisks:    map[string]int{"pd-standard": 40 + 64},
+		vmDiskType:       "pd-ssd",
+		webDiskGB:        20,
+		workerDiskGB:     200,
+		databaseGB:       10,
+		publicIPs:        3,
+		webInstances: map[string]string{
+			"small":   "n1-standard-1",
+			"medium":  "n1-standard-2",
+			"large":   "n1-standard-4",
+			"xlarge":  "n1-standard-8",
+			"2xlarge": "n1-standard-16",
+		},
+		workerInstance: func(size, _ string) string {
+			return map[string]string{
+				"medium":   "n1-standard-1",
+				"large":    "n1-standard-2",
+				"xlarge":   "n1-standard-4",
+				"2xlarge":  "n1-standard-8",
+				"4xlarge":  "n1-standard-16",
+				"10xlarge": "n1-standard-32",
+				"16xlarge": "n1-standard-64",
+			}[size]
+		},
+	},
+}
+
+// Item is the estimated monthly cost of one component of a deployment
+type Item struct {
+	Component string  `json:"component"`
+	Type      string  `json:"type"`
+	Count     int     `json:"count"`
+	Monthly   float64 `json:"monthly"`
+}
+
+// Estimate is the estimated monthly cost of a deployment in USD, broken down by component
+type Estimate struct {
+	IAAS string `json:"iaas"`
+	// Region is the region whose prices were used, which is the IAAS's default region if the table has none for the deployment's
+	Region string `json:"region"`
+	Items  []Item `json:"items"`
+	Spot   bool   `json:"spot"`
+	// WorkersOnDemand and WorkersSpot are the monthly cost of the workers on on-demand and spot or preemptible VMs
+	WorkersOnDemand float64 `json:"workers_on_demand"`
+	WorkersSpot     float64 `json:"workers_spot"`
+}
+
+// EstimateMonthly prices the VMs, database, networking and disks a deployment runs from the embedded pricing table
+func EstimateMonthly(conf config.ConfigView) (Estimate, error) {
+	p, err := loadPricing()
+	if err != nil {
+		return Estimate{}, err
+	}
+	l, ok := layouts[conf.GetIAAS()]
+	if !ok {
+		return Estimate{}, fmt.Errorf("no prices for IAAS [%s]", conf.GetIAAS())
+	}
+	prices, region, err := p.regionPrices(conf.GetIAAS(), conf.GetRegion())
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	instancePrice := func(instance string) (float64, error) {
+		hourly, ok := prices.Instances[instance]
+		if !ok {
+			return 0, fmt.Errorf("no price for instance type [%s] in %s", instance, region)
+		}
+		return hourly * HoursPerMonth, nil
+	}
+
+	e := Estimate{IAAS: conf.GetIAAS(), Region: region, Spot: conf.IsSpot()}
+
+	director, err := instancePrice(l.directorInstance)
+	if err != nil {
+		return Estimate{}, err
+	}
+	e.Items = append(e.Items, Item{Component: ComponentDirector, Type: l.directorInstance, Count: 1, Monthly: director})
+
+	webInstance, ok := l.webInstances[conf.GetConcourseWebSize()]
+	if !ok {
+		return Estimate{}, fmt.Errorf("unknown web size [%s]", conf.GetConcourseWebSize())
+	}
+	web, err := instancePrice(webInstance)
+	if err != nil {
+		return Estimate{}, err
+	}
+	e.Items = append(e.Items, Item{
+		Component: ComponentWeb,
+		Type:      fmt.Sprintf("concourse-web-%s (%s)", conf.GetConcourseWebSize(), webInstance),
+		Count:     1,
+		Monthly:   web,
+	})
+
+	workerCount := conf.GetConcourseWorkerCount()
+	workerInstance := l.workerInstance(conf.GetConcourseWorkerSize(), conf.GetWorkerType())
+	if workerInstance == "" {
+		return Estimate{}, fmt.Errorf("unknown worker size [%s]", conf.GetConcourseWorkerSize())
+	}
+	worker, err := instancePrice(workerInstance)
+	if err != nil {
+		return Estimate{}, err
+	}
+	e.WorkersOnDemand = worker * float64(workerCount)
+	e.WorkersSpot = e.WorkersOnDemand * prices.SpotRatio
+	workerType := fmt.Sprintf("concourse-%s (%s)", conf.GetConcourseWorkerSize(), workerInstance)
+	workers := e.WorkersOnDemand
+	if e.Spot {
+		workerType = fmt.Sprintf("concourse-%s (%s, spot)", conf.GetConcourseWorkerSize(), workerInstance)
+		workers = e.WorkersSpot
+	}
+	e.Items = append(e.Items, Item{Component: ComponentWorkers, Type: workerType, Count: workerCount, Monthly: workers})
+
+	database, ok := prices.Databases[conf.GetRDSInstanceClass()]
+	if !ok {
+		return Estimate{}, fmt.Errorf("no price for database [%s] in %s", conf.GetRDSInstanceClass(), region)
+	}
+	e.Items = append(e.Items,
+		Item{Component: ComponentDatabase, Type: conf.GetRDSInstanceClass(), Count: 1, Monthly: database * HoursPerMonth},
+		Item{Component: ComponentDatabaseStorage, Type: fmt.Sprintf("%dGB", l.databaseGB), Count: 1, Monthly: float64(l.databaseGB) * prices.DatabaseStorage},
+		Item{Component: ComponentNATGateway, Type: "-", Count: 1, Monthly: prices.NATGateway * HoursPerMonth},
+		Item{Component: ComponentPublicIPs, Type: "-", Count: l.publicIPs, Monthly: prices.PublicIP * HoursPerMonth * float64(l.publicIPs)},
+	)
+
+	persistentDiskGB, ok := persistentDiskSizes[conf.GetPersistentDiskSize()]
+	if !ok {
+		return Estimate{}, fmt.Errorf("unknown persistent disk size [%s]", conf.GetPersistentDiskSize())
+	}
+	diskGB := map[string]int{}
+	for diskType, gb := range l.directorDisks {
+		diskGB[diskType] += gb
+	}
+	diskGB[l.vmDiskType] += l.webDiskGB + persistentDiskGB + l.workerDiskGB*workerCount
+	var diskTypes []string
+	for diskType := range diskGB {
+		diskTypes = append(diskTypes, diskType)
+	}
+	sort.Strings(diskTypes)
+	for _, diskType := range diskTypes {
+		e.Items = append(e.Items, Item{
+			Component: ComponentDisks,
+			Type:      fmt.Sprintf("%s %dGB", diskType, diskGB[diskType]),
+			Count:     1,
+			Monthly:   float64(diskGB[diskType]) * prices.Disks[diskType],
+		})
+	}
+
+	return e, nil
+}
+
+// Total is the estimated monthly cost of the whole deployment
+func (e Estimate) Total() float64 {
+	var total float64
+	for _, item := range e.Items {
+		total += item.Monthly
+	}
+	return total
+}
+
+// Write writes a table of the cost of each component, the total and how much running the workers
+// on spot or on-demand VMs instead would change it
+func (e Estimate) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Component\tType\tCount\tMonthly (USD)")
+	for _, item := range e.Items {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f\n", item.Component, item.Type, item.Count, item.Monthly)
+	}
+	fmt.Fprintf(tw, "Total\t\t\t%.2f\n", e.Total())
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	difference := e.WorkersOnDemand - e.WorkersSpot
+	spotName := "spot"
+	if e.IAAS == "GCP" {
+		spotName = "preemptible"
+	}
+	var err error
+	if e.Spot {
+		_, err = fmt.Fprintf(w, "\nWorkers run on %s VMs, saving an estimated %.2f a month over on-demand VMs (total %.2f)\n", spotName, difference, e.Total()+difference)
+	} else {
+		_, err = fmt.Fprintf(w, "\nWorkers run on on-demand VMs, %s VMs would save an estimated %.2f a month (total %.2f)\n", spotName, difference, e.Total()-difference)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Estimated from %s %s list prices, excluding data transfer, NAT gateway data processing and taxes\n", e.IAAS, e.Region)
+	return err
+}
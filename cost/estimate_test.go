@@ -0,0 +1,138 @@
+package cost_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/EngineerBetter/control-tower/config"
+	. "github.com/EngineerBetter/control-tower/cost"
+)
+
+var _ = Describe("EstimateMonthly", func() {
+	var conf config.Config
+
+	BeforeEach(func() {
+		conf = config.Config{
+			IAAS:                 "AWS",
+			Region:               "eu-west-1",
+			ConcourseWebSize:     "small",
+			ConcourseWorkerSize:  "xlarge",
+			ConcourseWorkerCount: 2,
+			RDSInstanceClass:     "db.t3.small",
+			PersistentDisk:       "default",
+			VMProvisioningType:   config.SPOT,
+		}
+	})
+
+	itemsByComponent := func(e Estimate) map[string]Item {
+		items := map[string]Item{}
+		for _, item := range e.Items {
+			items[item.Component+" "+item.Type] = item
+		}
+		return items
+	}
+
+	It("prices each component of an AWS deployment", func() {
+		estimate, err := EstimateMonthly(conf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(estimate.Region).To(Equal("eu-west-1"))
+
+		items := itemsByComponent(estimate)
+		Expect(items).To(HaveKey("BOSH director t3.small"))
+		Expect(items["Web concourse-web-small (t3.small)"].Monthly).To(BeNumerically("~", 0.0228*730, 0.01))
+		Expect(items["Workers concourse-xlarge (m4.xlarge, spot)"].Count).To(Equal(2))
+		Expect(items["Database db.t3.small"].Monthly).To(BeNumerically("~", 0.039*730, 0.01))
+		Expect(items["NAT gateway -"].Monthly).To(BeNumerically("~", 0.048*730, 0.01))
+		Expect(items["Public IPs -"].Count).To(Equal(3))
+		Expect(items["Disks gp2 515GB"].Monthly).To(BeNumerically("~", 515*0.11, 0.01))
+	})
+
+	It("prices spot workers at a fraction of on-demand ones", func() {
+		estimate, err := EstimateMonthly(conf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(estimate.WorkersOnDemand).To(BeNumerically("~", 2*0.222*730, 0.01))
+		Expect(estimate.WorkersSpot).To(BeNumerically("<", estimate.WorkersOnDemand))
+		Expect(itemsByComponent(estimate)["Workers concourse-xlarge (m4.xlarge, spot)"].Monthly).To(Equal(estimate.WorkersSpot))
+	})
+
+	It("uses the worker type", func() {
+		conf.WorkerType = "m5"
+		conf.VMProvisioningType = config.ON_DEMAND
+		estimate, err := EstimateMonthly(conf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(itemsByComponent(estimate)["Workers concourse-xlarge (m5.xlarge)"].Monthly).To(Equal(estimate.WorkersOnDemand))
+	})
+
+	It("prices a GCP deployment", func() {
+		conf.IAAS = "GCP"
+		conf.Region = "europe-west2"
+		conf.RDSInstanceClass = "db-g1-small"
+		estimate, err := EstimateMonthly(conf)
+		Expect(err).ToNot(HaveOccurred())
+
+		items := itemsByComponent(estimate)
+		Expect(items).To(HaveKey("Web concourse-web-small (n1-standard-1)"))
+		Expect(items).To(HaveKey("Workers concourse-xlarge (n1-standard-4, spot)"))
+		Expect(items).To(HaveKey("Disks pd-standard 104GB"))
+		Expect(items).To(HaveKey("Disks pd-ssd 470GB"))
+	})
+
+	It("falls back to the default region of the IAAS", func() {
+		conf.Region = "ap-southeast-2"
+		estimate, err := EstimateMonthly(conf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(estimate.Region).To(Equal("eu-west-1"))
+	})
+
+	It("errors for a size it has no price for", func() {
+		conf.RDSInstanceClass = "db.x1.huge"
+		_, err := EstimateMonthly(conf)
+		Expect(err).To(MatchError("no price for database [db.x1.huge] in eu-west-1"))
+	})
+
+	It("errors for an unknown IAAS", func() {
+		conf.IAAS = "Azure"
+		_, err := EstimateMonthly(conf)
+		Expect(err).To(MatchError("no prices for IAAS [Azure]"))
+	})
+})
+
+var _ = Describe("Estimate", func() {
+	estimate := Estimate{
+		IAAS:   "AWS",
+		Region: "eu-west-1",
+		Items: []Item{
+			{Component: ComponentWeb, Type: "concourse-web-small (t3.small)", Count: 1, Monthly: 16.64},
+			{Component: ComponentWorkers, Type: "concourse-xlarge (m4.xlarge)", Count: 1, Monthly: 162.06},
+		},
+		WorkersOnDemand: 162.06,
+		WorkersSpot:     56.72,
+	}
+
+	It("totals the monthly cost of each component", func() {
+		Expect(estimate.Total()).To(BeNumerically("~", 178.70, 0.001))
+	})
+
+	It("writes a breakdown with what spot workers would save", func() {
+		var buf bytes.Buffer
+		Expect(estimate.Write(&buf)).To(Succeed())
+		Expect(buf.String()).To(Equal(`Component  Type                            Count  Monthly (USD)
+Web        concourse-web-small (t3.small)  1      16.64
+Workers    concourse-xlarge (m4.xlarge)    1      162.06
+Total                                             178.70
+
+Workers run on on-demand VMs, spot VMs would save an estimated 105.34 a month (total 73.36)
+Estimated from AWS eu-west-1 list prices, excluding data transfer, NAT gateway data processing and taxes
+`))
+	})
+
+	It("writes what spot workers save when they are used", func() {
+		spotEstimate := estimate
+		spotEstimate.Spot = true
+		var buf bytes.Buffer
+		Expect(spotEstimate.Write(&buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("Workers run on spot VMs, saving an estimated 105.34 a month over on-demand VMs (total 284.04)"))
+	})
+})
@@ -0,0 +1,57 @@
+package cost
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// HoursPerMonth turns hourly prices into monthly ones
+const HoursPerMonth = 730
+
+//go:embed assets/pricing.json
+var pricingContents []byte
+
+// pricing is the list price of everything a deployment runs, per IAAS and region, in USD
+type pricing map[string]struct {
+	DefaultRegion string                   `json:"default_region"`
+	Regions       map[string]regionPricing `json:"regions"`
+}
+
+type regionPricing struct {
+	// Instances are hourly on-demand prices of VM instance and machine types
+	Instances map[string]float64 `json:"instances"`
+	// SpotRatio is the typical price of a spot or preemptible VM as a fraction of the on-demand price
+	SpotRatio float64 `json:"spot_ratio"`
+	// Databases are hourly prices of RDS instance classes and CloudSQL tiers
+	Databases map[string]float64 `json:"databases"`
+	// Disks are prices per GB-month of each disk type
+	Disks map[string]float64 `json:"disks"`
+	// DatabaseStorage is the price per GB-month of database storage
+	DatabaseStorage float64 `json:"database_storage"`
+	// NATGateway is the hourly price of a NAT gateway, excluding data processed
+	NATGateway float64 `json:"nat_gateway"`
+	// PublicIP is the hourly price of a public IP address
+	PublicIP float64 `json:"public_ip"`
+}
+
+func loadPricing() (pricing, error) {
+	var p pricing
+	if err := json.Unmarshal(pricingContents, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse the pricing table: [%v]", err)
+	}
+	return p, nil
+}
+
+// regionPrices returns the prices for a region, falling back to the IAAS's default region
+// if the table has none for it, along with the region whose prices were used
+func (p pricing) regionPrices(iaas, region string) (regionPricing, string, error) {
+	iaasPricing, ok := p[iaas]
+	if !ok {
+		return regionPricing{}, "", fmt.Errorf("no prices for IAAS [%s]", iaas)
+	}
+	if prices, ok := iaasPricing.Regions[region]; ok {
+		return prices, region, nil
+	}
+	return iaasPricing.Regions[iaasPricing.DefaultRegion], iaasPricing.DefaultRegion, nil
+}
@@ -0,0 +1,141 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Phases of deploy
+const (
+	PhaseBucketEnsure     = "bucket-ensure"
+	PhaseConfigMerge      = "config-merge"
+	PhaseTerraformApply   = "terraform-apply"
+	PhaseCertGeneration   = "cert-generation"
+	PhaseBOSHCreateEnv    = "bosh-create-env"
+	PhaseBOSHCloudConfig  = "bosh-cloud-config"
+	PhaseBOSHStemcell     = "bosh-stemcell-upload"
+	PhaseDatabaseCreation = "database-creation"
+	PhaseConcourseDeploy  = "concourse-deploy"
+	PhaseSetPipeline      = "set-pipeline"
+	PhaseConfigSave       = "config-save"
+)
+
+// Phases of destroy
+const (
+	PhaseConfigLoad       = "config-load"
+	PhaseVMDeletion       = "vm-deletion"
+	PhaseTerraformDestroy = "terraform-destroy"
+	PhaseVolumeDeletion   = "volume-deletion"
+	PhaseConfigDelete     = "config-delete"
+)
+
+// Phases of maintain
+const (
+	PhaseAddNewCA                = "add-new-ca"
+	PhaseRecreateVMs             = "recreate-vms"
+	PhaseRemoveOldCA             = "remove-old-ca"
+	PhaseDirectorCleanup         = "director-creds-cleanup"
+	PhaseRotateDirectorPassword  = "rotate-director-password"
+	PhaseRotateRDSPassword       = "rotate-rds-password"
+	PhaseRotateConcoursePassword = "rotate-concourse-password"
+	PhaseRotateCredhubSecret     = "rotate-credhub-admin-secret"
+	PhaseDirectorCertGeneration  = "director-cert-generation"
+	PhaseAddNewDirectorCA        = "add-new-director-ca"
+	PhaseLandWorker              = "land-worker"
+	PhaseRecreateWorker          = "recreate-worker"
+	PhaseDBSnapshot              = "db-snapshot"
+	PhaseDBRestore               = "db-restore"
+	PhaseSSHKeyGeneration        = "ssh-key-generation"
+	PhaseSSHKeyRegistration      = "ssh-key-registration"
+	PhaseEncryptionKeyGeneration = "encryption-key-generation"
+	PhaseAddNewEncryptionKey     = "add-new-encryption-key"
+	PhaseWaitForReencryption     = "wait-for-reencryption"
+	PhaseRemoveOldEncryptionKey  = "remove-old-encryption-key"
+	PhaseBOSHCleanup             = "bosh-cleanup"
+	PhaseOrphanedDiskDeletion    = "orphaned-disk-deletion"
+	PhaseCompensate              = "compensate"
+)
+
+// Statuses of a finished phase
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+)
+
+// Event describes the outcome of a single phase of an operation
+type Event struct {
+	Timestamp time.Time `json:"timestamp"`
+	Phase     string    `json:"phase"`
+	Status    string    `json:"status"`
+	Duration  float64   `json:"duration_seconds"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Recorder runs the phases of an operation, reporting on each as it finishes
+type Recorder interface {
+	Record(phase string, fn func() error) error
+	Skip(phase string)
+}
+
+// NopRecorder runs phases without reporting on them
+type NopRecorder struct{}
+
+// Record runs fn
+func (NopRecorder) Record(phase string, fn func() error) error {
+	return fn()
+}
+
+// Skip does nothing
+func (NopRecorder) Skip(phase string) {}
+
+// JSONRecorder writes an Event as a line of JSON for every phase it runs
+type JSONRecorder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewJSONRecorder returns a recorder writing newline delimited JSON events to w
+func NewJSONRecorder(w io.Writer) *JSONRecorder {
+	return &JSONRecorder{w: w, now: time.Now}
+}
+
+// Record runs fn and then writes an event with its duration and any error it returned.
+// The error from fn is returned unchanged.
+func (r *JSONRecorder) Record(phase string, fn func() error) error {
+	start := r.now()
+	err := fn()
+	end := r.now()
+
+	event := Event{
+		Timestamp: end.UTC(),
+		Phase:     phase,
+		Status:    StatusSucceeded,
+		Duration:  end.Sub(start).Seconds(),
+	}
+	if err != nil {
+		event.Status = StatusFailed
+		event.Error = err.Error()
+	}
+
+	r.write(event)
+	return err
+}
+
+// Skip writes an event for a phase which did not need to run
+func (r *JSONRecorder) Skip(phase string) {
+	r.write(Event{
+		Timestamp: r.now().UTC(),
+		Phase:     phase,
+		Status:    StatusSkipped,
+	})
+}
+
+func (r *JSONRecorder) write(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	json.NewEncoder(r.w).Encode(event)
+}
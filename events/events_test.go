@@ -0,0 +1,71 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/events"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONRecorder", func() {
+	var output *bytes.Buffer
+	var recorder *events.JSONRecorder
+
+	BeforeEach(func() {
+		output = &bytes.Buffer{}
+		recorder = events.NewJSONRecorder(output)
+	})
+
+	decode := func() []events.Event {
+		var decoded []events.Event
+		for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+			var event events.Event
+			Expect(json.Unmarshal([]byte(line), &event)).To(Succeed())
+			decoded = append(decoded, event)
+		}
+		return decoded
+	}
+
+	It("writes one line per phase", func() {
+		Expect(recorder.Record(events.PhaseBucketEnsure, func() error { return nil })).To(Succeed())
+		Expect(recorder.Record(events.PhaseConfigMerge, func() error { return nil })).To(Succeed())
+
+		decoded := decode()
+		Expect(decoded).To(HaveLen(2))
+		Expect(decoded[0].Phase).To(Equal(events.PhaseBucketEnsure))
+		Expect(decoded[0].Status).To(Equal(events.StatusSucceeded))
+		Expect(decoded[0].Error).To(BeEmpty())
+		Expect(decoded[0].Timestamp.IsZero()).To(BeFalse())
+		Expect(decoded[0].Duration).To(BeNumerically(">=", 0))
+		Expect(decoded[1].Phase).To(Equal(events.PhaseConfigMerge))
+	})
+
+	It("records and returns the error of a failed phase", func() {
+		err := recorder.Record(events.PhaseTerraformApply, func() error { return errors.New("quota exceeded") })
+		Expect(err).To(MatchError("quota exceeded"))
+
+		decoded := decode()
+		Expect(decoded).To(HaveLen(1))
+		Expect(decoded[0].Status).To(Equal(events.StatusFailed))
+		Expect(decoded[0].Error).To(Equal("quota exceeded"))
+	})
+
+	It("omits the error field when a phase succeeds", func() {
+		Expect(recorder.Record(events.PhaseConfigSave, func() error { return nil })).To(Succeed())
+		Expect(output.String()).ToNot(ContainSubstring(`"error"`))
+	})
+
+	It("writes an event for a skipped phase", func() {
+		recorder.Skip(events.PhaseTerraformApply)
+
+		decoded := decode()
+		Expect(decoded).To(HaveLen(1))
+		Expect(decoded[0].Phase).To(Equal(events.PhaseTerraformApply))
+		Expect(decoded[0].Status).To(Equal(events.StatusSkipped))
+		Expect(decoded[0].Duration).To(BeZero())
+	})
+})
@@ -15,7 +15,7 @@ func NewAWSPipeline() Pipeline {
 }
 
 //BuildPipelineParams builds params for AWS control-tower self update pipeline
-func (a AWSPipeline) BuildPipelineParams(deployment, namespace, region, domain, allowIps, iaas string) (Pipeline, error) {
+func (a AWSPipeline) BuildPipelineParams(deployment, namespace, region, domain, allowIps, iaas, stateStore, stateStoreLocation string) (Pipeline, error) {
 	return AWSPipeline{
 		PipelineTemplateParams: PipelineTemplateParams{
 			ControlTowerVersion: ControlTowerVersion,
@@ -25,6 +25,8 @@ func (a AWSPipeline) BuildPipelineParams(deployment, namespace, region, domain,
 			Namespace:           namespace,
 			Region:              region,
 			IaaS:                iaas,
+			StateStore:          stateStore,
+			StateStoreLocation:  stateStoreLocation,
 		},
 	}, nil
 }
@@ -52,6 +54,8 @@ jobs:
       DEPLOYMENT: "{{ .Deployment }}"
       IAAS: "{{ .IaaS }}"
       NAMESPACE: "{{ .Namespace }}"
+      STATE_STORE: "{{ .StateStore }}"
+      STATE_STORE_LOCATION: "{{ .StateStoreLocation }}"
       ALLOW_IPS: "{{ .AllowIPs }}"
       SELF_UPDATE: true
     config:
@@ -88,6 +92,8 @@ jobs:
       DEPLOYMENT: "{{ .Deployment }}"
       IAAS: "{{ .IaaS }}"
       NAMESPACE: "{{ .Namespace }}"
+      STATE_STORE: "{{ .StateStore }}"
+      STATE_STORE_LOCATION: "{{ .StateStoreLocation }}"
       ALLOW_IPS: "{{ .AllowIPs }}"
       SELF_UPDATE: true
     config:
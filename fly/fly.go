@@ -3,6 +3,7 @@ package fly
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -28,6 +29,8 @@ var ControlTowerVersion = "COMPILE_TIME_VARIABLE_fly_control_tower_version"
 type IClient interface {
 	CanConnect() (bool, error)
 	SetDefaultPipeline(config config.ConfigView, allowFlyVersionDiscrepancy bool) error
+	Workers() ([]Worker, error)
+	LandWorker(name string) error
 	Cleanup() error
 }
 
@@ -39,6 +42,14 @@ type Client struct {
 	stdout      io.Writer
 	stderr      io.Writer
 	versionFile []byte
+	loggedIn    bool
+}
+
+// Worker represents a worker registered with concourse
+type Worker struct {
+	Name             string `json:"name"`
+	State            string `json:"state"`
+	ActiveContainers int    `json:"active_containers"`
 }
 
 // Credentials represents credentials needed to connect to concourse
@@ -101,12 +112,12 @@ func New(provider iaas.Provider, creds Credentials, stdout, stderr io.Writer, ve
 		return nil, errors.New("fly.go: IAAS not recognised")
 	}
 	return &Client{
-		pipeline,
-		tempDir,
-		creds,
-		stdout,
-		stderr,
-		versionFile,
+		pipeline:    pipeline,
+		tempDir:     tempDir,
+		creds:       creds,
+		stdout:      stdout,
+		stderr:      stderr,
+		versionFile: versionFile,
 	}, nil
 }
 
@@ -196,6 +207,36 @@ func (client *Client) SetDefaultPipeline(config config.ConfigView, allowFlyVersi
 	return client.run("unpause-pipeline", "--pipeline", pipelineName)
 }
 
+// Workers lists the workers registered with concourse
+func (client *Client) Workers() ([]Worker, error) {
+	if err := client.ensureLoggedIn(); err != nil {
+		return nil, err
+	}
+
+	output := new(bytes.Buffer)
+	cmd := client.runFly("--target", client.creds.Target, "workers", "--json")
+	cmd.Stdout = output
+	cmd.Stderr = client.stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+
+	var workers []Worker
+	if err := json.Unmarshal(output.Bytes(), &workers); err != nil {
+		return nil, fmt.Errorf("error parsing `fly workers` output: [%v]", err)
+	}
+	return workers, nil
+}
+
+// LandWorker stops new builds being scheduled on a worker so that it can be removed once its builds finish
+func (client *Client) LandWorker(name string) error {
+	if err := client.ensureLoggedIn(); err != nil {
+		return err
+	}
+
+	return client.run("land-worker", "--worker", name)
+}
+
 func (client *Client) writePipelineConfig(pipelinePath string, config config.ConfigView) error {
 	fileHandler, err := os.Create(pipelinePath)
 	if err != nil {
@@ -203,7 +244,7 @@ func (client *Client) writePipelineConfig(pipelinePath string, config config.Con
 	}
 	defer fileHandler.Close()
 
-	params, err := client.pipeline.BuildPipelineParams(config.GetDeployment(), config.GetNamespace(), config.GetRegion(), config.GetDomain(), config.GetAllowIPsUnformatted(), config.GetIAAS())
+	params, err := client.pipeline.BuildPipelineParams(config.GetDeployment(), config.GetNamespace(), config.GetRegion(), config.GetDomain(), config.GetAllowIPsUnformatted(), config.GetIAAS(), config.GetStateStore(), config.GetStateStoreLocation())
 	if err != nil {
 		return err
 	}
@@ -252,6 +293,18 @@ func (client *Client) login() error {
 	return fmt.Errorf("failed to log in to %s after %d seconds", client.creds.API, attempts*secondsBetweenAttempts)
 }
 
+// ensureLoggedIn logs in unless an earlier command already has
+func (client *Client) ensureLoggedIn() error {
+	if client.loggedIn {
+		return nil
+	}
+	if err := client.login(); err != nil {
+		return err
+	}
+	client.loggedIn = true
+	return nil
+}
+
 func (client *Client) sync() error {
 	return client.run("sync")
 }
@@ -82,3 +82,49 @@ func TestClient_runFly(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Workers(t *testing.T) {
+	tmpDir, _ := util.NewTempDir()
+	tests := []struct {
+		name      string
+		cmdOutput string
+		want      []Worker
+		wantErr   bool
+	}{{
+		name:      "running and landing workers",
+		cmdOutput: `[{"name":"abc-123","state":"running","active_containers":4},{"name":"def-456","state":"landing","active_containers":0}]`,
+		want: []Worker{
+			{Name: "abc-123", State: "running", ActiveContainers: 4},
+			{Name: "def-456", State: "landing", ActiveContainers: 0},
+		},
+	}, {
+		name:      "unparseable output",
+		cmdOutput: "not json",
+		wantErr:   true,
+	}}
+
+	execCommand = fakeExecCommand
+	defer func() { execCommand = exec.Command }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				tempDir:  tmpDir,
+				creds:    Credentials{Target: "test"},
+				stdout:   io.Discard,
+				stderr:   io.Discard,
+				loggedIn: true,
+			}
+			os.Setenv("TEST_HELPER_OUTPUT", tt.cmdOutput)
+
+			got, err := client.Workers()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.Workers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.Workers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -31,6 +31,17 @@ type FakeIClient struct {
 	cleanupReturnsOnCall map[int]struct {
 		result1 error
 	}
+	LandWorkerStub        func(string) error
+	landWorkerMutex       sync.RWMutex
+	landWorkerArgsForCall []struct {
+		arg1 string
+	}
+	landWorkerReturns struct {
+		result1 error
+	}
+	landWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetDefaultPipelineStub        func(config.ConfigView, bool) error
 	setDefaultPipelineMutex       sync.RWMutex
 	setDefaultPipelineArgsForCall []struct {
@@ -43,6 +54,18 @@ type FakeIClient struct {
 	setDefaultPipelineReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WorkersStub        func() ([]fly.Worker, error)
+	workersMutex       sync.RWMutex
+	workersArgsForCall []struct {
+	}
+	workersReturns struct {
+		result1 []fly.Worker
+		result2 error
+	}
+	workersReturnsOnCall map[int]struct {
+		result1 []fly.Worker
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -156,6 +179,67 @@ func (fake *FakeIClient) CleanupReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIClient) LandWorker(arg1 string) error {
+	fake.landWorkerMutex.Lock()
+	ret, specificReturn := fake.landWorkerReturnsOnCall[len(fake.landWorkerArgsForCall)]
+	fake.landWorkerArgsForCall = append(fake.landWorkerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.LandWorkerStub
+	fakeReturns := fake.landWorkerReturns
+	fake.recordInvocation("LandWorker", []interface{}{arg1})
+	fake.landWorkerMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeIClient) LandWorkerCallCount() int {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return len(fake.landWorkerArgsForCall)
+}
+
+func (fake *FakeIClient) LandWorkerCalls(stub func(string) error) {
+	fake.landWorkerMutex.Lock()
+	defer fake.landWorkerMutex.Unlock()
+	fake.LandWorkerStub = stub
+}
+
+func (fake *FakeIClient) LandWorkerArgsForCall(i int) string {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	argsForCall := fake.landWorkerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeIClient) LandWorkerReturns(result1 error) {
+	fake.landWorkerMutex.Lock()
+	defer fake.landWorkerMutex.Unlock()
+	fake.LandWorkerStub = nil
+	fake.landWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIClient) LandWorkerReturnsOnCall(i int, result1 error) {
+	fake.landWorkerMutex.Lock()
+	defer fake.landWorkerMutex.Unlock()
+	fake.LandWorkerStub = nil
+	if fake.landWorkerReturnsOnCall == nil {
+		fake.landWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.landWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIClient) SetDefaultPipeline(arg1 config.ConfigView, arg2 bool) error {
 	fake.setDefaultPipelineMutex.Lock()
 	ret, specificReturn := fake.setDefaultPipelineReturnsOnCall[len(fake.setDefaultPipelineArgsForCall)]
@@ -218,6 +302,62 @@ func (fake *FakeIClient) SetDefaultPipelineReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIClient) Workers() ([]fly.Worker, error) {
+	fake.workersMutex.Lock()
+	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
+	fake.workersArgsForCall = append(fake.workersArgsForCall, struct {
+	}{})
+	stub := fake.WorkersStub
+	fakeReturns := fake.workersReturns
+	fake.recordInvocation("Workers", []interface{}{})
+	fake.workersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIClient) WorkersCallCount() int {
+	fake.workersMutex.RLock()
+	defer fake.workersMutex.RUnlock()
+	return len(fake.workersArgsForCall)
+}
+
+func (fake *FakeIClient) WorkersCalls(stub func() ([]fly.Worker, error)) {
+	fake.workersMutex.Lock()
+	defer fake.workersMutex.Unlock()
+	fake.WorkersStub = stub
+}
+
+func (fake *FakeIClient) WorkersReturns(result1 []fly.Worker, result2 error) {
+	fake.workersMutex.Lock()
+	defer fake.workersMutex.Unlock()
+	fake.WorkersStub = nil
+	fake.workersReturns = struct {
+		result1 []fly.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIClient) WorkersReturnsOnCall(i int, result1 []fly.Worker, result2 error) {
+	fake.workersMutex.Lock()
+	defer fake.workersMutex.Unlock()
+	fake.WorkersStub = nil
+	if fake.workersReturnsOnCall == nil {
+		fake.workersReturnsOnCall = make(map[int]struct {
+			result1 []fly.Worker
+			result2 error
+		})
+	}
+	fake.workersReturnsOnCall[i] = struct {
+		result1 []fly.Worker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -225,8 +365,12 @@ func (fake *FakeIClient) Invocations() map[string][][]interface{} {
 	defer fake.canConnectMutex.RUnlock()
 	fake.cleanupMutex.RLock()
 	defer fake.cleanupMutex.RUnlock()
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
 	fake.setDefaultPipelineMutex.RLock()
 	defer fake.setDefaultPipelineMutex.RUnlock()
+	fake.workersMutex.RLock()
+	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -16,7 +16,7 @@ func NewGCPPipeline() Pipeline {
 }
 
 //BuildPipelineParams builds params for AWS control-tower self update pipeline
-func (a GCPPipeline) BuildPipelineParams(deployment, namespace, region, domain, allowIps, iaas string) (Pipeline, error) {
+func (a GCPPipeline) BuildPipelineParams(deployment, namespace, region, domain, allowIps, iaas, stateStore, stateStoreLocation string) (Pipeline, error) {
 	return GCPPipeline{
 		PipelineTemplateParams: PipelineTemplateParams{
 			ControlTowerVersion: ControlTowerVersion,
@@ -26,6 +26,8 @@ func (a GCPPipeline) BuildPipelineParams(deployment, namespace, region, domain,
 			Namespace:           namespace,
 			Region:              region,
 			IaaS:                iaas,
+			StateStore:          stateStore,
+			StateStoreLocation:  stateStoreLocation,
 		},
 	}, nil
 }
@@ -52,6 +54,8 @@ jobs:
       GCPCreds: ((google_self_update_credentials))
       IAAS: "{{ .IaaS }}"
       NAMESPACE: "{{ .Namespace }}"
+      STATE_STORE: "{{ .StateStore }}"
+      STATE_STORE_LOCATION: "{{ .StateStoreLocation }}"
       ALLOW_IPS: "{{ .AllowIPs }}"
       SELF_UPDATE: true
     config:
@@ -88,6 +92,8 @@ jobs:
       GCPCreds: ((google_self_update_credentials))
       IAAS: "{{ .IaaS }}"
       NAMESPACE: "{{ .Namespace }}"
+      STATE_STORE: "{{ .StateStore }}"
+      STATE_STORE_LOCATION: "{{ .StateStoreLocation }}"
       ALLOW_IPS: "{{ .AllowIPs }}"
       SELF_UPDATE: true
     config:
@@ -2,7 +2,7 @@ package fly
 
 // Pipeline is interface for self update pipeline
 type Pipeline interface {
-	BuildPipelineParams(deployment, namespace, region, domain, allowIps, iaas string) (Pipeline, error)
+	BuildPipelineParams(deployment, namespace, region, domain, allowIps, iaas, stateStore, stateStoreLocation string) (Pipeline, error)
 	GetConfigTemplate() string
 }
 
@@ -14,6 +14,8 @@ type PipelineTemplateParams struct {
 	Namespace           string
 	Region              string
 	IaaS                string
+	StateStore          string
+	StateStoreLocation  string
 }
 
 const selfUpdateResources = `
@@ -0,0 +1,119 @@
+package iaas
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2/google"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+)
+
+func (g *GCPProvider) sqlAdminService() (*sqladmin.Service, string, error) {
+	project, err := g.Attr("project")
+	if err != nil {
+		return nil, "", err
+	}
+
+	c, err := google.DefaultClient(g.ctx, sqladmin.CloudPlatformScope)
+	if err != nil {
+		return nil, "", err
+	}
+
+	service, err := sqladmin.New(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return service, project, nil
+}
+
+// SnapshotDB takes an on-demand backup of a Cloud SQL instance, described as name, and returns the ID of the backup run
+func (g *GCPProvider) SnapshotDB(instance, name string) (string, error) {
+	service, project, err := g.sqlAdminService()
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("Creating backup %s of Cloud SQL instance %s\n", name, instance)
+	operation, err := service.BackupRuns.Insert(project, instance, &sqladmin.BackupRun{
+		Description: name,
+	}).Context(g.ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("create backup of %s: %w", instance, err)
+	}
+	if err = g.waitForSQLOperation(service, project, operation.Name); err != nil {
+		return "", err
+	}
+
+	var backupRunID int64
+	err = service.BackupRuns.List(project, instance).Pages(g.ctx, func(page *sqladmin.BackupRunsListResponse) error {
+		for _, backupRun := range page.Items {
+			if backupRun.Description == name {
+				backupRunID = backupRun.Id
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("list backups of %s: %w", instance, err)
+	}
+	if backupRunID == 0 {
+		return "", fmt.Errorf("backup %s of %s not found", name, instance)
+	}
+
+	return strconv.FormatInt(backupRunID, 10), nil
+}
+
+// RestoreDB restores a Cloud SQL instance in place from one of its backup runs. As no instance is
+// replaced, replaced is unused.
+func (g *GCPProvider) RestoreDB(instance, snapshot, replaced string) error {
+	backupRunID, err := strconv.ParseInt(snapshot, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Cloud SQL backup ID must be a number, not [%s]", snapshot)
+	}
+
+	service, project, err := g.sqlAdminService()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restoring Cloud SQL instance %s from backup %s\n", instance, snapshot)
+	operation, err := service.Instances.RestoreBackup(project, instance, &sqladmin.InstancesRestoreBackupRequest{
+		RestoreBackupContext: &sqladmin.RestoreBackupContext{
+			BackupRunId: backupRunID,
+			InstanceId:  instance,
+			Project:     project,
+		},
+	}).Context(g.ctx).Do()
+	if err != nil {
+		return fmt.Errorf("restore %s from backup %s: %w", instance, snapshot, err)
+	}
+
+	return g.waitForSQLOperation(service, project, operation.Name)
+}
+
+// UndoRestoreDB does nothing, as Cloud SQL restores in place and so doesn't replace an instance which could be put back
+func (g *GCPProvider) UndoRestoreDB(instance, replaced string) error {
+	return nil
+}
+
+func (g *GCPProvider) waitForSQLOperation(service *sqladmin.Service, project, name string) error {
+	start := time.Now().UTC()
+	for {
+		operation, err := service.Operations.Get(project, name).Context(g.ctx).Do()
+		if err != nil {
+			return err
+		}
+		if operation.Status == "DONE" {
+			if operation.Error != nil && len(operation.Error.Errors) > 0 {
+				return fmt.Errorf("Cloud SQL operation %s failed: %s", name, operation.Error.Errors[0].Message)
+			}
+			return nil
+		}
+		if time.Since(start) > time.Hour*2 {
+			return fmt.Errorf("Cloud SQL operation %s not done after 2 hours", name)
+		}
+		fmt.Printf("Waiting for Cloud SQL operation %s to finish\n", name)
+		time.Sleep(time.Second * 30)
+	}
+}
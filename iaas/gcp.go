@@ -164,6 +164,34 @@ func (g *GCPProvider) BucketExists(name string) (bool, error) {
 	return false, nil
 }
 
+// ListBuckets returns every bucket in the project whose name starts with prefix,
+// along with the location each bucket was created in
+func (g *GCPProvider) ListBuckets(prefix string) ([]Bucket, error) {
+	project, err := g.Attr("project")
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := []Bucket{}
+	it := g.storage.Buckets(g.ctx, project)
+	it.Prefix = prefix
+	for {
+		battrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing buckets in project [%v]: [%v]", project, err)
+		}
+		buckets = append(buckets, Bucket{
+			Name:   battrs.Name,
+			Region: strings.ToLower(battrs.Location),
+		})
+	}
+
+	return buckets, nil
+}
+
 func (g *GCPProvider) HasFile(bucket, path string) (bool, error) {
 	o := g.storage.Bucket(bucket).Object(path)
 	_, err := o.Attrs(g.ctx)
@@ -208,6 +236,14 @@ func (g *GCPProvider) WriteFile(bucket, path string, contents []byte) error {
 	return nil
 }
 
+func (g *GCPProvider) DeleteFile(bucket, path string) error {
+	err := g.storage.Bucket(bucket).Object(path).Delete(g.ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil
+	}
+	return err
+}
+
 func (g *GCPProvider) Region() string {
 	return g.region
 }
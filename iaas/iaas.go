@@ -42,6 +42,12 @@ func Validate(name string) (Name, error) {
 	return Unknown, fmt.Errorf("cannot map iaas [%s] as any of %+v", name, names[1:])
 }
 
+// Bucket describes a storage bucket and the region it resides in
+type Bucket struct {
+	Name   string
+	Region string
+}
+
 //counterfeiter:generate . Provider
 // Provider represents actions taken against AWS
 type Provider interface {
@@ -50,6 +56,7 @@ type Provider interface {
 	CheckForWhitelistedIP(ip, securityGroup string) (bool, error)
 	CreateBucket(name string) error
 	CreateDatabases(name, username, password string) error
+	DeleteFile(bucket, path string) error
 	DeleteVersionedBucket(name string) error
 	DeleteVMsInDeployment(zone, project, deployment string) error
 	DeleteVMsInVPC(vpcID string) ([]string, error)
@@ -59,8 +66,12 @@ type Provider interface {
 	HasFile(bucket, path string) (bool, error)
 	DBType(name string) string
 	IAAS() Name
+	ListBuckets(prefix string) ([]Bucket, error)
 	LoadFile(bucket, path string) ([]byte, error)
 	Region() string
+	RestoreDB(instance, snapshot, replaced string) error
+	SnapshotDB(instance, name string) (string, error)
+	UndoRestoreDB(instance, replaced string) error
 	WriteFile(bucket, path string, contents []byte) error
 	Zone(string, string) string
 	Choose(Choice) interface{}
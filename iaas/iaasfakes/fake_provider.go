@@ -94,6 +94,18 @@ type FakeProvider struct {
 	dBTypeReturnsOnCall map[int]struct {
 		result1 string
 	}
+	DeleteFileStub        func(string, string) error
+	deleteFileMutex       sync.RWMutex
+	deleteFileArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteFileReturns struct {
+		result1 error
+	}
+	deleteFileReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteVMsInDeploymentStub        func(string, string, string) error
 	deleteVMsInDeploymentMutex       sync.RWMutex
 	deleteVMsInDeploymentArgsForCall []struct {
@@ -199,6 +211,19 @@ type FakeProvider struct {
 	iAASReturnsOnCall map[int]struct {
 		result1 iaas.Name
 	}
+	ListBucketsStub        func(string) ([]iaas.Bucket, error)
+	listBucketsMutex       sync.RWMutex
+	listBucketsArgsForCall []struct {
+		arg1 string
+	}
+	listBucketsReturns struct {
+		result1 []iaas.Bucket
+		result2 error
+	}
+	listBucketsReturnsOnCall map[int]struct {
+		result1 []iaas.Bucket
+		result2 error
+	}
 	LoadFileStub        func(string, string) ([]byte, error)
 	loadFileMutex       sync.RWMutex
 	loadFileArgsForCall []struct {
@@ -223,6 +248,45 @@ type FakeProvider struct {
 	regionReturnsOnCall map[int]struct {
 		result1 string
 	}
+	RestoreDBStub        func(string, string, string) error
+	restoreDBMutex       sync.RWMutex
+	restoreDBArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	restoreDBReturns struct {
+		result1 error
+	}
+	restoreDBReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SnapshotDBStub        func(string, string) (string, error)
+	snapshotDBMutex       sync.RWMutex
+	snapshotDBArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	snapshotDBReturns struct {
+		result1 string
+		result2 error
+	}
+	snapshotDBReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	UndoRestoreDBStub        func(string, string) error
+	undoRestoreDBMutex       sync.RWMutex
+	undoRestoreDBArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	undoRestoreDBReturns struct {
+		result1 error
+	}
+	undoRestoreDBReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WriteFileStub        func(string, string, []byte) error
 	writeFileMutex       sync.RWMutex
 	writeFileArgsForCall []struct {
@@ -691,6 +755,68 @@ func (fake *FakeProvider) DBTypeReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeProvider) DeleteFile(arg1 string, arg2 string) error {
+	fake.deleteFileMutex.Lock()
+	ret, specificReturn := fake.deleteFileReturnsOnCall[len(fake.deleteFileArgsForCall)]
+	fake.deleteFileArgsForCall = append(fake.deleteFileArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteFileStub
+	fakeReturns := fake.deleteFileReturns
+	fake.recordInvocation("DeleteFile", []interface{}{arg1, arg2})
+	fake.deleteFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeProvider) DeleteFileCallCount() int {
+	fake.deleteFileMutex.RLock()
+	defer fake.deleteFileMutex.RUnlock()
+	return len(fake.deleteFileArgsForCall)
+}
+
+func (fake *FakeProvider) DeleteFileCalls(stub func(string, string) error) {
+	fake.deleteFileMutex.Lock()
+	defer fake.deleteFileMutex.Unlock()
+	fake.DeleteFileStub = stub
+}
+
+func (fake *FakeProvider) DeleteFileArgsForCall(i int) (string, string) {
+	fake.deleteFileMutex.RLock()
+	defer fake.deleteFileMutex.RUnlock()
+	argsForCall := fake.deleteFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeProvider) DeleteFileReturns(result1 error) {
+	fake.deleteFileMutex.Lock()
+	defer fake.deleteFileMutex.Unlock()
+	fake.DeleteFileStub = nil
+	fake.deleteFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProvider) DeleteFileReturnsOnCall(i int, result1 error) {
+	fake.deleteFileMutex.Lock()
+	defer fake.deleteFileMutex.Unlock()
+	fake.DeleteFileStub = nil
+	if fake.deleteFileReturnsOnCall == nil {
+		fake.deleteFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeProvider) DeleteVMsInDeployment(arg1 string, arg2 string, arg3 string) error {
 	fake.deleteVMsInDeploymentMutex.Lock()
 	ret, specificReturn := fake.deleteVMsInDeploymentReturnsOnCall[len(fake.deleteVMsInDeploymentArgsForCall)]
@@ -1205,6 +1331,70 @@ func (fake *FakeProvider) IAASReturnsOnCall(i int, result1 iaas.Name) {
 	}{result1}
 }
 
+func (fake *FakeProvider) ListBuckets(arg1 string) ([]iaas.Bucket, error) {
+	fake.listBucketsMutex.Lock()
+	ret, specificReturn := fake.listBucketsReturnsOnCall[len(fake.listBucketsArgsForCall)]
+	fake.listBucketsArgsForCall = append(fake.listBucketsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListBucketsStub
+	fakeReturns := fake.listBucketsReturns
+	fake.recordInvocation("ListBuckets", []interface{}{arg1})
+	fake.listBucketsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeProvider) ListBucketsCallCount() int {
+	fake.listBucketsMutex.RLock()
+	defer fake.listBucketsMutex.RUnlock()
+	return len(fake.listBucketsArgsForCall)
+}
+
+func (fake *FakeProvider) ListBucketsCalls(stub func(string) ([]iaas.Bucket, error)) {
+	fake.listBucketsMutex.Lock()
+	defer fake.listBucketsMutex.Unlock()
+	fake.ListBucketsStub = stub
+}
+
+func (fake *FakeProvider) ListBucketsArgsForCall(i int) string {
+	fake.listBucketsMutex.RLock()
+	defer fake.listBucketsMutex.RUnlock()
+	argsForCall := fake.listBucketsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeProvider) ListBucketsReturns(result1 []iaas.Bucket, result2 error) {
+	fake.listBucketsMutex.Lock()
+	defer fake.listBucketsMutex.Unlock()
+	fake.ListBucketsStub = nil
+	fake.listBucketsReturns = struct {
+		result1 []iaas.Bucket
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProvider) ListBucketsReturnsOnCall(i int, result1 []iaas.Bucket, result2 error) {
+	fake.listBucketsMutex.Lock()
+	defer fake.listBucketsMutex.Unlock()
+	fake.ListBucketsStub = nil
+	if fake.listBucketsReturnsOnCall == nil {
+		fake.listBucketsReturnsOnCall = make(map[int]struct {
+			result1 []iaas.Bucket
+			result2 error
+		})
+	}
+	fake.listBucketsReturnsOnCall[i] = struct {
+		result1 []iaas.Bucket
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeProvider) LoadFile(arg1 string, arg2 string) ([]byte, error) {
 	fake.loadFileMutex.Lock()
 	ret, specificReturn := fake.loadFileReturnsOnCall[len(fake.loadFileArgsForCall)]
@@ -1323,6 +1513,196 @@ func (fake *FakeProvider) RegionReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeProvider) RestoreDB(arg1 string, arg2 string, arg3 string) error {
+	fake.restoreDBMutex.Lock()
+	ret, specificReturn := fake.restoreDBReturnsOnCall[len(fake.restoreDBArgsForCall)]
+	fake.restoreDBArgsForCall = append(fake.restoreDBArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RestoreDBStub
+	fakeReturns := fake.restoreDBReturns
+	fake.recordInvocation("RestoreDB", []interface{}{arg1, arg2, arg3})
+	fake.restoreDBMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeProvider) RestoreDBCallCount() int {
+	fake.restoreDBMutex.RLock()
+	defer fake.restoreDBMutex.RUnlock()
+	return len(fake.restoreDBArgsForCall)
+}
+
+func (fake *FakeProvider) RestoreDBCalls(stub func(string, string, string) error) {
+	fake.restoreDBMutex.Lock()
+	defer fake.restoreDBMutex.Unlock()
+	fake.RestoreDBStub = stub
+}
+
+func (fake *FakeProvider) RestoreDBArgsForCall(i int) (string, string, string) {
+	fake.restoreDBMutex.RLock()
+	defer fake.restoreDBMutex.RUnlock()
+	argsForCall := fake.restoreDBArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeProvider) RestoreDBReturns(result1 error) {
+	fake.restoreDBMutex.Lock()
+	defer fake.restoreDBMutex.Unlock()
+	fake.RestoreDBStub = nil
+	fake.restoreDBReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProvider) RestoreDBReturnsOnCall(i int, result1 error) {
+	fake.restoreDBMutex.Lock()
+	defer fake.restoreDBMutex.Unlock()
+	fake.RestoreDBStub = nil
+	if fake.restoreDBReturnsOnCall == nil {
+		fake.restoreDBReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.restoreDBReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProvider) SnapshotDB(arg1 string, arg2 string) (string, error) {
+	fake.snapshotDBMutex.Lock()
+	ret, specificReturn := fake.snapshotDBReturnsOnCall[len(fake.snapshotDBArgsForCall)]
+	fake.snapshotDBArgsForCall = append(fake.snapshotDBArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SnapshotDBStub
+	fakeReturns := fake.snapshotDBReturns
+	fake.recordInvocation("SnapshotDB", []interface{}{arg1, arg2})
+	fake.snapshotDBMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeProvider) SnapshotDBCallCount() int {
+	fake.snapshotDBMutex.RLock()
+	defer fake.snapshotDBMutex.RUnlock()
+	return len(fake.snapshotDBArgsForCall)
+}
+
+func (fake *FakeProvider) SnapshotDBCalls(stub func(string, string) (string, error)) {
+	fake.snapshotDBMutex.Lock()
+	defer fake.snapshotDBMutex.Unlock()
+	fake.SnapshotDBStub = stub
+}
+
+func (fake *FakeProvider) SnapshotDBArgsForCall(i int) (string, string) {
+	fake.snapshotDBMutex.RLock()
+	defer fake.snapshotDBMutex.RUnlock()
+	argsForCall := fake.snapshotDBArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeProvider) SnapshotDBReturns(result1 string, result2 error) {
+	fake.snapshotDBMutex.Lock()
+	defer fake.snapshotDBMutex.Unlock()
+	fake.SnapshotDBStub = nil
+	fake.snapshotDBReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProvider) SnapshotDBReturnsOnCall(i int, result1 string, result2 error) {
+	fake.snapshotDBMutex.Lock()
+	defer fake.snapshotDBMutex.Unlock()
+	fake.SnapshotDBStub = nil
+	if fake.snapshotDBReturnsOnCall == nil {
+		fake.snapshotDBReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.snapshotDBReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProvider) UndoRestoreDB(arg1 string, arg2 string) error {
+	fake.undoRestoreDBMutex.Lock()
+	ret, specificReturn := fake.undoRestoreDBReturnsOnCall[len(fake.undoRestoreDBArgsForCall)]
+	fake.undoRestoreDBArgsForCall = append(fake.undoRestoreDBArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UndoRestoreDBStub
+	fakeReturns := fake.undoRestoreDBReturns
+	fake.recordInvocation("UndoRestoreDB", []interface{}{arg1, arg2})
+	fake.undoRestoreDBMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeProvider) UndoRestoreDBCallCount() int {
+	fake.undoRestoreDBMutex.RLock()
+	defer fake.undoRestoreDBMutex.RUnlock()
+	return len(fake.undoRestoreDBArgsForCall)
+}
+
+func (fake *FakeProvider) UndoRestoreDBCalls(stub func(string, string) error) {
+	fake.undoRestoreDBMutex.Lock()
+	defer fake.undoRestoreDBMutex.Unlock()
+	fake.UndoRestoreDBStub = stub
+}
+
+func (fake *FakeProvider) UndoRestoreDBArgsForCall(i int) (string, string) {
+	fake.undoRestoreDBMutex.RLock()
+	defer fake.undoRestoreDBMutex.RUnlock()
+	argsForCall := fake.undoRestoreDBArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeProvider) UndoRestoreDBReturns(result1 error) {
+	fake.undoRestoreDBMutex.Lock()
+	defer fake.undoRestoreDBMutex.Unlock()
+	fake.UndoRestoreDBStub = nil
+	fake.undoRestoreDBReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeProvider) UndoRestoreDBReturnsOnCall(i int, result1 error) {
+	fake.undoRestoreDBMutex.Lock()
+	defer fake.undoRestoreDBMutex.Unlock()
+	fake.UndoRestoreDBStub = nil
+	if fake.undoRestoreDBReturnsOnCall == nil {
+		fake.undoRestoreDBReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.undoRestoreDBReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeProvider) WriteFile(arg1 string, arg2 string, arg3 []byte) error {
 	var arg3Copy []byte
 	if arg3 != nil {
@@ -1470,6 +1850,8 @@ func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	defer fake.createDatabasesMutex.RUnlock()
 	fake.dBTypeMutex.RLock()
 	defer fake.dBTypeMutex.RUnlock()
+	fake.deleteFileMutex.RLock()
+	defer fake.deleteFileMutex.RUnlock()
 	fake.deleteVMsInDeploymentMutex.RLock()
 	defer fake.deleteVMsInDeploymentMutex.RUnlock()
 	fake.deleteVMsInVPCMutex.RLock()
@@ -1486,10 +1868,18 @@ func (fake *FakeProvider) Invocations() map[string][][]interface{} {
 	defer fake.hasFileMutex.RUnlock()
 	fake.iAASMutex.RLock()
 	defer fake.iAASMutex.RUnlock()
+	fake.listBucketsMutex.RLock()
+	defer fake.listBucketsMutex.RUnlock()
 	fake.loadFileMutex.RLock()
 	defer fake.loadFileMutex.RUnlock()
 	fake.regionMutex.RLock()
 	defer fake.regionMutex.RUnlock()
+	fake.restoreDBMutex.RLock()
+	defer fake.restoreDBMutex.RUnlock()
+	fake.snapshotDBMutex.RLock()
+	defer fake.snapshotDBMutex.RUnlock()
+	fake.undoRestoreDBMutex.RLock()
+	defer fake.undoRestoreDBMutex.RUnlock()
 	fake.writeFileMutex.RLock()
 	defer fake.writeFileMutex.RUnlock()
 	fake.zoneMutex.RLock()
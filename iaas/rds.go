@@ -0,0 +1,236 @@
+package iaas
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// rdsWaiterOptions waits up to 2 hours, checking every 30 seconds
+func rdsWaiterOptions(w *request.Waiter) {
+	w.MaxAttempts = 240
+	w.Delay = func(_ int) time.Duration { return time.Second * 30 }
+}
+
+// SnapshotDB takes a snapshot of an RDS instance called name and waits for it to become available
+func (a *AWSProvider) SnapshotDB(instance, name string) (string, error) {
+	rdsClient := rds.New(a.sess)
+
+	fmt.Printf("Creating snapshot %s of RDS instance %s\n", name, instance)
+	if _, err := rdsClient.CreateDBSnapshot(&rds.CreateDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(instance),
+		DBSnapshotIdentifier: aws.String(name),
+	}); err != nil {
+		return "", fmt.Errorf("create snapshot of %s: %w", instance, err)
+	}
+
+	if err := rdsClient.WaitUntilDBSnapshotAvailableWithContext(
+		context.Background(),
+		&rds.DescribeDBSnapshotsInput{DBSnapshotIdentifier: aws.String(name)},
+		rdsWaiterOptions,
+	); err != nil {
+		return "", fmt.Errorf("wait for snapshot %s: %w", name, err)
+	}
+
+	return name, nil
+}
+
+// RestoreDB replaces an RDS instance with one restored from a snapshot. RDS can't restore into an
+// existing instance, so it is renamed to replaced and left to be deleted once the restore has been
+// checked. The restored instance takes the original name, and so address. Running it again with the
+// same replaced name continues a restore which was interrupted.
+func (a *AWSProvider) RestoreDB(instance, snapshot, replaced string) error {
+	rdsClient := rds.New(a.sess)
+
+	current, err := describeDBInstance(rdsClient, replaced)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		if current, err = describeDBInstance(rdsClient, instance); err != nil {
+			return err
+		}
+		if current == nil {
+			return fmt.Errorf("RDS instance %s not found", instance)
+		}
+
+		fmt.Printf("Renaming RDS instance %s to %s\n", instance, replaced)
+		if _, err = rdsClient.ModifyDBInstance(&rds.ModifyDBInstanceInput{
+			DBInstanceIdentifier:    aws.String(instance),
+			NewDBInstanceIdentifier: aws.String(replaced),
+			ApplyImmediately:        aws.Bool(true),
+		}); err != nil {
+			return fmt.Errorf("rename RDS instance %s: %w", instance, err)
+		}
+		if err = waitForRenamedDBInstance(rdsClient, replaced); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("RDS instance %s was already renamed to %s\n", instance, replaced)
+	}
+
+	restored, err := describeDBInstance(rdsClient, instance)
+	if err != nil {
+		return err
+	}
+	if restored == nil {
+		if err = restoreDBInstance(rdsClient, current, instance, snapshot); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("RDS instance %s is already being restored\n", instance)
+	}
+
+	if err = waitForDBInstance(rdsClient, instance); err != nil {
+		return err
+	}
+
+	// These settings can't be given when restoring, so are applied to the restored instance afterwards
+	fmt.Printf("Applying the settings of %s to RDS instance %s\n", replaced, instance)
+	if _, err = rdsClient.ModifyDBInstance(&rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier:    aws.String(instance),
+		CACertificateIdentifier: current.CACertificateIdentifier,
+		BackupRetentionPeriod:   current.BackupRetentionPeriod,
+		ApplyImmediately:        aws.Bool(true),
+	}); err != nil {
+		return fmt.Errorf("apply the settings of %s to RDS instance %s: %w", replaced, instance, err)
+	}
+	return waitForDBInstance(rdsClient, instance)
+}
+
+// UndoRestoreDB puts back an RDS instance which RestoreDB renamed to replaced, deleting any instance
+// restored in its place. It does nothing if the instance was never renamed, or has already been put back.
+func (a *AWSProvider) UndoRestoreDB(instance, replaced string) error {
+	rdsClient := rds.New(a.sess)
+
+	original, err := describeDBInstance(rdsClient, replaced)
+	if err != nil || original == nil {
+		return err
+	}
+
+	restored, err := describeDBInstance(rdsClient, instance)
+	if err != nil {
+		return err
+	}
+	if restored != nil {
+		fmt.Printf("Deleting RDS instance %s restored in place of %s\n", instance, replaced)
+		if _, err = rdsClient.DeleteDBInstance(&rds.DeleteDBInstanceInput{
+			DBInstanceIdentifier:   aws.String(instance),
+			SkipFinalSnapshot:      aws.Bool(true),
+			DeleteAutomatedBackups: aws.Bool(true),
+		}); err != nil && !isDBInstanceNotFound(err) {
+			return fmt.Errorf("delete RDS instance %s: %w", instance, err)
+		}
+		if err = rdsClient.WaitUntilDBInstanceDeletedWithContext(
+			context.Background(),
+			&rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(instance)},
+			rdsWaiterOptions,
+		); err != nil {
+			return fmt.Errorf("wait for RDS instance %s to be deleted: %w", instance, err)
+		}
+	}
+
+	fmt.Printf("Renaming RDS instance %s back to %s\n", replaced, instance)
+	if _, err = rdsClient.ModifyDBInstance(&rds.ModifyDBInstanceInput{
+		DBInstanceIdentifier:    aws.String(replaced),
+		NewDBInstanceIdentifier: aws.String(instance),
+		ApplyImmediately:        aws.Bool(true),
+	}); err != nil {
+		return fmt.Errorf("rename RDS instance %s: %w", replaced, err)
+	}
+	return waitForRenamedDBInstance(rdsClient, instance)
+}
+
+// restoreDBInstance starts restoring a snapshot into a new instance, with the settings of the instance it replaces
+func restoreDBInstance(rdsClient *rds.RDS, current *rds.DBInstance, instance, snapshot string) error {
+	var securityGroupIDs []*string
+	for _, group := range current.VpcSecurityGroups {
+		securityGroupIDs = append(securityGroupIDs, group.VpcSecurityGroupId)
+	}
+	var parameterGroupName *string
+	if len(current.DBParameterGroups) > 0 {
+		parameterGroupName = current.DBParameterGroups[0].DBParameterGroupName
+	}
+	tags, err := rdsClient.ListTagsForResource(&rds.ListTagsForResourceInput{
+		ResourceName: current.DBInstanceArn,
+	})
+	if err != nil {
+		return fmt.Errorf("list tags of RDS instance %s: %w", aws.StringValue(current.DBInstanceIdentifier), err)
+	}
+
+	fmt.Printf("Restoring RDS instance %s from snapshot %s\n", instance, snapshot)
+	if _, err = rdsClient.RestoreDBInstanceFromDBSnapshot(&rds.RestoreDBInstanceFromDBSnapshotInput{
+		DBInstanceIdentifier: aws.String(instance),
+		DBSnapshotIdentifier: aws.String(snapshot),
+		DBInstanceClass:      current.DBInstanceClass,
+		DBSubnetGroupName:    current.DBSubnetGroup.DBSubnetGroupName,
+		DBParameterGroupName: parameterGroupName,
+		VpcSecurityGroupIds:  securityGroupIDs,
+		PubliclyAccessible:   current.PubliclyAccessible,
+		MultiAZ:              current.MultiAZ,
+		StorageType:          current.StorageType,
+		Tags:                 tags.TagList,
+	}); err != nil {
+		return fmt.Errorf("restore RDS instance %s from snapshot %s: %w", instance, snapshot, err)
+	}
+	return nil
+}
+
+// describeDBInstance returns the instance with an identifier, or nil if there isn't one
+func describeDBInstance(rdsClient *rds.RDS, instance string) (*rds.DBInstance, error) {
+	output, err := rdsClient.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(instance),
+	})
+	if isDBInstanceNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("describe RDS instance %s: %w", instance, err)
+	}
+	if len(output.DBInstances) == 0 {
+		return nil, nil
+	}
+	return output.DBInstances[0], nil
+}
+
+func isDBInstanceNotFound(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == rds.ErrCodeDBInstanceNotFoundFault
+}
+
+func waitForDBInstance(rdsClient *rds.RDS, instance string) error {
+	if err := rdsClient.WaitUntilDBInstanceAvailableWithContext(
+		context.Background(),
+		&rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(instance)},
+		rdsWaiterOptions,
+	); err != nil {
+		return fmt.Errorf("wait for RDS instance %s: %w", instance, err)
+	}
+	return nil
+}
+
+// waitForRenamedDBInstance waits for an instance to be available under its new name, which it only has once the rename is applied
+func waitForRenamedDBInstance(rdsClient *rds.RDS, renamed string) error {
+	start := time.Now().UTC()
+	for {
+		output, err := rdsClient.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+			DBInstanceIdentifier: aws.String(renamed),
+		})
+		if err == nil && len(output.DBInstances) > 0 && aws.StringValue(output.DBInstances[0].DBInstanceStatus) == "available" {
+			return nil
+		}
+		if err != nil && !isDBInstanceNotFound(err) {
+			return fmt.Errorf("describe RDS instance %s: %w", renamed, err)
+		}
+		if time.Since(start) > time.Minute*30 {
+			return fmt.Errorf("RDS instance %s not available after 30 minutes", renamed)
+		}
+		fmt.Printf("Waiting for RDS instance %s to be renamed\n", renamed)
+		time.Sleep(time.Second * 30)
+	}
+}
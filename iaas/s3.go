@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"io/ioutil"
+	"strings"
 
 	"time"
 
@@ -123,6 +124,38 @@ func (client *AWSProvider) BucketExists(name string) (bool, error) {
 	return false, nil
 }
 
+// ListBuckets returns every bucket in the account whose name starts with prefix,
+// along with the region each bucket was created in
+func (client *AWSProvider) ListBuckets(prefix string) ([]Bucket, error) {
+
+	s3Client := s3.New(client.sess)
+
+	output, err := s3Client.ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing S3 buckets: [%v]", err)
+	}
+
+	buckets := []Bucket{}
+	for _, b := range output.Buckets {
+		name := aws.StringValue(b.Name)
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		location, err := s3Client.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: b.Name})
+		if err != nil {
+			return nil, fmt.Errorf("error determining location of S3 bucket [%v]: [%v]", name, err)
+		}
+
+		buckets = append(buckets, Bucket{
+			Name:   name,
+			Region: s3.NormalizeBucketLocation(aws.StringValue(location.LocationConstraint)),
+		})
+	}
+
+	return buckets, nil
+}
+
 // WriteFile writes the specified S3 object
 func (client *AWSProvider) WriteFile(bucket, path string, contents []byte) error {
 	s3Client := s3.New(client.sess)
@@ -13,9 +13,14 @@ var _ = Describe("control-tower", func() {
 		Expect(err).NotTo(HaveOccurred())
 		outputStr := string(output)
 		Expect(outputStr).To(ContainSubstring("Control-Tower - A CLI tool to deploy Concourse CI"), outputStr)
+		Expect(outputStr).To(ContainSubstring("backup, b    Writes all state of a deployment to an encrypted backup file"), outputStr)
+		Expect(outputStr).To(ContainSubstring("config       Works with the configuration of a deployment"), outputStr)
 		Expect(outputStr).To(ContainSubstring("deploy, d    Deploys or updates a Concourse"), outputStr)
 		Expect(outputStr).To(ContainSubstring("destroy, x   Destroys a Concourse"), outputStr)
 		Expect(outputStr).To(ContainSubstring("info, i      Fetches information on a deployed environment"), outputStr)
+		Expect(outputStr).To(ContainSubstring("list, l      Lists all deployments found in the account"), outputStr)
 		Expect(outputStr).To(ContainSubstring("maintain, m  Handles maintenance operations in control-tower"), outputStr)
+		Expect(outputStr).To(ContainSubstring("restore      Restores all state of a deployment from an encrypted backup file"), outputStr)
+		Expect(outputStr).To(ContainSubstring("upgrade-all  Upgrades every deployment in the account to this version of control-tower"), outputStr)
 	})
 })
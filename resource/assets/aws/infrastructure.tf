@@ -1,9 +1,19 @@
 terraform {
+{{- if eq .StateBackend "local" }}
+	backend "local" {
+		path = "{{ .StateDir }}/{{ .TFStatePath }}"
+	}
+{{- else if eq .StateBackend "gcs" }}
+	backend "gcs" {
+		bucket = "{{ .ConfigBucket }}"
+	}
+{{- else }}
 	backend "s3" {
 		bucket = "{{ .ConfigBucket }}"
 		key    = "{{ .TFStatePath }}"
-		region = "{{ .Region }}"
+		region = "{{ if .StateRegion }}{{ .StateRegion }}{{ else }}{{ .Region }}{{ end }}"
 	}
+{{- end }}
 }
 
 data "aws_availability_zones" "available" {
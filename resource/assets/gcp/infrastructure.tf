@@ -87,9 +87,21 @@ provider "google" {
 
 
 terraform {
+{{- if eq .StateBackend "local" }}
+	backend "local" {
+		path = "{{ .StateDir }}/terraform.tfstate"
+	}
+{{- else if eq .StateBackend "s3" }}
+	backend "s3" {
+		bucket = "{{ .ConfigBucket }}"
+		key    = "terraform.tfstate"
+		region = "{{ .StateRegion }}"
+	}
+{{- else }}
 	backend "gcs" {
 		bucket = "{{ .ConfigBucket }}"
 	}
+{{- end }}
 
     required_providers {
       google = {
@@ -77,6 +77,10 @@ var (
 	//go:embed assets/maintenance/cleanup-certs.yml
 	CleanupCerts string
 
+	// AddNewDirectorCa carries the ops file that adds a new default CA and mbus certificate required for director cert renewal
+	//go:embed assets/maintenance/add-new-director-ca.yml
+	AddNewDirectorCa string
+
 	AWSVersionFile = opsassets.AWSVersionFile
 	GCPVersionFile = opsassets.GCPVersionFile
 
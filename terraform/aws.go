@@ -34,6 +34,9 @@ type AWSInputVars struct {
 	RDS2CIDR               string
 	Region                 string
 	SourceAccessIP         string
+	StateBackend           string
+	StateDir               string
+	StateRegion            string
 	TFStatePath            string
 }
 
@@ -56,11 +59,11 @@ type AWSOutputs struct {
 	ATCPublicIP               MetadataStringValue `json:"atc_public_ip" valid:"required"`
 	ATCSecurityGroupID        MetadataStringValue `json:"atc_security_group_id" valid:"required"`
 	BlobstoreBucket           MetadataStringValue `json:"blobstore_bucket" valid:"required"`
-	BlobstoreSecretAccessKey  MetadataStringValue `json:"blobstore_user_secret_access_key" valid:"required"`
+	BlobstoreSecretAccessKey  MetadataStringValue `json:"blobstore_user_secret_access_key" valid:"required" secret:"true"`
 	BlobstoreUserAccessKeyID  MetadataStringValue `json:"blobstore_user_access_key_id" valid:"required"`
 	BoshDBAddress             MetadataStringValue `json:"bosh_db_address" valid:"required"`
 	BoshDBPort                MetadataStringValue `json:"bosh_db_port" valid:"required"`
-	BoshSecretAccessKey       MetadataStringValue `json:"bosh_user_secret_access_key" valid:"required"`
+	BoshSecretAccessKey       MetadataStringValue `json:"bosh_user_secret_access_key" valid:"required" secret:"true"`
 	BoshUserAccessKeyID       MetadataStringValue `json:"bosh_user_access_key_id" valid:"required"`
 	DirectorKeyPair           MetadataStringValue `json:"director_key_pair" valid:"required"`
 	DirectorPublicIP          MetadataStringValue `json:"director_public_ip" valid:"required"`
@@ -68,7 +71,7 @@ type AWSOutputs struct {
 	NatGatewayIP              MetadataStringValue `json:"nat_gateway_ip" valid:"required"`
 	PrivateSubnetID           MetadataStringValue `json:"private_subnet_id" valid:"required"`
 	PublicSubnetID            MetadataStringValue `json:"public_subnet_id" valid:"required"`
-	SelfUpdateSecretAccessKey MetadataStringValue `json:"self_update_user_secret_access_key" valid:"required"`
+	SelfUpdateSecretAccessKey MetadataStringValue `json:"self_update_user_secret_access_key" valid:"required" secret:"true"`
 	SelfUpdateUserAccessKeyID MetadataStringValue `json:"self_update_user_access_key_id" valid:"required"`
 	SourceAccessIP            MetadataStringValue `json:"source_access_ip"`
 	VMsSecurityGroupID        MetadataStringValue `json:"vms_security_group_id" valid:"required"`
@@ -29,6 +29,9 @@ type GCPInputVars struct {
 	Project            string
 	PublicCIDR         string
 	Region             string
+	StateBackend       string
+	StateDir           string
+	StateRegion        string
 	Tags               string
 	Zone               string
 }
@@ -47,7 +50,7 @@ type GCPOutputs struct {
 	ATCPublicIP                 MetadataStringValue `json:"atc_public_ip" valid:"required"`
 	BoshDBAddress               MetadataStringValue `json:"bosh_db_address" valid:"required"`
 	DBName                      MetadataStringValue `json:"db_name" valid:"required"`
-	DirectorAccountCreds        MetadataStringValue `json:"director_account_creds" valid:"required"`
+	DirectorAccountCreds        MetadataStringValue `json:"director_account_creds" valid:"required" secret:"true"`
 	DirectorPublicIP            MetadataStringValue `json:"director_public_ip" valid:"required"`
 	DirectorSecurityGroupID     MetadataStringValue `json:"director_firewall_name" valid:"required"`
 	NatGatewayIP                MetadataStringValue `json:"nat_gateway_ip" valid:"required"`
@@ -56,7 +59,7 @@ type GCPOutputs struct {
 	PrivateSubnetworkName       MetadataStringValue `json:"private_subnetwork_name" valid:"required"`
 	PublicSubnetworkInternalGw  MetadataStringValue `json:"public_subnetwork_internal_gw" valid:"required"`
 	PublicSubnetworkName        MetadataStringValue `json:"public_subnetwork_name" valid:"required"`
-	SelfUpdateAccountCreds      MetadataStringValue `json:"self_update_account_creds" valid:"required"`
+	SelfUpdateAccountCreds      MetadataStringValue `json:"self_update_account_creds" valid:"required" secret:"true"`
 	SQLServerCert               MetadataStringValue `json:"server_ca_cert" valid:"required"`
 }
 
@@ -3,8 +3,10 @@ package terraform_test
 import (
 	"bytes"
 	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/EngineerBetter/control-tower/resource"
 	. "github.com/EngineerBetter/control-tower/terraform"
 )
 
@@ -155,3 +157,55 @@ func TestGCPMetadata_Init(t *testing.T) {
 		})
 	}
 }
+
+func TestGCPInputVars_StateBackend(t *testing.T) {
+	tests := []struct {
+		name         string
+		stateBackend string
+		stateRegion  string
+		stateDir     string
+		want         []string
+	}{
+		{
+			name:         "Default",
+			stateBackend: "",
+			stateRegion:  "",
+			stateDir:     "",
+			want:         []string{`backend "gcs"`},
+		},
+		{
+			name:         "S3",
+			stateBackend: "s3",
+			stateRegion:  "us-east-1",
+			stateDir:     "",
+			want:         []string{`backend "s3"`, `region = "us-east-1"`},
+		},
+		{
+			name:         "Local",
+			stateBackend: "local",
+			stateRegion:  "",
+			stateDir:     "/state/bucket",
+			want:         []string{`backend "local"`, `path = "/state/bucket/terraform.tfstate"`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := &GCPInputVars{
+				ConfigBucket: "bucket",
+				Region:       "europe-west1",
+				StateBackend: test.stateBackend,
+				StateDir:     test.stateDir,
+				StateRegion:  test.stateRegion,
+			}
+			got, err := v.ConfigureTerraform(resource.GCPTerraformConfig)
+			if err != nil {
+				t.Fatalf("InputVars.ConfigureTerraform() test case \"%s\" failed\nReturned error %v", test.name, err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("InputVars.ConfigureTerraform() test case \"%s\" failed\nExpected output to contain \"%s\"", test.name, want)
+				}
+			}
+		})
+	}
+}
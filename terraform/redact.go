@@ -0,0 +1,35 @@
+package terraform
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/EngineerBetter/control-tower/config"
+)
+
+var metadataStringValueType = reflect.TypeOf(MetadataStringValue{})
+
+// Redact returns the value of each output by its terraform name, with every non-empty output tagged
+// `secret:"true"` replaced by config.RedactedValue, so that they can be shared safely
+func Redact(outputs Outputs) map[string]string {
+	redacted := map[string]string{}
+	v := reflect.ValueOf(outputs)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return redacted
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type != metadataStringValueType {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		value := v.Field(i).Interface().(MetadataStringValue).Value
+		if field.Tag.Get("secret") == "true" && value != "" {
+			value = config.RedactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
@@ -0,0 +1,59 @@
+package terraform_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/EngineerBetter/control-tower/terraform"
+	"github.com/EngineerBetter/control-tower/terraform/terraformfakes"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs Outputs
+		want    map[string]string
+	}{
+		{
+			name: "AWS",
+			outputs: &AWSOutputs{
+				ATCPublicIP:              MetadataStringValue{Value: "1.2.3.4"},
+				BoshSecretAccessKey:      MetadataStringValue{Value: "s3cret"},
+				BlobstoreSecretAccessKey: MetadataStringValue{},
+			},
+			want: map[string]string{
+				"atc_public_ip":               "1.2.3.4",
+				"bosh_user_secret_access_key": "REDACTED",
+			},
+		},
+		{
+			name: "GCP",
+			outputs: &GCPOutputs{
+				DirectorPublicIP:     MetadataStringValue{Value: "5.6.7.8"},
+				DirectorAccountCreds: MetadataStringValue{Value: `{"private_key":"s3cret"}`},
+			},
+			want: map[string]string{
+				"director_public_ip":     "5.6.7.8",
+				"director_account_creds": "REDACTED",
+			},
+		},
+		{
+			name:    "not a terraform outputs struct",
+			outputs: &terraformfakes.FakeOutputs{},
+			want:    map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Redact(tt.outputs)
+			for name, value := range got {
+				if value == "" {
+					delete(got, name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Redact() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
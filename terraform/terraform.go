@@ -35,6 +35,7 @@ type Outputs interface {
 //CLIInterface is the abstraction of execCmd
 type CLIInterface interface {
 	Apply(InputVars) error
+	Plan(InputVars) error
 	Destroy(InputVars) error
 	BuildOutput(InputVars) (Outputs, error)
 }
@@ -164,6 +165,24 @@ func (c *CLI) Apply(config InputVars) error {
 	return cmd.Run()
 }
 
+// Plan runs terraform plan for a given config, printing the changes that apply would make
+func (c *CLI) Plan(config InputVars) error {
+	terraformConfigPath, err := c.init(config)
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(terraformConfigPath)
+
+	cmd := c.execCmd(c.Path, "plan", "-input=false")
+	cmd.Dir = terraformConfigPath
+
+	cmd.Stderr = os.Stderr
+	cmd.Stdout = os.Stdout
+
+	return cmd.Run()
+}
+
 // Destroy destroys terraform resources specified in a config file
 func (c *CLI) Destroy(config InputVars) error {
 	terraformConfigPath, err := c.init(config)
@@ -75,6 +75,29 @@ func TestCLI_ApplyPlan(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCLI_Plan(t *testing.T) {
+	e := fakeexec.New(t)
+	defer e.Finish()
+	mockCLIent, err := terraform.New(iaas.AWS, terraform.FakeExec(e.Cmd()))
+	require.NoError(t, err)
+
+	config := &mockTerraformInputVars{}
+
+	e.ExpectFunc(func(t testing.TB, command string, args ...string) {
+		require.Equal(t, "terraform", command)
+		require.Equal(t, args[0], "init")
+
+	})
+	e.ExpectFunc(func(t testing.TB, command string, args ...string) {
+		require.Equal(t, "terraform", command)
+		require.Equal(t, args[0], "plan")
+		require.Equal(t, args[1], "-input=false")
+		require.Len(t, args, 2)
+	})
+	err = mockCLIent.Plan(config)
+	require.NoError(t, err)
+}
+
 func TestCLI_Destroy(t *testing.T) {
 	e := fakeexec.New(t)
 	defer e.Finish()
@@ -43,6 +43,17 @@ type FakeCLIInterface struct {
 	destroyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PlanStub        func(terraform.InputVars) error
+	planMutex       sync.RWMutex
+	planArgsForCall []struct {
+		arg1 terraform.InputVars
+	}
+	planReturns struct {
+		result1 error
+	}
+	planReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -233,6 +244,67 @@ func (fake *FakeCLIInterface) DestroyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeCLIInterface) Plan(arg1 terraform.InputVars) error {
+	fake.planMutex.Lock()
+	ret, specificReturn := fake.planReturnsOnCall[len(fake.planArgsForCall)]
+	fake.planArgsForCall = append(fake.planArgsForCall, struct {
+		arg1 terraform.InputVars
+	}{arg1})
+	stub := fake.PlanStub
+	fakeReturns := fake.planReturns
+	fake.recordInvocation("Plan", []interface{}{arg1})
+	fake.planMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCLIInterface) PlanCallCount() int {
+	fake.planMutex.RLock()
+	defer fake.planMutex.RUnlock()
+	return len(fake.planArgsForCall)
+}
+
+func (fake *FakeCLIInterface) PlanCalls(stub func(terraform.InputVars) error) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = stub
+}
+
+func (fake *FakeCLIInterface) PlanArgsForCall(i int) terraform.InputVars {
+	fake.planMutex.RLock()
+	defer fake.planMutex.RUnlock()
+	argsForCall := fake.planArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCLIInterface) PlanReturns(result1 error) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = nil
+	fake.planReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCLIInterface) PlanReturnsOnCall(i int, result1 error) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = nil
+	if fake.planReturnsOnCall == nil {
+		fake.planReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.planReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCLIInterface) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -242,6 +314,8 @@ func (fake *FakeCLIInterface) Invocations() map[string][][]interface{} {
 	defer fake.buildOutputMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
+	fake.planMutex.RLock()
+	defer fake.planMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value